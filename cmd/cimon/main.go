@@ -2,17 +2,26 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"text/template"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lance0/cimon/internal/browser"
 	"github.com/lance0/cimon/internal/config"
 	"github.com/lance0/cimon/internal/gh"
 	"github.com/lance0/cimon/internal/git"
+	"github.com/lance0/cimon/internal/gitlab"
+	"github.com/lance0/cimon/internal/notify"
 	"github.com/lance0/cimon/internal/tui"
+	"github.com/muesli/termenv"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 )
 
 // Build variables (set by goreleaser)
@@ -38,14 +47,25 @@ func run() int {
 			return runCancel(args[1:])
 		case "dispatch":
 			return runDispatch(args[1:])
+		case "logs":
+			return runLogs(args[1:])
+		case "open":
+			return runOpenCommand(args[1:])
+		case "version":
+			return runVersion(args[1:])
+		case "doctor":
+			return runDoctor(args[1:])
 		case "help", "-h", "--help":
 			printUsage()
 			return 0
 		}
 	}
 
+	// Load config file; its defaults section stands in for flags not given
+	fileCfg, fileErr := config.LoadConfigFile(config.DefaultConfigPath())
+
 	// Parse CLI flags for TUI mode
-	cfg, err := config.Parse(args)
+	cfg, err := config.ParseWithDefaults(args, fileCfg.FlagDefaults())
 	if err != nil {
 		if err == config.ErrHelp {
 			return 0
@@ -56,16 +76,45 @@ func run() int {
 
 	// Handle --version
 	if cfg.Version {
-		fmt.Printf("cimon %s (%s) built %s\n", version, commit, date)
+		printVersion()
 		return 0
 	}
 
-	// Load config file if no --repos flag (v0.8)
-	if len(cfg.Repositories) == 0 {
-		fileCfg, fileErr := config.LoadConfigFile(config.DefaultConfigPath())
-		if fileErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: %v\n", fileErr)
-		} else if fileCfg != nil {
+	// Handle --clear-cache
+	if cfg.ClearCache {
+		dir, err := gh.DefaultLogCacheDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		if err := gh.NewLogCache(dir).Clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		fmt.Printf("Cleared log cache: %s\n", dir)
+		return 0
+	}
+
+	// --repos - and --repos-file lists replace the config file's repos
+	if cfg.ReposFile != "" {
+		specs, err := readReposFile(cfg.ReposFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		cfg.Repositories = specs
+	}
+
+	// The config file's repos are used only if there's no --repos flag (v0.8)
+	if fileErr != nil {
+		warn(cfg, "%v", fileErr)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if fileCfg != nil {
+		if len(cfg.Repositories) == 0 {
 			specs, specErr := fileCfg.ToRepoSpecs()
 			if specErr != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", specErr)
@@ -73,25 +122,60 @@ func run() int {
 			}
 			cfg.Repositories = specs
 		}
+		fileCfg.ApplyNotifyTemplates(cfg)
+		fileCfg.ApplyDefaultOwner(cfg)
+	}
+
+	// Invalid notification templates fall back to the defaults
+	for _, tmpl := range []string{cfg.NotifyTitleTemplate, cfg.NotifyBodyTemplate} {
+		if tmpl == "" {
+			continue
+		}
+		if err := notify.ValidateTemplate(tmpl); err != nil {
+			warn(cfg, "invalid notification template, using default: %v", err)
+		}
+	}
+
+	if cfg.IsMultiRepo() && cfg.HasRunSelection() {
+		fmt.Fprintf(os.Stderr, "Error: --run and --run-id need a single repository\n")
+		return 2
+	}
+	if cfg.IsMultiRepo() && cfg.Open {
+		fmt.Fprintf(os.Stderr, "Error: --open needs a single repository\n")
+		return 2
+	}
+	if cfg.IsMultiRepo() && cfg.BranchPattern != "" {
+		fmt.Fprintf(os.Stderr, "Error: --branch-pattern needs a single repository\n")
+		return 2
+	}
+	if cfg.IsMultiRepo() && cfg.WatchNew {
+		fmt.Fprintf(os.Stderr, "Error: --watch-new needs a single repository (multi-repo --watch already follows every repo)\n")
+		return 2
 	}
 
 	// Create GitHub client (may be needed for detached HEAD resolution)
-	var client *gh.Client
+	var client gh.Provider
 
 	// Multi-repo mode: skip single-repo resolution (v0.8)
 	if cfg.IsMultiRepo() {
 		var err error
-		client, err = gh.NewClient()
+		client, err = newClient(cfg)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return 2
 		}
-	} else if len(cfg.Repositories) == 1 {
+	} else {
 		// Single repo from --repos or config file
-		cfg.Owner = cfg.Repositories[0].Owner
-		cfg.Repo = cfg.Repositories[0].Repo
-		cfg.Branch = cfg.Repositories[0].Branch
-		cfg.Repositories = nil // Clear to use single-repo mode
+		cfg.UseSingleRepository()
+	}
+
+	// A branch from --branch or the config file overrides the remembered one
+	explicitBranch := cfg.Branch != "" || cfg.AllBranches || cfg.BranchPattern != ""
+
+	// Complete a bare --repo name, which then behaves like --repo owner/name
+	if err := cfg.ResolveOwner(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
 	}
 
 	// Resolve repo and branch from git (single-repo mode only)
@@ -100,7 +184,7 @@ func run() int {
 			if err == config.ErrDetachedHead {
 				// In detached HEAD state, we need to resolve the default branch
 				// First create client to get repository info
-				client, clientErr := gh.NewClient()
+				client, clientErr := newClient(cfg)
 				if clientErr != nil {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", clientErr)
 					return 2
@@ -113,7 +197,7 @@ func run() int {
 					return 2
 				}
 
-				repoInfo, repoErr := git.GetRepoInfo(cwd)
+				repoInfo, repoErr := cfg.RemoteRepoInfo(cwd)
 				if repoErr != nil {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", repoErr)
 					return 2
@@ -140,28 +224,82 @@ func run() int {
 	// Create GitHub client if not already created for detached HEAD
 	if client == nil {
 		var err error
-		client, err = gh.NewClient()
+		client, err = newClient(cfg)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return 2
 		}
 	}
 
+	if cfg.Open {
+		return openRun(cfg, client)
+	}
+
 	// Handle output modes
 	if cfg.Plain && cfg.Json {
 		fmt.Fprintf(os.Stderr, "Error: cannot use both --plain and --json flags\n")
 		return 2
 	}
-	if cfg.Plain {
+	if cfg.Template != "" {
+		return runTemplate(cfg, client)
+	}
+	if cfg.Expect != "" {
+		return runExpect(cfg, client)
+	}
+	if cfg.Plain || (cfg.Wait && !cfg.Json) {
 		return runPlain(cfg, client)
 	}
 	if cfg.Json {
 		return runJson(cfg, client)
 	}
 
-	// Create and run TUI
+	// Restore the last branch and status filter used for this repo
+	if !cfg.NoState && !cfg.IsMultiRepo() {
+		restoreState(cfg, explicitBranch)
+	}
+
+	// Resolve --run/--run-id up front so a missing run is a clear CLI error;
+	// the TUI then opens straight into that run by ID
+	if cfg.HasRunSelection() {
+		run, err := resolveTargetRun(client, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		cfg.RunID = run.ID
+		cfg.RunNumber = 0
+	}
+
+	return runTUI(cfg, client)
+}
+
+// runTUI runs the interactive TUI and returns its exit code
+func runTUI(cfg *config.Config, client gh.Provider) int {
+	// FORCE_COLOR has to override lipgloss' own terminal detection
+	if cfg.ForceColor() {
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+
+	// Warn once, before the TUI takes over the screen
+	if cfg.Watch {
+		if warning := cfg.PollWarning(); warning != "" {
+			warn(cfg, "%s", warning)
+		}
+	}
+
 	model := tui.NewModel(cfg, client)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	if cfg.RefreshOnFocus {
+		opts = append(opts, tea.WithReportFocus())
+	}
+	if cfg.ReposFile == config.StdinRepos {
+		// stdin held the repo list, so read keys from the terminal
+		opts = append(opts, tea.WithInputTTY())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	finalModel, err := p.Run()
 	if err != nil {
@@ -171,6 +309,12 @@ func run() int {
 
 	// Return exit code based on run status
 	if m, ok := finalModel.(tui.Model); ok {
+		if job := m.FailFastJob(); job != "" {
+			fmt.Fprintf(os.Stderr, "Job %q failed, stopping watch (--fail-fast)\n", job)
+		}
+		if m.TimedOut() {
+			fmt.Fprintf(os.Stderr, "Run did not complete within --timeout %s\n", cfg.Timeout)
+		}
 		return m.ExitCode()
 	}
 
@@ -178,17 +322,30 @@ func run() int {
 }
 
 // runPlain runs in plain text mode, fetching and displaying data synchronously
-func runPlain(cfg *config.Config, client *gh.Client) int {
-	// Fetch latest run
-	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch)
+func runPlain(cfg *config.Config, client gh.Provider) int {
+	if cfg.Limit > 1 {
+		return runPlainList(cfg, client)
+	}
+
+	// Fetch the latest run, or the one picked by --run/--run-id
+	run, err := fetchOutputRun(cfg, client)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching latest run: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error fetching run: %v\n", err)
 		return 2
 	}
 
-	// Fetch jobs if run exists
+	// --wait: block until the run completes
+	if cfg.Wait && run != nil {
+		run, err = waitForRun(cfg, client, run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error waiting for run: %v\n", err)
+			return waitExitCode(err)
+		}
+	}
+
+	// Fetch jobs if run exists, unless --no-jobs
 	var jobs []gh.Job
-	if run != nil {
+	if run != nil && !cfg.NoJobs {
 		jobs, err = client.FetchJobs(cfg.Owner, cfg.Repo, run.ID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error fetching jobs: %v\n", err)
@@ -199,30 +356,94 @@ func runPlain(cfg *config.Config, client *gh.Client) int {
 	// Output plain text
 	outputPlain(cfg, run, jobs)
 
-	// Return exit code based on run status
-	if run == nil {
+	// Return exit code based on run status and --exit-on
+	return runExitCode(cfg, run)
+}
+
+// runPlainList prints a compact history of the most recent --limit runs.
+// The exit code reflects the latest run, as in single-run mode.
+func runPlainList(cfg *config.Config, client gh.Provider) int {
+	runs, err := client.FetchWorkflowRuns(cfg.Owner, cfg.Repo, cfg.Branch, "", cfg.Event, cfg.CreatedFilter(), 1, cfg.Limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching workflow runs: %v\n", err)
 		return 2
 	}
-	if run.IsSuccess() {
-		return 0
-	} else if run.IsFailure() {
-		return 1
+
+	fmt.Printf("Repository: %s\n", cfg.RepoSlug())
+	fmt.Printf("Branch: %s\n", cfg.BranchLabel())
+	fmt.Println()
+
+	if len(runs) == 0 {
+		fmt.Println("No workflow runs found")
+		return 2
 	}
-	return 0
+
+	for i := range runs {
+		run := &runs[i]
+		fmt.Printf("#%d %s: %s", run.RunNumber, run.DisplayName(), run.Status)
+		if run.Conclusion != nil {
+			fmt.Printf(" (%s)", *run.Conclusion)
+		}
+		fmt.Printf(" - %s", run.Event)
+		if actor := run.ActorLogin(); actor != "" {
+			fmt.Printf(" by %s", actor)
+		}
+		if cfg.Branch == "" {
+			fmt.Printf(" on %s", run.HeadBranch)
+		}
+		fmt.Printf(" - %s\n", run.CreatedAt.UTC().Format("2006-01-02 15:04:05"))
+
+		if cfg.WithJobs {
+			jobs, err := client.FetchJobs(cfg.Owner, cfg.Repo, run.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error fetching jobs for run #%d: %v\n", run.RunNumber, err)
+				return 2
+			}
+			outputPlainJobs(jobs, "    ")
+		}
+	}
+
+	return runExitCode(cfg, &runs[0])
+}
+
+// fetchOutputRun returns the run for plain/JSON output: the one selected by
+// --run or --run-id if set, otherwise the latest run in the time window
+func fetchOutputRun(cfg *config.Config, client gh.Provider) (*gh.WorkflowRun, error) {
+	if cfg.HasRunSelection() {
+		return resolveTargetRun(client, cfg)
+	}
+	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch, cfg.Event, cfg.CreatedFilter())
+	if errors.Is(err, gh.ErrNoRuns) && cfg.Event == "" && cfg.CreatedFilter() == "" {
+		return nil, gh.ExplainNoRuns(client, cfg.Owner, cfg.Repo, cfg.BranchLabel())
+	}
+	return run, err
 }
 
 // runJson runs in JSON mode, fetching and displaying data synchronously
-func runJson(cfg *config.Config, client *gh.Client) int {
-	// Fetch latest run
-	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch)
+func runJson(cfg *config.Config, client gh.Provider) int {
+	if cfg.Limit > 1 {
+		return runJsonList(cfg, client)
+	}
+
+	// Fetch the latest run, or the one picked by --run/--run-id
+	run, err := fetchOutputRun(cfg, client)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching latest run: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error fetching run: %v\n", err)
 		return 2
 	}
 
-	// Fetch jobs if run exists
+	// --wait: block until the run completes
+	if cfg.Wait && run != nil {
+		run, err = waitForRun(cfg, client, run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error waiting for run: %v\n", err)
+			return waitExitCode(err)
+		}
+	}
+
+	// Fetch jobs if run exists, unless --no-jobs
 	var jobs []gh.Job
-	if run != nil {
+	if run != nil && !cfg.NoJobs {
 		jobs, err = client.FetchJobs(cfg.Owner, cfg.Repo, run.ID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error fetching jobs: %v\n", err)
@@ -233,22 +454,177 @@ func runJson(cfg *config.Config, client *gh.Client) int {
 	// Output JSON
 	outputJson(cfg, run, jobs)
 
-	// Return exit code based on run status
+	// Return exit code based on run status and --exit-on
+	return runExitCode(cfg, run)
+}
+
+// runTemplate runs in --template mode, fetching data synchronously and
+// printing it with the user's template
+func runTemplate(cfg *config.Config, client gh.Provider) int {
+	// Catch template mistakes before any API calls
+	tmpl, err := parseOutputTemplate(cfg.Template)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Fetch the latest run, or the one picked by --run/--run-id
+	run, err := fetchOutputRun(cfg, client)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching run: %v\n", err)
+		return 2
+	}
+
+	// --wait: block until the run completes
+	if cfg.Wait && run != nil {
+		run, err = waitForRun(cfg, client, run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error waiting for run: %v\n", err)
+			return waitExitCode(err)
+		}
+	}
+
+	// Fetch jobs if run exists, unless --no-jobs
+	var jobs []gh.Job
+	if run != nil && !cfg.NoJobs {
+		jobs, err = client.FetchJobs(cfg.Owner, cfg.Repo, run.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching jobs: %v\n", err)
+			return 2
+		}
+	}
+
+	if err := outputTemplate(cfg, run, jobs, tmpl); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Return exit code based on run status and --exit-on
+	return runExitCode(cfg, run)
+}
+
+// runJsonList outputs the most recent --limit runs as a JSON array.
+// The exit code reflects the latest run, as in single-run mode.
+func runJsonList(cfg *config.Config, client gh.Provider) int {
+	runs, err := client.FetchWorkflowRuns(cfg.Owner, cfg.Repo, cfg.Branch, "", cfg.Event, cfg.CreatedFilter(), 1, cfg.Limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching workflow runs: %v\n", err)
+		return 2
+	}
+
+	jsonRuns := make([]JsonRun, len(runs))
+	for i := range runs {
+		jsonRuns[i].WorkflowRun = runs[i]
+		if cfg.WithJobs {
+			jobs, err := client.FetchJobs(cfg.Owner, cfg.Repo, runs[i].ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error fetching jobs for run #%d: %v\n", runs[i].RunNumber, err)
+				return 2
+			}
+			jsonRuns[i].Jobs = jobs
+		}
+	}
+
+	writeJson(JsonOutput{
+		Repository: cfg.RepoSlug(),
+		Branch:     cfg.Branch,
+		Runs:       jsonRuns,
+	})
+
+	if len(runs) == 0 {
+		return 2
+	}
+	return runExitCode(cfg, &runs[0])
+}
+
+// waitForRun polls a run every --poll interval until it completes
+func waitForRun(cfg *config.Config, client gh.Provider, run *gh.WorkflowRun) (*gh.WorkflowRun, error) {
+	var deadline time.Time
+	if cfg.Timeout > 0 {
+		deadline = time.Now().Add(cfg.Timeout)
+	}
+	shownStatus := ""
+	for !run.IsCompleted() {
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return nil, fmt.Errorf("%w: run #%d still %s after %s", errWaitTimeout, run.RunNumber, run.Status, cfg.Timeout)
+		}
+		// Say so once per status rather than on every poll
+		if !cfg.Json && !cfg.Quiet && run.Status != shownStatus {
+			fmt.Fprintf(os.Stderr, "Waiting for run #%d (%s)...\n", run.RunNumber, run.Status)
+			shownStatus = run.Status
+		}
+		wait := cfg.Poll
+		if !deadline.IsZero() {
+			wait = min(wait, time.Until(deadline))
+		}
+		time.Sleep(wait)
+
+		updated, err := client.FetchRun(cfg.Owner, cfg.Repo, run.ID)
+		if err != nil {
+			return nil, err
+		}
+		run = updated
+	}
+	return run, nil
+}
+
+// errWaitTimeout is returned by waitForRun when --timeout runs out
+var errWaitTimeout = errors.New("run did not complete within --timeout")
+
+// waitExitCode returns the exit code for a waitForRun error
+func waitExitCode(err error) int {
+	if errors.Is(err, errWaitTimeout) {
+		return config.ExitTimeout
+	}
+	return 2
+}
+
+// runExitCode returns the process exit code for a run: 2 if there is no
+// run, else what --exit-on (or the default) says about its outcome
+func runExitCode(cfg *config.Config, run *gh.WorkflowRun) int {
 	if run == nil {
 		return 2
 	}
-	if run.IsSuccess() {
-		return 0
-	} else if run.IsFailure() {
+	return cfg.ExitCode(run.Outcome(), run.IsFailure())
+}
+
+// runExpect is --wait --expect: a CI gate that waits for the run without
+// the TUI and exits 0 only if it concludes as expected. A different
+// conclusion exits 1; no run or an API error exits 2, and running out of
+// --timeout exits 124.
+func runExpect(cfg *config.Config, client gh.Provider) int {
+	run, err := fetchOutputRun(cfg, client)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching run: %v\n", err)
+		return 2
+	}
+	if run == nil {
+		fmt.Fprintf(os.Stderr, "Error: no workflow runs found\n")
+		return 2
+	}
+
+	run, err = waitForRun(cfg, client, run)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error waiting for run: %v\n", err)
+		return waitExitCode(err)
+	}
+
+	conclusion := "none"
+	if run.Conclusion != nil {
+		conclusion = *run.Conclusion
+	}
+	if conclusion != cfg.Expect {
+		fmt.Fprintf(os.Stderr, "Run #%d %s concluded %s, expected %s\n%s\n", run.RunNumber, run.DisplayName(), conclusion, cfg.Expect, run.HTMLURL)
 		return 1
 	}
+	fmt.Printf("Run #%d %s concluded %s\n", run.RunNumber, run.DisplayName(), conclusion)
 	return 0
 }
 
 // outputPlain outputs run and job information in plain text format
 func outputPlain(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job) {
 	fmt.Printf("Repository: %s\n", cfg.RepoSlug())
-	fmt.Printf("Branch: %s\n", cfg.Branch)
+	fmt.Printf("Branch: %s\n", cfg.BranchLabel())
 	fmt.Println()
 
 	if run == nil {
@@ -257,19 +633,28 @@ func outputPlain(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job) {
 	}
 
 	// Run information
-	fmt.Printf("Run #%d: %s\n", run.RunNumber, run.Name)
+	fmt.Printf("Run #%d: %s\n", run.RunNumber, run.DisplayName())
 	fmt.Printf("Status: %s", run.Status)
 	if run.Conclusion != nil {
 		fmt.Printf(" (%s)", *run.Conclusion)
 	}
 	fmt.Println()
+	if run.IsActionRequired() {
+		fmt.Println("Action required: the run is waiting for approval; approve it at the URL below")
+	}
 	fmt.Printf("Event: %s\n", run.Event)
+	if cfg.Branch == "" {
+		fmt.Printf("Run branch: %s\n", run.HeadBranch)
+	}
 	if run.Actor != nil {
 		fmt.Printf("Triggered by: %s\n", run.Actor.Login)
 	}
-	fmt.Printf("Created: %s\n", run.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Created: %s\n", run.CreatedAt.UTC().Format("2006-01-02 15:04:05"))
+	if run.RunAttempt > 1 {
+		fmt.Printf("Attempt: %d\n", run.RunAttempt)
+	}
 	if run.Status == gh.StatusCompleted {
-		fmt.Printf("Updated: %s\n", run.UpdatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("Updated: %s\n", run.UpdatedAt.UTC().Format("2006-01-02 15:04:05"))
 	}
 	fmt.Printf("URL: %s\n", run.HTMLURL)
 	fmt.Println()
@@ -281,8 +666,13 @@ func outputPlain(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job) {
 	}
 
 	fmt.Printf("Jobs (%d):\n", len(jobs))
+	outputPlainJobs(jobs, "  ")
+}
+
+// outputPlainJobs prints one line per job with the given indent
+func outputPlainJobs(jobs []gh.Job, indent string) {
 	for _, job := range jobs {
-		fmt.Printf("  %s: %s", job.Name, job.Status)
+		fmt.Printf("%s%s: %s", indent, job.Name, job.Status)
 		if job.Conclusion != nil {
 			fmt.Printf(" (%s)", *job.Conclusion)
 		}
@@ -312,34 +702,134 @@ USAGE:
     cimon retry [flags]              Rerun the latest workflow
     cimon cancel [flags]             Cancel a running workflow
     cimon dispatch <workflow> [flags] Trigger workflow dispatch
+    cimon logs [job] [flags]         Print a job's logs (default: first failed job)
+    cimon open [flags]               Open the latest run (or --run) in the browser
+    cimon version [--json]           Print the version (--json: version, commit and date)
+    cimon doctor [flags]             Check authentication, repository detection and API access
 
 FLAGS:
-    -r, --repo string     Repository in owner/name format
-        --repos string    Comma-separated repos for multi-repo mode (owner/repo1,owner/repo2)
-    -b, --branch string   Branch name
+    -r, --repo string     Repository in owner/name format, or just name for the
+                          current repo's owner (or default_owner in cimon.yml)
+        --repos string    Comma-separated repos for multi-repo mode (owner/repo1,owner/repo2), or - for stdin
+        --repos-file string  Read multi-repo repos from a file, one owner/repo per line
+        --profile string  Use a named profile from cimon.yml (flags override its settings)
+    -b, --branch string   Branch name ("all" for every branch)
+        --branch-pattern string  Show runs from every branch matching a glob, e.g. 'release/*'
     -w, --watch           Watch mode - poll until completion
-    -p, --poll duration   Poll interval for watch mode (default 5s)
+        --watch-new       Keep watching and switch to newer runs as they start
+    -p, --poll duration   Poll interval for watch mode (default 5s, minimum 2s)
+        --fail-fast       Exit 1 as soon as any job fails (watch mode)
         --notify          Desktop notification on completion (watch mode)
         --hook string     Run script on completion with env vars (watch mode)
+        --bell            Ring the terminal bell and flash the header on completion (watch mode)
+        --notify-title-template string  Go template for notification titles
+        --notify-body-template string   Go template for notification bodies
+        --since string    Only runs created since a duration ago (24h, 7d) or date (2024-01-01)
+        --until string    Only runs created until a duration ago or date
+        --event string    Only runs triggered by an event (push, pull_request, schedule, ...)
+        --provider string CI provider: github or gitlab (default: detected from the git remote)
+        --no-cache        Don't cache completed job logs on disk
+        --clear-cache     Clear the log cache and exit
+        --no-state        Don't remember the last branch and status filter per repo
+        --max-retries int           Max retries for failed API requests (default 3)
+        --retry-base-delay duration Initial retry backoff delay (default 1s)
+        --retry-max-delay duration  Maximum retry backoff delay (default 30s)
+        --ca-cert string  PEM file of extra CA certificates to trust (proxies, GHES)
+        --token-file string  Read the API token from a file (e.g. a mounted secret)
+        --insecure-skip-verify  Skip TLS certificate verification (testing only, insecure)
+        --max-log-bytes int  Max size of a job log held in memory; larger logs fail to load (default 50MiB, 0 = no limit)
         --no-color        Disable color output
+        --symbols         Text status symbols ([PASS], [FAIL], [RUN], ...) that don't rely on color
+        --utc             Show TUI and doctor timestamps in UTC instead of local time (--plain is always UTC)
+        --compact         Compact TUI layout: one tight line per job, for small terminals and panes
+        --mouse           Mouse wheel scrolling and click-to-select in the TUI
+        --refresh-on-focus  Refresh when the terminal regains focus (needs terminal support)
+        --export-format string  Format for logs saved with s: txt, json or html (default txt)
+        --no-redact       Don't mask likely secrets (tokens, keys) in saved or shared logs
         --plain           Plain text output (no TUI)
+    -q, --quiet           Don't print warnings to stderr (errors are still printed);
+                          also works with subcommands
         --json            JSON output for scripting
+        --template string Print the run with a Go template (fields .Repository,
+                          .Branch, .Run, .Jobs; functions ago, duration)
+        --exit-on string  Conclusions that exit 1 (default failure,cancelled,timed_out,action_required)
+        --wait            Wait for the latest run to complete, print the result and exit
+        --expect string   With --wait, exit 0 only if the run concludes with this (e.g. success)
+        --timeout duration  With --wait or --watch, exit 124 if the run hasn't completed
+                          after this long (default no limit)
+        --open            Open the latest run (or --run) in the browser and exit
+        --limit int       Number of recent runs to output with --json or --plain (default 1)
+        --with-jobs       Include each run's jobs in --limit output
+        --no-jobs         Don't fetch jobs, only the run (halves API calls)
+        --run int         Open a specific run by number instead of the latest
+        --run-id int      Open a specific run by ID instead of the latest
+        --tail int        Open job logs at their last N lines (0 = whole log)
     -v, --version         Show version
 
+RETRY/CANCEL/LOGS/OPEN FLAGS:
+        --run int         Run number to target instead of the latest run
+        --run-id int      Run ID to target instead of the latest run
+
+RETRY/CANCEL/DISPATCH FLAGS:
+    -y, --yes             Don't ask for confirmation (required when stdin isn't a terminal)
+
+LOGS FLAGS:
+        --tail int        Print only the last N lines
+
+DISPATCH FLAGS:
+    -w, --watch           Wait for the dispatched run to start, then watch it
+    -p, --poll duration   Poll interval for --watch (default 5s, minimum 2s)
+
 CONFIG FILE (cimon.yml):
     repositories:
       - owner/repo1
       - owner/repo2
+    default_owner: my-org       # owner for a bare --repo name outside a git repo
+    notify_title_template: "{{.Icon}} {{.Repo}} {{.Conclusion}}"
+    notify_body_template: "{{.WorkflowName}} #{{.RunNumber}} on {{.Branch}}"
+    profiles:                   # select with --profile prod
+      prod:
+        repos: [org/api, org/web]
+        branch: main
+        poll: 10s
+        notify: true
+
+NOTIFICATION TEMPLATE FIELDS:
+    .Icon .WorkflowName .RunNumber .Conclusion .Repo .Branch .HTMLURL
 
 EXAMPLES:
     cimon                                   # Monitor current repo
     cimon --repos org/api,org/web           # Monitor multiple repos
+    cimon --repo web                        # Repo "web" of the current repo's owner
+    cimon --branch all                      # Latest runs from every branch
+    cimon --branch-pattern 'release/*'      # Runs from every release branch
+    cimon --profile prod                    # Settings from the "prod" profile in cimon.yml
     cimon --plain                           # Plain text output
     cimon -w --notify                       # Watch with desktop notification
+    cimon -w --fail-fast                    # Stop watching at the first failed job
     cimon -w --hook ./my-script.sh          # Watch with custom hook
+    cimon --since 24h                       # Only runs from the last day
+    cimon --event push                      # Only runs triggered by a push
+    cimon --run 457                         # Open run #457 directly
+    cimon --provider gitlab --repo acme/web # Monitor a GitLab project's pipelines
+    cimon --wait --exit-on failure,timed_out # CI gate: wait, fail only on failure/timeout
+    cimon --wait --expect success --timeout 30m # Deploy gate: pass only on success
+    cimon --json --limit 10 --with-jobs     # Last 10 runs with jobs as JSON
+    cimon --plain --limit 5                 # Compact history of the last 5 runs
+    cimon --json --no-jobs                  # Is it green? One API call, no jobs
+    cimon --template '{{.Run.RunNumber}} {{.Run.Status}}'  # Custom one-line output
+    cimon --max-retries 6 --retry-max-delay 1m  # Be patient on flaky networks
     cimon retry                             # Rerun latest workflow
+    cimon retry --run 123                   # Rerun run #123
     cimon cancel                            # Cancel running workflow
+    cimon cancel --run-id 9876543210        # Cancel a run by its ID
+    cimon retry --yes                       # Rerun without prompting (scripts, CI)
     cimon dispatch deploy.yml               # Trigger workflow dispatch
+    cimon dispatch deploy.yml --watch       # Trigger it, then watch the new run
+    cimon logs --tail 100                   # Last 100 lines of the first failed job
+    cimon logs build --run 123              # Logs of job "build" in run #123
+    cimon open                              # Open the latest run in the browser
+    cimon open --run 123                    # Open run #123 in the browser
 
 HOOK ENVIRONMENT VARIABLES:
     CIMON_WORKFLOW_NAME   Workflow name (e.g., "CI")
@@ -349,6 +839,13 @@ HOOK ENVIRONMENT VARIABLES:
     CIMON_BRANCH          Branch name
     CIMON_HTML_URL        URL to the run
 
+NETWORK ENVIRONMENT VARIABLES (overridden by flags):
+    CIMON_MAX_RETRIES       Same as --max-retries
+    CIMON_RETRY_BASE_DELAY  Same as --retry-base-delay
+    CIMON_RETRY_MAX_DELAY   Same as --retry-max-delay
+    CIMON_CA_CERT           Same as --ca-cert
+    CIMON_TOKEN_FILE        Same as --token-file
+
 For more information, see: https://github.com/lance0/cimon
 `)
 }
@@ -368,27 +865,26 @@ func runRetry(args []string) int {
 	}
 
 	// Create client
-	client, err := gh.NewClient()
+	client, err := newClient(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
 
-	// Get latest run
-	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch)
+	// Get the target run (--run/--run-id, or the latest)
+	run, err := resolveTargetRun(client, cfg)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching latest run: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
 
-	if run == nil {
-		fmt.Fprintf(os.Stderr, "No workflow runs found for %s/%s on branch %s\n", cfg.Owner, cfg.Repo, cfg.Branch)
+	// Confirm rerun
+	confirmed, err := confirm(cfg, "Rerun workflow #%d (%s) on %s/%s?", run.RunNumber, run.DisplayName(), cfg.Owner, cfg.Repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
-
-	// Confirm rerun
-	fmt.Printf("Rerun workflow #%d (%s) on %s/%s?\n", run.RunNumber, run.Name, cfg.Owner, cfg.Repo)
-	if !getConfirmation() {
+	if !confirmed {
 		fmt.Println("Cancelled.")
 		return 0
 	}
@@ -419,21 +915,16 @@ func runCancel(args []string) int {
 	}
 
 	// Create client
-	client, err := gh.NewClient()
+	client, err := newClient(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
 
-	// Get latest run
-	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch)
+	// Get the target run (--run/--run-id, or the latest)
+	run, err := resolveTargetRun(client, cfg)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching latest run: %v\n", err)
-		return 2
-	}
-
-	if run == nil {
-		fmt.Fprintf(os.Stderr, "No workflow runs found for %s/%s on branch %s\n", cfg.Owner, cfg.Repo, cfg.Branch)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
 
@@ -443,8 +934,12 @@ func runCancel(args []string) int {
 	}
 
 	// Confirm cancellation
-	fmt.Printf("Cancel workflow #%d (%s) on %s/%s?\n", run.RunNumber, run.Name, cfg.Owner, cfg.Repo)
-	if !getConfirmation() {
+	confirmed, err := confirm(cfg, "Cancel workflow #%d (%s) on %s/%s?", run.RunNumber, run.DisplayName(), cfg.Owner, cfg.Repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if !confirmed {
 		fmt.Println("Cancelled.")
 		return 0
 	}
@@ -483,20 +978,31 @@ func runDispatch(args []string) int {
 	}
 
 	// Create client
-	client, err := gh.NewClient()
+	client, err := newClient(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
 	}
 
+	if cfg.AllBranches {
+		fmt.Fprintf(os.Stderr, "Error: dispatch needs a single branch to run on, not --branch %s\n", config.AllBranches)
+		return 2
+	}
+
 	// Confirm dispatch
-	fmt.Printf("Trigger workflow dispatch for %s on %s/%s (branch: %s)?\n", workflowFile, cfg.Owner, cfg.Repo, cfg.Branch)
-	if !getConfirmation() {
+	confirmed, err := confirm(cfg, "Trigger workflow dispatch for %s on %s/%s (branch: %s)?", workflowFile, cfg.Owner, cfg.Repo, cfg.Branch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if !confirmed {
 		fmt.Println("Cancelled.")
 		return 0
 	}
 
-	// Dispatch the workflow
+	// Dispatch the workflow. created_at has one-second precision and clocks
+	// drift, so allow some slack when matching the new run.
+	dispatchedAt := time.Now().Add(-dispatchClockSkew)
 	err = client.DispatchWorkflow(cfg.Owner, cfg.Repo, workflowFile, cfg.Branch)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error dispatching workflow: %v\n", err)
@@ -504,35 +1010,560 @@ func runDispatch(args []string) int {
 	}
 
 	fmt.Printf("Successfully triggered workflow dispatch for %s\n", workflowFile)
+	if !cfg.Watch {
+		return 0
+	}
+
+	// --watch: find the run the dispatch created and watch it in the TUI
+	run, err := waitForDispatchedRun(cfg, client, workflowFile, dispatchedAt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	cfg.RunID = run.ID
+	return runTUI(cfg, client)
+}
+
+const (
+	dispatchClockSkew    = 5 * time.Second  // Slack when matching a dispatched run's creation time
+	dispatchRunTimeout   = 60 * time.Second // How long to wait for a dispatched run to appear
+	dispatchPollInterval = 2 * time.Second
+)
+
+// waitForDispatchedRun polls for the run created by a workflow dispatch,
+// which takes a few seconds to appear after the dispatch is accepted
+func waitForDispatchedRun(cfg *config.Config, client gh.Provider, workflowFile string, since time.Time) (*gh.WorkflowRun, error) {
+	fmt.Fprintf(os.Stderr, "Waiting for the %s run to start...\n", workflowFile)
+	deadline := time.Now().Add(dispatchRunTimeout)
+	for {
+		run, err := client.FetchDispatchedRun(cfg.Owner, cfg.Repo, workflowFile, cfg.Branch, since)
+		if err != nil {
+			return nil, fmt.Errorf("finding dispatched run: %w", err)
+		}
+		if run != nil {
+			return run, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no %s run appeared on %s within %s; check the Actions tab", workflowFile, cfg.Branch, dispatchRunTimeout)
+		}
+		time.Sleep(dispatchPollInterval)
+	}
+}
+
+func runLogs(args []string) int {
+	// An optional job name comes before the flags
+	var jobName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		jobName = args[0]
+		args = args[1:]
+	}
+
+	// Parse flags for logs command
+	cfg, err := parseSubcommandFlags(args, "logs")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Resolve repo and branch
+	if err := cfg.Resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Create client
+	client, err := newClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Get the target run (--run/--run-id, or the latest)
+	run, err := resolveTargetRun(client, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	jobs, err := client.FetchJobs(cfg.Owner, cfg.Repo, run.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching jobs: %v\n", err)
+		return 2
+	}
+	job, err := selectLogJob(jobs, jobName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Completed logs come from the cached ZIP; running jobs from the live log
+	var logs string
+	if job.IsCompleted() {
+		logs, err = client.FetchJobLogs(cfg.Owner, cfg.Repo, job.ID, true)
+	} else {
+		var chunk *gh.LogChunk
+		chunk, err = client.FetchJobLogsSince(cfg.Owner, cfg.Repo, job.ID, 0)
+		if chunk != nil {
+			logs = chunk.Content
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching logs for job %q: %v\n", job.Name, err)
+		return 2
+	}
+
+	fmt.Print(gh.TailLines(logs, cfg.Tail))
+	return 0
+}
+
+// selectLogJob picks the job named name (case-insensitive), or with no name
+// the first failed job, falling back to the first job
+func selectLogJob(jobs []gh.Job, name string) (*gh.Job, error) {
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("run has no jobs")
+	}
+	if name == "" {
+		for i := range jobs {
+			if jobs[i].IsFailure() {
+				return &jobs[i], nil
+			}
+		}
+		return &jobs[0], nil
+	}
+
+	names := make([]string, 0, len(jobs))
+	for i := range jobs {
+		if strings.EqualFold(jobs[i].Name, name) {
+			return &jobs[i], nil
+		}
+		names = append(names, jobs[i].Name)
+	}
+	return nil, fmt.Errorf("no job named %q (jobs: %s)", name, strings.Join(names, ", "))
+}
+
+// runOpenCommand implements `cimon open`
+func runOpenCommand(args []string) int {
+	cfg, err := parseSubcommandFlags(args, "open")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if err := cfg.Resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	return openRun(cfg, client)
+}
+
+// openRun opens the run selected by --run/--run-id, or the latest run, in
+// the browser
+func openRun(cfg *config.Config, client gh.Provider) int {
+	run, err := resolveTargetRun(client, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if err := browser.Open(run.HTMLURL); err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening browser: %v\n", err)
+		fmt.Println(run.HTMLURL)
+		return 2
+	}
+
+	fmt.Printf("Opened run #%d (%s): %s\n", run.RunNumber, run.DisplayName(), run.HTMLURL)
+	return 0
+}
+
+// doctorCheck is one line of the cimon doctor report
+type doctorCheck struct {
+	name     string
+	ok       bool
+	detail   string
+	hint     string // How to fix a failed check
+	optional bool   // A failure is reported but doesn't fail doctor
+}
+
+// runDoctor implements cimon doctor: it checks each thing cimon needs
+// (a token, a repository, the API) and prints how to fix what's missing.
+// It exits 1 if a required check fails.
+func runDoctor(args []string) int {
+	cfg, err := parseSubcommandFlags(args, "doctor")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var checks []doctorCheck
+	resolveErr := cfg.Resolve()
+	repoOK := resolveErr == nil || errors.Is(resolveErr, config.ErrNoBranch) || errors.Is(resolveErr, config.ErrDetachedHead)
+	if repoOK {
+		checks = append(checks, doctorCheck{name: "Repository", ok: true, detail: cfg.RepoSlug()})
+	} else {
+		checks = append(checks, doctorCheck{name: "Repository", detail: firstLine(resolveErr.Error()),
+			hint: "Run inside a clone of a GitHub repository or pass --repo owner/name"})
+	}
+	checks = append(checks, doctorBranchCheck(cfg, resolveErr))
+
+	if cfg.Provider == config.ProviderGitLab {
+		checks = append(checks, doctorCheck{name: "CI provider", ok: true, detail: "GitLab (token and API checks cover GitHub only)"})
+	} else {
+		checks = append(checks, doctorGitHubChecks(cfg, repoOK)...)
+	}
+
+	if notify.IsNotificationAvailable() {
+		checks = append(checks, doctorCheck{name: "Desktop notifications", ok: true, detail: "available for --notify"})
+	} else {
+		checks = append(checks, doctorCheck{name: "Desktop notifications", optional: true, detail: "no notification tool found",
+			hint: "Install notify-send (libnotify) for --notify, or use --bell for a terminal bell"})
+	}
+
+	failed := false
+	for _, check := range checks {
+		mark := "✓"
+		if !check.ok {
+			mark = "✗"
+			failed = failed || !check.optional
+		}
+		fmt.Printf("%s %s: %s\n", mark, check.name, check.detail)
+		if !check.ok && check.hint != "" {
+			fmt.Printf("  → %s\n", check.hint)
+		}
+	}
+	if failed {
+		return 1
+	}
 	return 0
 }
 
+// doctorBranchCheck reports the branch cimon would monitor
+func doctorBranchCheck(cfg *config.Config, resolveErr error) doctorCheck {
+	if resolveErr == nil {
+		return doctorCheck{name: "Branch", ok: true, detail: cfg.BranchLabel()}
+	}
+	cwd, err := os.Getwd()
+	if err == nil {
+		var branch string
+		if branch, err = git.GetBranch(cwd); err == nil {
+			return doctorCheck{name: "Branch", ok: true, detail: branch}
+		}
+	}
+	if errors.Is(err, git.ErrDetachedHead) {
+		return doctorCheck{name: "Branch", optional: true, detail: "detached HEAD",
+			hint: "cimon looks up the branch from the commit's runs; pass --branch to choose one"}
+	}
+	return doctorCheck{name: "Branch", optional: true, detail: firstLine(err.Error()),
+		hint: "Pass --branch name, or --branch all for every branch"}
+}
+
+// rateLimiter is implemented by providers that report their API rate limit
+type rateLimiter interface {
+	FetchRateLimit() (*gh.RateLimit, error)
+}
+
+// doctorGitHubChecks checks for a GitHub token, that the API answers with it,
+// and, when the repository is known, that the token can read it
+func doctorGitHubChecks(cfg *config.Config, repoOK bool) []doctorCheck {
+	source := gh.TokenSource()
+	if cfg.TokenFile != "" {
+		if _, err := gh.ReadTokenFile(cfg.TokenFile); err != nil {
+			return []doctorCheck{{name: "GitHub token", detail: firstLine(err.Error()),
+				hint: "Check the --token-file path (or " + config.EnvTokenFile + ") and that the file holds the token"}}
+		}
+		source = cfg.TokenFile
+	}
+	if source == "" {
+		return []doctorCheck{{name: "GitHub token", detail: "none found",
+			hint: "Run 'gh auth login', or set GH_TOKEN to a token with repo and actions access"}}
+	}
+	checks := []doctorCheck{{name: "GitHub token", ok: true, detail: "from " + source}}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return append(checks, doctorCheck{name: "GitHub API", detail: firstLine(err.Error()),
+			hint: "Check --ca-cert, or run 'gh auth login' again"})
+	}
+	return append(checks, doctorAPIChecks(cfg, client, repoOK)...)
+}
+
+// doctorAPIChecks checks that the API answers through client and how much
+// of the rate limit is left, and, when the repository is known, that the
+// token can read it
+func doctorAPIChecks(cfg *config.Config, client gh.Provider, repoOK bool) []doctorCheck {
+	var checks []doctorCheck
+	if rl, ok := client.(rateLimiter); ok {
+		limit, err := rl.FetchRateLimit()
+		switch {
+		case err != nil:
+			return append(checks, doctorCheck{name: "GitHub API", detail: firstLine(err.Error()),
+				hint: "Check your network, proxy and GH_HOST, and that the token hasn't expired"})
+		case limit.Remaining == 0:
+			checks = append(checks, doctorCheck{name: "GitHub API", detail: fmt.Sprintf("rate limit used up (0/%d)", limit.Limit),
+				hint: fmt.Sprintf("Wait until %s for the limit to reset", cfg.FormatTime(limit.Reset, "15:04 MST"))})
+		default:
+			checks = append(checks, doctorCheck{name: "GitHub API", ok: true,
+				detail: fmt.Sprintf("reachable, %d/%d requests left this hour", limit.Remaining, limit.Limit)})
+		}
+	}
+
+	if repoOK {
+		if _, err := client.GetRepository(cfg.Owner, cfg.Repo); err != nil {
+			hint := "Check the repository name, and that the token can read it (private repos need repo scope)"
+			var permErr *gh.PermissionError
+			if errors.As(err, &permErr) {
+				hint = permErr.Hint()
+			}
+			checks = append(checks, doctorCheck{name: "Repository access", detail: firstLine(err.Error()), hint: hint})
+		} else {
+			checks = append(checks, doctorCheck{name: "Repository access", ok: true, detail: "can read " + cfg.RepoSlug()})
+		}
+	}
+	return checks
+}
+
+// firstLine returns the first line of a possibly multi-line error message
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
 func parseSubcommandFlags(args []string, command string) (*config.Config, error) {
 	cfg := &config.Config{}
 
 	fs := pflag.NewFlagSet(command, pflag.ContinueOnError)
 
 	var repoFlag string
-	fs.StringVarP(&repoFlag, "repo", "r", "", "Repository in owner/name format")
-	fs.StringVarP(&cfg.Branch, "branch", "b", "", "Branch name")
+	fs.StringVarP(&repoFlag, "repo", "r", "", "Repository in owner/name format, or a name owned by the current repo's owner")
+	fs.StringVarP(&cfg.Branch, "branch", "b", "", "Branch name (\"all\" for every branch)")
+	if command == "retry" || command == "cancel" || command == "logs" || command == "open" {
+		fs.IntVar(&cfg.RunNumber, "run", 0, "Run number to target instead of the latest run")
+		fs.Int64Var(&cfg.RunID, "run-id", 0, "Run ID to target instead of the latest run")
+	}
+	if command == "logs" {
+		fs.IntVar(&cfg.Tail, "tail", 0, "Print only the last N lines of the log")
+	}
+	fs.StringVar(&cfg.Provider, "provider", "", "CI provider: github or gitlab (default: detected from the git remote)")
+	fs.BoolVarP(&cfg.Quiet, "quiet", "q", false, "Don't print warnings (errors are still printed)")
+	if command == "retry" || command == "cancel" || command == "dispatch" {
+		fs.BoolVarP(&cfg.Yes, "yes", "y", false, "Don't ask for confirmation")
+	}
+	if command == "dispatch" {
+		fs.BoolVarP(&cfg.Watch, "watch", "w", false, "Watch the dispatched run until it completes")
+		fs.DurationVarP(&cfg.Poll, "poll", "p", config.DefaultPollInterval, "Poll interval for --watch")
+	}
+	if err := config.AddNetworkFlags(fs, cfg); err != nil {
+		return nil, err
+	}
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
 	}
+	if err := cfg.ValidateRetry(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateProvider(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateRunSelection(); err != nil {
+		return nil, err
+	}
+	if cfg.Tail < 0 {
+		return nil, fmt.Errorf("--tail must not be negative (got %d)", cfg.Tail)
+	}
+	if command == "dispatch" {
+		if err := cfg.ValidatePoll(); err != nil {
+			return nil, err
+		}
+	}
 
-	// Handle --repo flag
+	// Handle --repo flag; Resolve completes a bare name's owner
 	if repoFlag != "" {
-		parts := strings.SplitN(repoFlag, "/", 2)
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-			return nil, fmt.Errorf("invalid repo format %q: expected owner/name", repoFlag)
+		owner, repo, err := config.ParseRepoFlag(repoFlag)
+		if err != nil {
+			return nil, err
 		}
-		cfg.Owner = parts[0]
-		cfg.Repo = parts[1]
+		cfg.Owner = owner
+		cfg.Repo = repo
+	}
+
+	// default_owner from cimon.yml completes a bare --repo name outside a git repo
+	fileCfg, err := config.LoadConfigFile(config.DefaultConfigPath())
+	if err != nil {
+		warn(cfg, "%v", err)
 	}
+	fileCfg.ApplyDefaultOwner(cfg)
 
 	return cfg, nil
 }
 
+// resolveTargetRun returns the run selected by --run or --run-id, or the
+// latest run on the branch if neither is set
+func resolveTargetRun(client gh.Provider, cfg *config.Config) (*gh.WorkflowRun, error) {
+	switch {
+	case cfg.RunID != 0:
+		run, err := client.FetchRun(cfg.Owner, cfg.Repo, cfg.RunID)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch run ID %d in %s/%s: %w", cfg.RunID, cfg.Owner, cfg.Repo, err)
+		}
+		return run, nil
+
+	case cfg.RunNumber != 0:
+		run, err := client.FindRunByNumber(cfg.Owner, cfg.Repo, cfg.RunNumber)
+		if errors.Is(err, gh.ErrNoRuns) {
+			return nil, fmt.Errorf("run #%d not found in recent runs of %s/%s", cfg.RunNumber, cfg.Owner, cfg.Repo)
+		}
+		return run, err
+	}
+
+	run, err := client.FetchLatestRun(cfg.Owner, cfg.Repo, cfg.Branch, "", "")
+	if errors.Is(err, gh.ErrNoRuns) || (err == nil && run == nil) {
+		return nil, gh.ExplainNoRuns(client, cfg.Owner, cfg.Repo, cfg.BranchLabel())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching latest run: %w", err)
+	}
+	return run, nil
+}
+
+// readReposFile reads the --repos-file list, or stdin for --repos -
+func readReposFile(path string) ([]config.RepoSpec, error) {
+	name := path
+	var data []byte
+	var err error
+	if path == config.StdinRepos {
+		name = "stdin"
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading repos from %s: %w", name, err)
+	}
+
+	specs, err := config.ParseReposFlag(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("repos from %s: %w", name, err)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no repos found in %s", name)
+	}
+	return specs, nil
+}
+
+// restoreState applies the branch and status filter saved by the last TUI
+// session for this repo, unless --branch or cimon.yml set them, and tells
+// the TUI where to save changes. The state file is a convenience, so
+// problems with it are only warnings.
+func restoreState(cfg *config.Config, explicitBranch bool) {
+	path, err := config.DefaultStatePath()
+	if err != nil {
+		return
+	}
+	cfg.StatePath = path
+
+	state, err := config.LoadState(path)
+	if err != nil {
+		warn(cfg, "%v", err)
+		return
+	}
+	saved := state.Repos[cfg.RepoSlug()]
+	if saved.Branch != "" && !explicitBranch {
+		cfg.Branch = saved.Branch
+	}
+	// Like the branch, a status from the config file beats the remembered
+	// one, which is "" when the last session went back to all runs
+	if cfg.StatusFilter == "" {
+		cfg.StatusFilter = saved.StatusFilter
+	}
+}
+
+// warn prints a non-fatal warning to stderr unless --quiet is set
+func warn(cfg *config.Config, format string, args ...interface{}) {
+	if cfg.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
+
+// newClient creates a client for the configured CI provider using the
+// configured retry policy and CA bundle and, for GitHub unless --no-cache is
+// set, the on-disk log cache
+func newClient(cfg *config.Config) (gh.Provider, error) {
+	if cfg.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "WARNING: --insecure-skip-verify disables TLS certificate verification.")
+		fmt.Fprintln(os.Stderr, "WARNING: Your API token and data can be intercepted. Use only for testing, never in production.")
+	}
+
+	retry := gh.RetryConfig{
+		MaxRetries: cfg.MaxRetries,
+		BaseDelay:  cfg.RetryBaseDelay,
+		MaxDelay:   cfg.RetryMaxDelay,
+	}
+
+	if cfg.Provider == config.ProviderGitLab {
+		var token string
+		if cfg.TokenFile != "" {
+			var err error
+			if token, err = gh.ReadTokenFile(cfg.TokenFile); err != nil {
+				return nil, err
+			}
+		}
+		client, err := gitlab.NewClient(gitlab.ClientOptions{
+			Retry:              retry,
+			CACertFile:         cfg.CACertFile,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+			Token:              token,
+			MaxLogBytes:        cfg.MaxLogBytes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{
+		Retry:              retry,
+		CACertFile:         cfg.CACertFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MaxLogBytes:        cfg.MaxLogBytes,
+		TokenFile:          cfg.TokenFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.NoCache {
+		// The cache is an optimization; run without it if there's no cache dir
+		if dir, dirErr := gh.DefaultLogCacheDir(); dirErr == nil {
+			client.SetLogCache(gh.NewLogCache(dir))
+		}
+	}
+	return client, nil
+}
+
+// confirm asks the question and reads a y/N answer from stdin. --yes skips
+// the prompt; without it, a non-interactive stdin is an error rather than
+// a silent "no", so scripts find out they need --yes.
+func confirm(cfg *config.Config, format string, args ...interface{}) (bool, error) {
+	if cfg.Yes {
+		return true, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, errors.New("refusing to prompt on non-interactive stdin; pass --yes")
+	}
+	fmt.Printf(format+"\n", args...)
+	return getConfirmation(), nil
+}
+
 func getConfirmation() bool {
 	fmt.Print("Confirm? (y/N): ")
 	var response string
@@ -541,15 +1572,110 @@ func getConfirmation() bool {
 	return response == "y" || response == "yes"
 }
 
+// TemplateData is what a --template is executed against
+type TemplateData struct {
+	Repository string // owner/repo
+	Branch     string // --branch, or "" for every branch
+	Run        *gh.WorkflowRun
+	Jobs       []gh.Job
+}
+
+// templateFuncs are the helper functions available to --template
+var templateFuncs = template.FuncMap{
+	"ago":      tui.TimeAgo,    // {{ago .Run.CreatedAt}} -> "5 minutes ago"
+	"duration": formatDuration, // {{duration .Duration}} -> "1.5m" (in a range over .Jobs)
+}
+
+// parseOutputTemplate parses a --template with the template helper functions
+func parseOutputTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// outputTemplate renders the run and jobs with a parsed --template, adding a
+// final newline if the template has none. Nothing is printed if it fails.
+func outputTemplate(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job, tmpl *template.Template) error {
+	var b strings.Builder
+	data := TemplateData{
+		Repository: cfg.RepoSlug(),
+		Branch:     cfg.Branch,
+		Run:        run,
+		Jobs:       jobs,
+	}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// VersionInfo is the build information printed by cimon version --json
+type VersionInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// runVersion implements cimon version: the --version string, or the build
+// variables as JSON with --json
+func runVersion(args []string) int {
+	fs := pflag.NewFlagSet("version", pflag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Print the version as JSON")
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+
+	if !*asJSON {
+		printVersion()
+		return 0
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(VersionInfo{Version: version, Commit: commit, Date: date}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// printVersion prints the human-readable version line
+func printVersion() {
+	fmt.Printf("cimon %s (%s) built %s\n", version, commit, date)
+}
+
 // JsonOutput represents the JSON structure for cimon output
 type JsonOutput struct {
+	CimonVersion string `json:"cimon_version"` // Version of cimon that wrote the output, set by writeJson
+
 	Repository string          `json:"repository"`
 	Branch     string          `json:"branch"`
 	Run        *gh.WorkflowRun `json:"run,omitempty"`
 	Jobs       []gh.Job        `json:"jobs,omitempty"`
+	Runs       []JsonRun       `json:"runs,omitempty"` // Set instead of Run/Jobs when --limit > 1
 	Error      string          `json:"error,omitempty"`
 }
 
+// JsonRun is a workflow run in list output, optionally with its jobs
+type JsonRun struct {
+	gh.WorkflowRun
+	Jobs []gh.Job `json:"jobs,omitempty"`
+}
+
 // outputJson outputs run and job information in JSON format
 func outputJson(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job) {
 	output := JsonOutput{
@@ -558,7 +1684,13 @@ func outputJson(cfg *config.Config, run *gh.WorkflowRun, jobs []gh.Job) {
 		Run:        run,
 		Jobs:       jobs,
 	}
+	writeJson(output)
+}
 
+// writeJson writes output as indented JSON to stdout, stamped with the
+// cimon version
+func writeJson(output JsonOutput) {
+	output.CimonVersion = version
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(output); err != nil {
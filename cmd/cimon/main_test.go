@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lance0/cimon/internal/config"
+	"github.com/lance0/cimon/internal/gh"
+)
+
+func TestDoctorAPIChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		remaining  int
+		rateStatus int // 0 for a good rate_limit response
+		repoStatus int // 0 for a readable repository
+		repoOK     bool
+		want       []doctorCheck // Only name and ok are compared
+	}{
+		{
+			name:      "all good",
+			remaining: 4990,
+			repoOK:    true,
+			want:      []doctorCheck{{name: "GitHub API", ok: true}, {name: "Repository access", ok: true}},
+		},
+		{
+			name:   "rate limit used up",
+			repoOK: true,
+			want:   []doctorCheck{{name: "GitHub API"}, {name: "Repository access", ok: true}},
+		},
+		{
+			name:       "API unreachable skips the repository",
+			rateStatus: http.StatusUnauthorized,
+			repoOK:     true,
+			want:       []doctorCheck{{name: "GitHub API"}},
+		},
+		{
+			name:       "repository not readable",
+			remaining:  4990,
+			repoStatus: http.StatusNotFound,
+			repoOK:     true,
+			want:       []doctorCheck{{name: "GitHub API", ok: true}, {name: "Repository access"}},
+		},
+		{
+			name:      "no repository to check",
+			remaining: 4990,
+			want:      []doctorCheck{{name: "GitHub API", ok: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rate_limit":
+					if tt.rateStatus != 0 {
+						http.Error(w, `{"message": "Bad credentials"}`, tt.rateStatus)
+						return
+					}
+					fmt.Fprintf(w, `{"resources": {"core": {"limit": 5000, "remaining": %d, "reset": %d}}}`, tt.remaining, time.Now().Add(time.Hour).Unix())
+				case "/repos/o/r":
+					if tt.repoStatus != 0 {
+						http.Error(w, `{"message": "Not Found"}`, tt.repoStatus)
+						return
+					}
+					json.NewEncoder(w).Encode(gh.Repository{Name: "r", DefaultBranch: "main"})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+			client, err := gh.NewClientWithOptions(gh.ClientOptions{
+				Retry:     gh.RetryConfig{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+				Transport: srv.Client().Transport,
+				BaseURL:   srv.URL,
+				AuthToken: "t",
+			})
+			if err != nil {
+				t.Fatalf("NewClientWithOptions() error = %v", err)
+			}
+
+			cfg := &config.Config{Owner: "o", Repo: "r"}
+			checks := doctorAPIChecks(cfg, client, tt.repoOK)
+			if len(checks) != len(tt.want) {
+				t.Fatalf("doctorAPIChecks() = %+v, want %d checks", checks, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := checks[i]
+				if got.name != want.name || got.ok != want.ok {
+					t.Errorf("check %d = %q ok=%v, want %q ok=%v", i, got.name, got.ok, want.name, want.ok)
+				}
+				if !got.ok && got.hint == "" {
+					t.Errorf("failed check %q has no hint", got.name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package browser opens URLs in the user's default web browser.
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens url in the default browser without waiting for it. The
+// launcher's output is discarded so it can't draw over the TUI.
+func Open(url string) error {
+	cmd := command(runtime.GOOS, url)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	cmd.Stdin = nil
+	// Detach from terminal
+	cmd.Env = os.Environ()
+	return cmd.Start()
+}
+
+// command returns the platform's command for opening url
+func command(goos, url string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("cmd", "/c", "start", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
@@ -0,0 +1,27 @@
+package browser
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	const url = "https://github.com/o/r/actions/runs/1"
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"darwin", "open " + url},
+		{"windows", "cmd /c start " + url},
+		{"linux", "xdg-open " + url},
+		{"freebsd", "xdg-open " + url},
+	}
+	for _, tt := range tests {
+		cmd := command(tt.goos, url)
+		args := append([]string{filepath.Base(cmd.Args[0])}, cmd.Args[1:]...)
+		if got := strings.Join(args, " "); got != tt.want {
+			t.Errorf("command(%q) = %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ColorEnabled reports whether output should be colored. In order of
+// precedence: --no-color and NO_COLOR disable color, FORCE_COLOR enables it
+// even when output is piped (FORCE_COLOR=0 disables it), and otherwise color
+// is used only when stdout is a terminal.
+func (c *Config) ColorEnabled() bool {
+	return colorEnabled(c.NoColor, os.Getenv, term.IsTerminal(int(os.Stdout.Fd())))
+}
+
+// ForceColor reports whether FORCE_COLOR requests color regardless of TTY
+func (c *Config) ForceColor() bool {
+	return c.ColorEnabled() && forceColorSet(os.Getenv)
+}
+
+func colorEnabled(noColorFlag bool, getenv func(string) string, isTTY bool) bool {
+	if noColorFlag || getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := getenv("FORCE_COLOR"); force != "" {
+		return forceColorSet(getenv)
+	}
+	return isTTY
+}
+
+// forceColorSet reports whether FORCE_COLOR is set to a value that enables color
+func forceColorSet(getenv func(string) string) bool {
+	switch strings.ToLower(getenv("FORCE_COLOR")) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
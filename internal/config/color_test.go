@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor bool
+		env     map[string]string
+		isTTY   bool
+		want    bool
+	}{
+		{"tty", false, nil, true, true},
+		{"piped", false, nil, false, false},
+		{"flag disables on tty", true, nil, true, false},
+		{"NO_COLOR disables on tty", false, map[string]string{"NO_COLOR": "1"}, true, false},
+		{"FORCE_COLOR enables when piped", false, map[string]string{"FORCE_COLOR": "1"}, false, true},
+		{"FORCE_COLOR=0 disables on tty", false, map[string]string{"FORCE_COLOR": "0"}, true, false},
+		{"FORCE_COLOR=false disables on tty", false, map[string]string{"FORCE_COLOR": "false"}, true, false},
+		{"flag beats FORCE_COLOR", true, map[string]string{"FORCE_COLOR": "1"}, false, false},
+		{"NO_COLOR beats FORCE_COLOR", false, map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := colorEnabled(tt.noColor, getenv, tt.isTTY); got != tt.want {
+				t.Errorf("colorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,11 +3,16 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lance0/cimon/internal/git"
+	"github.com/lance0/cimon/internal/gitlab"
 	"github.com/spf13/pflag"
 )
 
@@ -19,8 +24,12 @@ type RepoSpec struct {
 	Owner  string
 	Repo   string
 	Branch string // Optional: if empty, fetch all branches
+	Status string // Optional run status filter; the TUI's own filter overrides it
 }
 
+// RunStatusFilters are the run status filters the TUI and per-repo config offer
+var RunStatusFilters = []string{"success", "failure", "in_progress", "completed", "queued"}
+
 // Slug returns "owner/repo" format
 func (r *RepoSpec) Slug() string {
 	return r.Owner + "/" + r.Repo
@@ -28,18 +37,109 @@ func (r *RepoSpec) Slug() string {
 
 // Config holds all runtime configuration for cimon
 type Config struct {
+	Provider     string // CI backend: github or gitlab ("" = detect from the git remote)
+	Profile      string // Named settings from cimon.yml's profiles (--profile)
 	Owner        string
 	Repo         string
+	DefaultOwner string // Owner for a bare --repo name outside a git repo (default_owner in cimon.yml)
 	Branch       string
+	AllBranches  bool   // Show runs from every branch (--branch all); Branch is then empty
+	Event        string // Only show runs triggered by this event, e.g. push ("" = any)
 	Watch        bool
+	WatchNew     bool // Keep watching after a run completes and follow newer runs as they start
+	FailFast     bool // Exit 1 as soon as any job fails while watching
 	Poll         time.Duration
 	NoColor      bool
+	Symbols      bool // Text status symbols ([PASS], [FAIL], ...) instead of icons
+	UTC          bool // Show timestamps in UTC rather than local time
+	Mouse        bool // Mouse wheel scrolling and click-to-select in the TUI
+	Compact      bool // One line per job and a trimmed header/footer, for small panes
 	Plain        bool
+	Quiet        bool // Don't print non-fatal warnings to stderr
+	Yes          bool // Skip confirmation prompts (retry/cancel/dispatch --yes)
 	Json         bool
+	Template     string // Go text/template for the run, printed instead of the TUI (--template)
 	Version      bool
 	Notify       bool       // v0.7 - Enable desktop notifications on completion
 	Hook         string     // v0.7 - Path to hook script to execute on completion
+	Bell         bool       // Ring the terminal bell and flash the header on completion
 	Repositories []RepoSpec // v0.8 - Multiple repos for multi-repo mode
+	Since        time.Time  // Only show runs created at or after this time (zero = no limit)
+	Until        time.Time  // Only show runs created at or before this time (zero = no limit)
+
+	// Retry policy for GitHub API requests
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	ExitOn []string // Conclusions (or "pending") that exit 1; empty uses the default mapping
+	Wait   bool     // Wait for the latest run to complete without the TUI, then exit
+	Open   bool     // Open the run in the browser and exit (--open, cimon open)
+
+	Expect  string        // Conclusion --wait must end in to exit 0 ("" = use --exit-on)
+	Timeout time.Duration // Give up on --wait/--watch after this long, exiting ExitTimeout (0 = no limit)
+
+	Limit    int  // Number of runs to output with --json or --plain (1 = latest run only)
+	WithJobs bool // Include jobs for each run in --json/--plain list output
+	NoJobs   bool // Don't fetch jobs at all; show only the run
+
+	NoCache    bool // Don't read or write the on-disk log cache
+	ClearCache bool // Clear the log cache and exit
+
+	NoState      bool   // Don't remember branch/filter between sessions
+	StatePath    string // State file the TUI saves branch/filter to ("" = don't save)
+	StatusFilter string // Initial run status filter for the TUI
+
+	Tail int // Show only the last N lines of job logs (0 = the whole log)
+
+	// Subcommand run selection (retry/cancel/logs); zero means the latest run
+	RunNumber int
+	RunID     int64
+
+	// text/template overrides for notification title/body (see notify.NotificationData)
+	NotifyTitleTemplate string
+	NotifyBodyTemplate  string
+
+	CACertFile string // PEM bundle of extra CAs to trust for GitHub requests
+	TokenFile  string // File holding the API token, which beats token variables
+
+	InsecureSkipVerify bool // Skip TLS certificate verification (testing only)
+
+	MaxLogBytes int64 // Cap on a job log read into memory (0 = no limit)
+
+	RefreshOnFocus bool // Refresh the TUI when the terminal regains focus
+
+	ExportFormat string // Format of logs saved with s in the log viewer: txt, json or html
+	NoRedact     bool   // Don't mask likely secrets in saved or shared logs
+
+	ReposFile string // File to read the multi-repo list from, one per line ("-" = stdin)
+
+	BranchPattern string // Glob of branches whose runs are shown together, e.g. release/* (--branch-pattern)
+
+	flagsSet map[string]bool // Flags given on the command line, which beat a profile
+}
+
+// applyFlagDefaults sets each flag in defaults that wasn't given on the
+// command line, so defaults go through the same parsing and validation as
+// flags. It runs after flagsSet is recorded: a profile still beats them.
+func applyFlagDefaults(fs *pflag.FlagSet, defaults map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(defaults)) {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("%s defaults: unknown flag %q", DefaultConfigPath(), name)
+		}
+		if fs.Changed(name) {
+			continue
+		}
+		if err := fs.Set(name, defaults[name]); err != nil {
+			return fmt.Errorf("%s defaults: invalid value %q for --%s: %w", DefaultConfigPath(), defaults[name], name, err)
+		}
+	}
+	return nil
+}
+
+// changed reports whether the named flag was given on the command line
+func (c *Config) changed(name string) bool {
+	return c.flagsSet[name]
 }
 
 // IsMultiRepo returns true if multiple repos are configured (v0.8)
@@ -47,9 +147,64 @@ func (c *Config) IsMultiRepo() bool {
 	return len(c.Repositories) > 1
 }
 
+// UseSingleRepository switches a one-entry repo list (from --repos or the
+// config file) to single-repo mode, keeping the entry's branch and status
+// filter
+func (c *Config) UseSingleRepository() {
+	if len(c.Repositories) != 1 {
+		return
+	}
+	spec := c.Repositories[0]
+	c.Owner = spec.Owner
+	c.Repo = spec.Repo
+	c.Branch = spec.Branch
+	if c.StatusFilter == "" {
+		c.StatusFilter = spec.Status
+	}
+	c.Repositories = nil
+}
+
+// AllBranches is the --branch value that shows runs from every branch
+const AllBranches = "all"
+
+// CI providers for --provider
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// Log export formats for --export-format
+const (
+	ExportFormatTxt  = "txt"
+	ExportFormatJSON = "json"
+	ExportFormatHTML = "html"
+)
+
 // Default values
 const (
-	DefaultPollInterval = 5 * time.Second
+	DefaultPollInterval   = 5 * time.Second
+	MinPollInterval       = 2 * time.Second // faster polling burns through the API rate limit
+	MaxLimit              = 100             // GitHub's maximum page size
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = 1 * time.Second
+	DefaultRetryMaxDelay  = 30 * time.Second
+	DefaultMaxLogBytes    = 50 << 20 // 50 MiB
+
+	// PollWarnCallsPerMinute is the estimated watch-mode request rate above
+	// which cimon warns: 60 a minute is 3,600 of GitHub's 5,000 an hour
+	PollWarnCallsPerMinute = 60
+)
+
+// Environment variables that override the network defaults
+const (
+	EnvMaxRetries     = "CIMON_MAX_RETRIES"
+	EnvRetryBaseDelay = "CIMON_RETRY_BASE_DELAY"
+	EnvRetryMaxDelay  = "CIMON_RETRY_MAX_DELAY"
+	EnvCACert         = "CIMON_CA_CERT"
+	EnvTokenFile      = "CIMON_TOKEN_FILE"
+
+	// EnvHost is gh's GitHub Enterprise host setting
+	EnvHost = "GH_HOST"
 )
 
 var (
@@ -66,30 +221,177 @@ var (
 // Parse parses command-line flags and resolves configuration.
 // It auto-detects repo and branch from git if not specified.
 func Parse(args []string) (*Config, error) {
+	return ParseWithDefaults(args, nil)
+}
+
+// ParseWithDefaults is Parse with cimon.yml's defaults section: flag values,
+// by flag name, used for every flag not given on the command line
+func ParseWithDefaults(args []string, defaults map[string]string) (*Config, error) {
 	cfg := &Config{}
 
 	fs := pflag.NewFlagSet("cimon", pflag.ContinueOnError)
 
 	var repoFlag string
 	var reposFlag string
-	fs.StringVarP(&repoFlag, "repo", "r", "", "Repository in owner/name format")
-	fs.StringVar(&reposFlag, "repos", "", "Comma-separated repos for multi-repo mode (owner/repo1,owner/repo2)")
-	fs.StringVarP(&cfg.Branch, "branch", "b", "", "Branch name")
+	var sinceFlag, untilFlag string
+	var exitOnFlag string
+	fs.StringVarP(&repoFlag, "repo", "r", "", "Repository in owner/name format, or a name owned by the current repo's owner")
+	fs.StringVar(&cfg.Provider, "provider", "", "CI provider: github or gitlab (default: detected from the git remote)")
+	fs.StringVar(&reposFlag, "repos", "", "Comma-separated repos for multi-repo mode (owner/repo1,owner/repo2), or - to read them from stdin")
+	fs.StringVar(&cfg.ReposFile, "repos-file", "", "Read multi-repo repos from a file, one owner/repo per line")
+	fs.StringVar(&cfg.Profile, "profile", "", "Use a named profile from cimon.yml (flags override its settings)")
+	fs.StringVarP(&cfg.Branch, "branch", "b", "", "Branch name (\"all\" for every branch)")
+	fs.StringVar(&cfg.BranchPattern, "branch-pattern", "", "Show runs from every branch matching a glob, e.g. 'release/*'")
+	fs.StringVar(&cfg.Event, "event", "", "Only show runs triggered by this event (push, pull_request, schedule, ...)")
 	fs.BoolVarP(&cfg.Watch, "watch", "w", false, "Watch mode - poll until completion")
+	fs.BoolVar(&cfg.WatchNew, "watch-new", false, "Watch mode that follows the newest run, switching when a newer one starts")
+	fs.BoolVar(&cfg.FailFast, "fail-fast", false, "Exit 1 as soon as any job fails (watch mode)")
 	fs.DurationVarP(&cfg.Poll, "poll", "p", DefaultPollInterval, "Poll interval for watch mode")
+	fs.StringVar(&cfg.Template, "template", "", "Print the run with a Go template, e.g. '{{.Run.RunNumber}} {{.Run.Status}}'")
 	fs.BoolVar(&cfg.NoColor, "no-color", false, "Disable color output")
+	fs.BoolVar(&cfg.Symbols, "symbols", false, "Text status symbols ([PASS], [FAIL], [RUN], ...) that don't rely on color")
+	fs.BoolVar(&cfg.UTC, "utc", false, "Show TUI and doctor timestamps in UTC instead of local time (--plain is always UTC)")
+	fs.BoolVar(&cfg.Mouse, "mouse", false, "Enable mouse wheel scrolling and click-to-select in the TUI")
+	fs.BoolVar(&cfg.Compact, "compact", false, "Compact TUI layout: one tight line per job, for small terminals and panes")
+	fs.StringVar(&cfg.ExportFormat, "export-format", ExportFormatTxt, "Format for logs saved with s in the log viewer: txt, json or html")
+	fs.BoolVar(&cfg.NoRedact, "no-redact", false, "Don't mask likely secrets (tokens, keys) in saved or shared logs")
+	fs.BoolVar(&cfg.RefreshOnFocus, "refresh-on-focus", false, "Refresh the TUI when the terminal regains focus (needs focus reporting support)")
 	fs.BoolVar(&cfg.Plain, "plain", false, "Plain text output (no TUI)")
+	fs.BoolVarP(&cfg.Quiet, "quiet", "q", false, "Don't print warnings (errors are still printed)")
 	fs.BoolVar(&cfg.Json, "json", false, "JSON output for scripting")
 	fs.BoolVarP(&cfg.Version, "version", "v", false, "Show version")
 	fs.BoolVar(&cfg.Notify, "notify", false, "Show desktop notification on completion (watch mode)")
 	fs.StringVar(&cfg.Hook, "hook", "", "Run script on completion with env vars (watch mode)")
+	fs.BoolVar(&cfg.Bell, "bell", false, "Ring the terminal bell and flash the header on completion (watch mode)")
+	fs.StringVar(&cfg.NotifyTitleTemplate, "notify-title-template", "", "Go template for notification titles, e.g. \"{{.Icon}} {{.Repo}} {{.Conclusion}}\"")
+	fs.StringVar(&cfg.NotifyBodyTemplate, "notify-body-template", "", "Go template for notification bodies")
+	fs.StringVar(&sinceFlag, "since", "", "Only show runs created since a duration ago (24h, 7d) or date (2024-01-01)")
+	fs.StringVar(&untilFlag, "until", "", "Only show runs created until a duration ago (24h, 7d) or date (2024-01-01)")
+	fs.StringVar(&exitOnFlag, "exit-on", "", "Conclusions that exit 1, e.g. failure,cancelled,timed_out (pending = not completed)")
+	fs.BoolVar(&cfg.Wait, "wait", false, "Wait for the latest run to complete, print the result and exit")
+	fs.StringVar(&cfg.Expect, "expect", "", "With --wait, exit 0 only if the run concludes with this, e.g. success")
+	fs.DurationVar(&cfg.Timeout, "timeout", 0, "With --wait or --watch, exit 124 if the run hasn't completed after this long, e.g. 30m")
+	fs.BoolVar(&cfg.Open, "open", false, "Open the latest run (or --run) in the browser and exit")
+	fs.IntVar(&cfg.Limit, "limit", 1, "Number of recent runs to output with --json or --plain (max 100)")
+	fs.BoolVar(&cfg.WithJobs, "with-jobs", false, "Include each run's jobs in --json/--plain --limit output")
+	fs.BoolVar(&cfg.NoJobs, "no-jobs", false, "Don't fetch jobs, only the run (halves API calls)")
+	fs.BoolVar(&cfg.NoCache, "no-cache", false, "Don't cache completed job logs on disk")
+	fs.BoolVar(&cfg.ClearCache, "clear-cache", false, "Clear the log cache and exit")
+	fs.BoolVar(&cfg.NoState, "no-state", false, "Don't remember the last branch and status filter per repo")
+	fs.IntVar(&cfg.RunNumber, "run", 0, "Open a specific run by number instead of the latest")
+	fs.Int64Var(&cfg.RunID, "run-id", 0, "Open a specific run by ID instead of the latest")
+	fs.IntVar(&cfg.Tail, "tail", 0, "Open job logs at their last N lines (0 = whole log)")
+	if err := AddNetworkFlags(fs, cfg); err != nil {
+		return nil, err
+	}
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
 	}
+	cfg.flagsSet = map[string]bool{}
+	fs.Visit(func(f *pflag.Flag) {
+		cfg.flagsSet[f.Name] = true
+	})
+	if err := applyFlagDefaults(fs, defaults); err != nil {
+		return nil, err
+	}
+	cfg.applyAllBranches()
+	if err := cfg.ValidateRetry(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidatePoll(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateEvent(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateProvider(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateBranchPattern(); err != nil {
+		return nil, err
+	}
+	switch cfg.ExportFormat {
+	case ExportFormatTxt, ExportFormatJSON, ExportFormatHTML:
+	default:
+		return nil, fmt.Errorf("invalid --export-format %q: expected %s, %s or %s", cfg.ExportFormat, ExportFormatTxt, ExportFormatJSON, ExportFormatHTML)
+	}
+	if cfg.Limit < 1 || cfg.Limit > MaxLimit {
+		return nil, fmt.Errorf("--limit must be between 1 and %d (got %d)", MaxLimit, cfg.Limit)
+	}
+	// --watch-new is a kind of --watch
+	if cfg.WatchNew {
+		cfg.Watch = true
+	}
+	// A profile can turn --watch on or off, so ApplyProfile checks these then
+	if cfg.Profile == "" {
+		if err := cfg.ValidateWatch(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.NoJobs && (cfg.WithJobs || cfg.FailFast) {
+		return nil, fmt.Errorf("--no-jobs cannot be combined with --with-jobs or --fail-fast")
+	}
+	if err := cfg.ValidateRunSelection(); err != nil {
+		return nil, err
+	}
+	if cfg.Tail < 0 {
+		return nil, fmt.Errorf("--tail must not be negative (got %d)", cfg.Tail)
+	}
+	if cfg.HasRunSelection() && cfg.Limit > 1 {
+		return nil, fmt.Errorf("cannot use --run or --run-id with --limit")
+	}
+	if cfg.HasRunSelection() && cfg.WatchNew {
+		return nil, fmt.Errorf("--watch-new follows the newest run and cannot be combined with --run or --run-id")
+	}
+	if cfg.Template != "" && (cfg.Plain || cfg.Json) {
+		return nil, fmt.Errorf("--template cannot be combined with --plain or --json")
+	}
+	if cfg.Template != "" && cfg.Limit > 1 {
+		return nil, fmt.Errorf("--template prints a single run and cannot be used with --limit")
+	}
 
-	// Handle --repos flag (v0.8 multi-repo mode)
-	if reposFlag != "" {
+	if err := cfg.ValidateExpect(exitOnFlag != ""); err != nil {
+		return nil, err
+	}
+
+	// Handle --exit-on conclusion set
+	if exitOnFlag != "" {
+		exitOn, err := ParseExitOn(exitOnFlag)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ExitOn = exitOn
+	}
+
+	// Handle --since/--until time window
+	now := time.Now()
+	if sinceFlag != "" {
+		t, err := ParseTimeFlag(sinceFlag, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since value: %w", err)
+		}
+		cfg.Since = t
+	}
+	if untilFlag != "" {
+		t, err := ParseTimeFlag(untilFlag, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until value: %w", err)
+		}
+		cfg.Until = t
+	}
+	if !cfg.Since.IsZero() && !cfg.Until.IsZero() && cfg.Until.Before(cfg.Since) {
+		return nil, fmt.Errorf("--until (%s) is before --since (%s)", untilFlag, sinceFlag)
+	}
+
+	// Handle --repos flag (v0.8 multi-repo mode); --repos - and --repos-file
+	// are read by the caller, since reading stdin doesn't belong in parsing
+	if reposFlag != "" && cfg.ReposFile != "" {
+		return nil, fmt.Errorf("--repos and --repos-file can't be used together")
+	}
+	if reposFlag == StdinRepos {
+		cfg.ReposFile = StdinRepos
+	} else if reposFlag != "" {
 		specs, err := ParseReposFlag(reposFlag)
 		if err != nil {
 			return nil, err
@@ -99,41 +401,310 @@ func Parse(args []string) (*Config, error) {
 
 	// Handle --repo flag (single repo mode)
 	if repoFlag != "" {
-		parts := strings.SplitN(repoFlag, "/", 2)
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-			return nil, fmt.Errorf("invalid repo format %q: expected owner/name", repoFlag)
+		owner, repo, err := ParseRepoFlag(repoFlag)
+		if err != nil {
+			return nil, err
 		}
-		cfg.Owner = parts[0]
-		cfg.Repo = parts[1]
+		cfg.Owner = owner
+		cfg.Repo = repo
 	}
 
 	return cfg, nil
 }
 
-// ParseReposFlag parses the --repos flag into RepoSpec slice (v0.8)
+// AddNetworkFlags registers the --max-retries, --retry-base-delay,
+// --retry-max-delay, --ca-cert, --token-file, --insecure-skip-verify and
+// --max-log-bytes flags on fs. Their
+// defaults come from the CIMON_* environment variables when set, so flags
+// take precedence over env.
+func AddNetworkFlags(fs *pflag.FlagSet, cfg *Config) error {
+	maxRetries := DefaultMaxRetries
+	baseDelay := DefaultRetryBaseDelay
+	maxDelay := DefaultRetryMaxDelay
+
+	if v := os.Getenv(EnvMaxRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: expected an integer", EnvMaxRetries, v)
+		}
+		maxRetries = n
+	}
+	if v := os.Getenv(EnvRetryBaseDelay); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", EnvRetryBaseDelay, v, err)
+		}
+		baseDelay = d
+	}
+	if v := os.Getenv(EnvRetryMaxDelay); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", EnvRetryMaxDelay, v, err)
+		}
+		maxDelay = d
+	}
+
+	fs.IntVar(&cfg.MaxRetries, "max-retries", maxRetries, "Max retries for failed API requests (env "+EnvMaxRetries+")")
+	fs.DurationVar(&cfg.RetryBaseDelay, "retry-base-delay", baseDelay, "Initial retry backoff delay (env "+EnvRetryBaseDelay+")")
+	fs.DurationVar(&cfg.RetryMaxDelay, "retry-max-delay", maxDelay, "Maximum retry backoff delay (env "+EnvRetryMaxDelay+")")
+	fs.StringVar(&cfg.CACertFile, "ca-cert", os.Getenv(EnvCACert), "PEM file of extra CA certificates to trust (env "+EnvCACert+")")
+	fs.StringVar(&cfg.TokenFile, "token-file", os.Getenv(EnvTokenFile), "Read the API token from this file instead of GH_TOKEN/GITHUB_TOKEN (env "+EnvTokenFile+")")
+	fs.BoolVar(&cfg.InsecureSkipVerify, "insecure-skip-verify", false, "INSECURE: skip TLS certificate verification (testing against self-signed servers only)")
+	fs.Int64Var(&cfg.MaxLogBytes, "max-log-bytes", DefaultMaxLogBytes, "Max size of a job log held in memory; larger logs fail to load (0 = no limit)")
+	return nil
+}
+
+// ValidateRetry checks that the retry, TLS and log download settings are
+// usable
+func (c *Config) ValidateRetry() error {
+	if c.InsecureSkipVerify && c.CACertFile != "" {
+		return fmt.Errorf("--insecure-skip-verify ignores --ca-cert (or %s); use one or the other", EnvCACert)
+	}
+	if c.MaxLogBytes < 0 {
+		return fmt.Errorf("--max-log-bytes must not be negative (got %d)", c.MaxLogBytes)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("--max-retries must not be negative (got %d)", c.MaxRetries)
+	}
+	if c.RetryBaseDelay <= 0 {
+		return fmt.Errorf("--retry-base-delay must be positive (got %s)", c.RetryBaseDelay)
+	}
+	if c.RetryMaxDelay < c.RetryBaseDelay {
+		return fmt.Errorf("--retry-max-delay (%s) is less than --retry-base-delay (%s)", c.RetryMaxDelay, c.RetryBaseDelay)
+	}
+	return nil
+}
+
+// ValidatePoll rejects poll intervals short enough to hit the API rate limit
+func (c *Config) ValidatePoll() error {
+	if c.Poll < MinPollInterval {
+		return fmt.Errorf("--poll must be at least %s (got %s)", MinPollInterval, c.Poll)
+	}
+	return nil
+}
+
+// ValidateWatch checks the flags that need, or can't be used with, --watch,
+// which a profile can also turn on or off
+func (c *Config) ValidateWatch() error {
+	if c.FailFast && !c.Watch {
+		return fmt.Errorf("--fail-fast requires --watch")
+	}
+	if c.Timeout > 0 && !c.Wait && !c.Watch {
+		return fmt.Errorf("--timeout requires --wait or --watch")
+	}
+	if c.Open && (c.Watch || c.Wait || c.Plain || c.Json || c.Template != "") {
+		return fmt.Errorf("--open cannot be combined with --watch, --wait, --plain, --json or --template")
+	}
+	return nil
+}
+
+// ValidateProvider checks the --provider flag
+func (c *Config) ValidateProvider() error {
+	switch c.Provider {
+	case "", ProviderGitHub, ProviderGitLab:
+		return nil
+	default:
+		return fmt.Errorf("invalid --provider %q: expected %s or %s", c.Provider, ProviderGitHub, ProviderGitLab)
+	}
+}
+
+// ValidateBranchPattern checks that --branch-pattern is a valid glob and
+// isn't combined with a single branch or an output mode that shows one run
+func (c *Config) ValidateBranchPattern() error {
+	if c.BranchPattern == "" {
+		return nil
+	}
+	if _, err := path.Match(c.BranchPattern, ""); err != nil {
+		return fmt.Errorf("invalid --branch-pattern %q: %w", c.BranchPattern, err)
+	}
+	if c.Branch != "" || c.AllBranches {
+		return fmt.Errorf("--branch-pattern cannot be combined with --branch")
+	}
+	if c.HasRunSelection() {
+		return fmt.Errorf("--branch-pattern cannot be combined with --run or --run-id")
+	}
+	if c.Plain || c.Json || c.Wait || c.Open || c.Template != "" {
+		return fmt.Errorf("--branch-pattern is only supported in the TUI, not with --plain, --json, --wait, --open or --template")
+	}
+	return nil
+}
+
+// ValidateEvent checks that --event looks like a GitHub event name. Events
+// aren't checked against a list, since GitHub keeps adding them.
+func (c *Config) ValidateEvent() error {
+	for _, r := range c.Event {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return fmt.Errorf("invalid --event %q: expected an event name such as push or pull_request", c.Event)
+		}
+	}
+	return nil
+}
+
+// PollCallsPerMinute estimates the API requests watch mode makes a minute:
+// a run list per repo in multi-repo mode, or the runs and the latest run's
+// jobs for a single repo
+func (c *Config) PollCallsPerMinute() int {
+	if c.Poll <= 0 {
+		return 0
+	}
+	perPoll := 2
+	if c.IsMultiRepo() {
+		perPoll = len(c.Repositories)
+	}
+	return int(float64(perPoll) * float64(time.Minute) / float64(c.Poll))
+}
+
+// PollWarning returns a warning when watch mode would poll fast enough to
+// risk the API rate limit, or "" if the rate is reasonable
+func (c *Config) PollWarning() string {
+	calls := c.PollCallsPerMinute()
+	if calls <= PollWarnCallsPerMinute {
+		return ""
+	}
+	target := "1 repo"
+	if c.IsMultiRepo() {
+		target = fmt.Sprintf("%d repos", len(c.Repositories))
+	}
+	return fmt.Sprintf("polling %s every %s makes about %d API requests a minute (%d an hour, GitHub allows 5,000); consider a longer --poll", target, c.Poll, calls, calls*60)
+}
+
+// HasRunSelection reports whether --run or --run-id picks a specific run
+func (c *Config) HasRunSelection() bool {
+	return c.RunNumber != 0 || c.RunID != 0
+}
+
+// ValidateRunSelection checks the --run and --run-id flags
+func (c *Config) ValidateRunSelection() error {
+	if c.RunNumber < 0 || c.RunID < 0 {
+		return fmt.Errorf("--run and --run-id must be positive")
+	}
+	if c.RunNumber != 0 && c.RunID != 0 {
+		return fmt.Errorf("cannot use both --run and --run-id")
+	}
+	return nil
+}
+
+// ParseTimeFlag parses a --since/--until value relative to now.
+// Accepts a duration ago ("90m", "24h", "7d") or an absolute date/time
+// ("2024-01-01", "2024-01-01T15:04:05Z").
+func ParseTimeFlag(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	// Day durations are not supported by time.ParseDuration
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("%q: duration must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q: expected a duration (e.g. 24h, 7d) or date (YYYY-MM-DD)", value)
+}
+
+// CreatedFilter returns the GitHub "created" query qualifier for the
+// --since/--until window, or "" if no window is set.
+func (c *Config) CreatedFilter() string {
+	const layout = "2006-01-02T15:04:05Z"
+	switch {
+	case !c.Since.IsZero() && !c.Until.IsZero():
+		return c.Since.UTC().Format(layout) + ".." + c.Until.UTC().Format(layout)
+	case !c.Since.IsZero():
+		return ">=" + c.Since.UTC().Format(layout)
+	case !c.Until.IsZero():
+		return "<=" + c.Until.UTC().Format(layout)
+	default:
+		return ""
+	}
+}
+
+// ParseRepoFlag parses a --repo value: "owner/name", or a bare "name" whose
+// owner is left empty for ResolveOwner to fill in
+func ParseRepoFlag(value string) (owner, repo string, err error) {
+	if !strings.Contains(value, "/") {
+		return "", value, nil
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid repo format %q: expected owner/name or name", value)
+	}
+	return parts[0], parts[1], nil
+}
+
+// StdinRepos is the --repos value that reads the repo list from stdin
+const StdinRepos = "-"
+
+// ParseReposFlag parses the --repos flag into RepoSpec slice (v0.8). Repos
+// are separated by commas or newlines, so it also parses a --repos-file
+// list, where blank lines and # comments are skipped.
 func ParseReposFlag(flag string) ([]RepoSpec, error) {
 	if flag == "" {
 		return nil, nil
 	}
 
-	repos := strings.Split(flag, ",")
+	lines := strings.Split(flag, "\n")
 	var specs []RepoSpec
 
-	for _, r := range repos {
-		r = strings.TrimSpace(r)
-		if r == "" {
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
 			continue
 		}
-		parts := strings.SplitN(r, "/", 2)
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-			return nil, fmt.Errorf("invalid repo format %q: expected owner/repo", r)
+		for _, r := range strings.Split(line, ",") {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+			parts := strings.SplitN(r, "/", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				if len(lines) > 1 {
+					return nil, fmt.Errorf("line %d: invalid repo format %q: expected owner/repo", i+1, r)
+				}
+				return nil, fmt.Errorf("invalid repo format %q: expected owner/repo", r)
+			}
+			specs = append(specs, RepoSpec{Owner: parts[0], Repo: parts[1]})
 		}
-		specs = append(specs, RepoSpec{Owner: parts[0], Repo: parts[1]})
 	}
 
 	return specs, nil
 }
 
+// ResolveOwner fills in the owner of a bare --repo name: the owner of the
+// current git repo's remote, or DefaultOwner outside a git repo
+func (c *Config) ResolveOwner() error {
+	if c.Owner != "" || c.Repo == "" {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not get working directory: %w", err)
+	}
+	return c.resolveOwner(cwd)
+}
+
+func (c *Config) resolveOwner(dir string) error {
+	info, err := c.repoInfo(dir)
+	switch {
+	case err == nil:
+		c.Owner = info.Owner
+	case c.DefaultOwner != "":
+		c.Owner = c.DefaultOwner
+	default:
+		return fmt.Errorf("%w: no owner for --repo %s: %v\nPass --repo owner/%s, run inside a git repo, or set default_owner in cimon.yml", ErrNoRepo, c.Repo, err, c.Repo)
+	}
+	return nil
+}
+
 // Resolve fills in missing Owner, Repo, and Branch from git.
 // Should be called after Parse.
 func (c *Config) Resolve() error {
@@ -143,9 +714,16 @@ func (c *Config) Resolve() error {
 		return fmt.Errorf("could not get working directory: %w", err)
 	}
 
+	// A bare --repo name only needs its owner
+	if c.Owner == "" && c.Repo != "" {
+		if err := c.resolveOwner(cwd); err != nil {
+			return err
+		}
+	}
+
 	// Resolve repo if not specified
 	if c.Owner == "" || c.Repo == "" {
-		info, err := git.GetRepoInfo(cwd)
+		info, err := c.repoInfo(cwd)
 		if err != nil {
 			return fmt.Errorf("%w: %v\nRun inside a git repo or pass --repo owner/name", ErrNoRepo, err)
 		}
@@ -154,7 +732,8 @@ func (c *Config) Resolve() error {
 	}
 
 	// Resolve branch if not specified
-	if c.Branch == "" {
+	c.applyAllBranches()
+	if c.Branch == "" && !c.AllBranches && c.BranchPattern == "" {
 		branch, err := git.GetBranch(cwd)
 		if err != nil {
 			// If in detached HEAD state, we'll handle it after client creation
@@ -169,7 +748,64 @@ func (c *Config) Resolve() error {
 	return nil
 }
 
+// repoInfo reads the owner and repo from the git remote in dir, which must
+// be on the provider's host. Without --provider, a remote on the GitLab
+// host selects GitLab.
+func (c *Config) repoInfo(dir string) (git.RepoInfo, error) {
+	if c.Provider != "" {
+		return c.RemoteRepoInfo(dir)
+	}
+	info, err := git.GetRepoInfo(dir, c.GitHubHost())
+	if err == nil {
+		return info, nil
+	}
+	if gitlabInfo, gitlabErr := git.GetGitLabRepoInfo(dir, gitlab.Host()); gitlabErr == nil {
+		c.Provider = ProviderGitLab
+		return gitlabInfo, nil
+	}
+	return info, err
+}
+
+// RemoteRepoInfo reads the owner and repo from the git remote in dir, which
+// must be on the provider's host. A GitLab owner can be a nested group.
+func (c *Config) RemoteRepoInfo(dir string) (git.RepoInfo, error) {
+	if c.Provider == ProviderGitLab {
+		return git.GetGitLabRepoInfo(dir, gitlab.Host())
+	}
+	return git.GetRepoInfo(dir, c.GitHubHost())
+}
+
+// GitHubHost returns the host git remotes must be on: GH_HOST for GitHub
+// Enterprise, otherwise github.com
+func (c *Config) GitHubHost() string {
+	if host := os.Getenv(EnvHost); host != "" {
+		return host
+	}
+	return git.DefaultHost
+}
+
 // RepoSlug returns the owner/repo format
 func (c *Config) RepoSlug() string {
 	return c.Owner + "/" + c.Repo
 }
+
+// applyAllBranches turns --branch all into an empty branch, which the API
+// treats as no branch filter
+func (c *Config) applyAllBranches() {
+	if c.Branch == AllBranches {
+		c.AllBranches = true
+		c.Branch = ""
+	}
+}
+
+// BranchLabel returns the branch for display: the --branch-pattern glob, or
+// "all branches" when runs aren't filtered by branch
+func (c *Config) BranchLabel() string {
+	if c.BranchPattern != "" {
+		return c.BranchPattern
+	}
+	if c.Branch == "" {
+		return "all branches"
+	}
+	return c.Branch
+}
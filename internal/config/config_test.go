@@ -1,7 +1,15 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/lance0/cimon/internal/gitlab"
 )
 
 func TestRepoSpecSlug(t *testing.T) {
@@ -67,6 +75,21 @@ func TestParseReposFlag(t *testing.T) {
 				{Owner: "owner2", Repo: "repo2"},
 			},
 		},
+		{
+			name: "one per line with comments",
+			flag: "# services\nowner1/repo1\n\n  owner2/repo2  \nowner3/repo3,owner4/repo4\n",
+			want: []RepoSpec{
+				{Owner: "owner1", Repo: "repo1"},
+				{Owner: "owner2", Repo: "repo2"},
+				{Owner: "owner3", Repo: "repo3"},
+				{Owner: "owner4", Repo: "repo4"},
+			},
+		},
+		{
+			name:    "invalid line",
+			flag:    "owner1/repo1\nnot-a-repo\n",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,6 +150,50 @@ func TestConfigIsMultiRepo(t *testing.T) {
 	}
 }
 
+func TestUseSingleRepository(t *testing.T) {
+	cfg := Config{Repositories: []RepoSpec{{Owner: "o", Repo: "r", Branch: "main", Status: "failure"}}}
+	cfg.UseSingleRepository()
+	if cfg.IsMultiRepo() || len(cfg.Repositories) != 0 || cfg.RepoSlug() != "o/r" || cfg.Branch != "main" || cfg.StatusFilter != "failure" {
+		t.Errorf("collapsed config = %+v", cfg)
+	}
+
+	// A status filter already set wins over the repo's own
+	cfg = Config{StatusFilter: "success", Repositories: []RepoSpec{{Owner: "o", Repo: "r", Status: "failure"}}}
+	cfg.UseSingleRepository()
+	if cfg.StatusFilter != "success" {
+		t.Errorf("StatusFilter = %q, want success", cfg.StatusFilter)
+	}
+}
+
+func TestParseReposFlagReportsLine(t *testing.T) {
+	_, err := ParseReposFlag("owner1/repo1\nowner2/repo2\nnot-a-repo\n")
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("ParseReposFlag() error = %v, want it to name line 3", err)
+	}
+}
+
+func TestParseReposFromStdinOrFile(t *testing.T) {
+	cfg, err := Parse([]string{"--repos", "-"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.ReposFile != StdinRepos || len(cfg.Repositories) != 0 {
+		t.Errorf("--repos -: ReposFile = %q, Repositories = %v; want stdin left to the caller", cfg.ReposFile, cfg.Repositories)
+	}
+
+	cfg, err = Parse([]string{"--repos-file", "repos.txt"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.ReposFile != "repos.txt" {
+		t.Errorf("ReposFile = %q, want repos.txt", cfg.ReposFile)
+	}
+
+	if _, err := Parse([]string{"--repos", "a/b", "--repos-file", "repos.txt"}); err == nil {
+		t.Error("Parse() with --repos and --repos-file should fail")
+	}
+}
+
 func TestParseWithReposFlag(t *testing.T) {
 	args := []string{"--repos", "owner1/repo1,owner2/repo2"}
 	cfg, err := Parse(args)
@@ -185,6 +252,13 @@ func TestParseFlags(t *testing.T) {
 				return c.Plain
 			},
 		},
+		{
+			name: "quiet shorthand",
+			args: []string{"-q"},
+			check: func(c *Config) bool {
+				return c.Quiet
+			},
+		},
 		{
 			name: "json flag",
 			args: []string{"--json"},
@@ -192,6 +266,30 @@ func TestParseFlags(t *testing.T) {
 				return c.Json
 			},
 		},
+		{
+			name: "limit defaults to latest run only",
+			args: []string{"--json"},
+			check: func(c *Config) bool {
+				return c.Limit == 1 && !c.WithJobs
+			},
+		},
+		{
+			name: "limit and with-jobs flags",
+			args: []string{"--json", "--limit", "10", "--with-jobs"},
+			check: func(c *Config) bool {
+				return c.Limit == 10 && c.WithJobs
+			},
+		},
+		{
+			name:    "limit out of range",
+			args:    []string{"--limit", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "limit above page size",
+			args:    []string{"--limit", "101"},
+			wantErr: true,
+		},
 		{
 			name: "no-color flag",
 			args: []string{"--no-color"},
@@ -220,9 +318,21 @@ func TestParseFlags(t *testing.T) {
 				return c.Owner == "owner" && c.Repo == "repo"
 			},
 		},
+		{
+			name: "bare repo name",
+			args: []string{"--repo", "repo"},
+			check: func(c *Config) bool {
+				return c.Owner == "" && c.Repo == "repo"
+			},
+		},
 		{
 			name:    "invalid repo format",
-			args:    []string{"--repo", "invalid"},
+			args:    []string{"--repo", "owner/"},
+			wantErr: true,
+		},
+		{
+			name:    "missing owner",
+			args:    []string{"--repo", "/repo"},
 			wantErr: true,
 		},
 		{
@@ -252,3 +362,490 @@ func TestParseFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"hours", "24h", now.Add(-24 * time.Hour), false},
+		{"minutes", "90m", now.Add(-90 * time.Minute), false},
+		{"days", "7d", now.AddDate(0, 0, -7), false},
+		{"rfc3339", "2024-01-01T10:00:00Z", time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), false},
+		{"date", "2024-01-01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), false},
+		{"negative duration", "-1h", time.Time{}, true},
+		{"garbage", "yesterday", time.Time{}, true},
+		{"empty", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeFlag(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeFlag(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseTimeFlag(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatedFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"no window", Config{}, ""},
+		{"since only", Config{Since: since}, ">=2024-01-01T00:00:00Z"},
+		{"until only", Config{Until: until}, "<=2024-01-31T00:00:00Z"},
+		{"range", Config{Since: since, Until: until}, "2024-01-01T00:00:00Z..2024-01-31T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.CreatedFilter(); got != tt.want {
+				t.Errorf("CreatedFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSinceUntilFlags(t *testing.T) {
+	if _, err := Parse([]string{"--since", "not-a-time"}); err == nil {
+		t.Error("Parse() with invalid --since should fail")
+	}
+	if _, err := Parse([]string{"--since", "2024-02-01", "--until", "2024-01-01"}); err == nil {
+		t.Error("Parse() with --until before --since should fail")
+	}
+	cfg, err := Parse([]string{"--since", "24h"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.Since.IsZero() || !cfg.Until.IsZero() {
+		t.Errorf("Parse() Since = %v, Until = %v, want only Since set", cfg.Since, cfg.Until)
+	}
+}
+
+func TestParseRetryFlags(t *testing.T) {
+	cfg, err := Parse([]string{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.MaxRetries != DefaultMaxRetries || cfg.RetryBaseDelay != DefaultRetryBaseDelay || cfg.RetryMaxDelay != DefaultRetryMaxDelay {
+		t.Errorf("Parse() retry = %d/%v/%v, want defaults", cfg.MaxRetries, cfg.RetryBaseDelay, cfg.RetryMaxDelay)
+	}
+
+	// Env overrides defaults, flags override env
+	t.Setenv(EnvMaxRetries, "5")
+	t.Setenv(EnvRetryBaseDelay, "2s")
+	cfg, err = Parse([]string{"--retry-base-delay", "500ms", "--retry-max-delay", "1m"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.MaxRetries != 5 {
+		t.Errorf("MaxRetries = %d, want 5 from env", cfg.MaxRetries)
+	}
+	if cfg.RetryBaseDelay != 500*time.Millisecond {
+		t.Errorf("RetryBaseDelay = %v, want 500ms from flag", cfg.RetryBaseDelay)
+	}
+	if cfg.RetryMaxDelay != time.Minute {
+		t.Errorf("RetryMaxDelay = %v, want 1m", cfg.RetryMaxDelay)
+	}
+
+	invalid := [][]string{
+		{"--max-retries", "-1"},
+		{"--retry-base-delay", "0s"},
+		{"--retry-base-delay", "10s", "--retry-max-delay", "5s"},
+		{"--max-log-bytes", "-1"},
+		{"--insecure-skip-verify", "--ca-cert", "corp.pem"},
+	}
+	for _, args := range invalid {
+		if _, err := Parse(args); err == nil {
+			t.Errorf("Parse(%v) should fail", args)
+		}
+	}
+
+	t.Setenv(EnvMaxRetries, "lots")
+	if _, err := Parse([]string{}); err == nil {
+		t.Errorf("Parse() with invalid %s should fail", EnvMaxRetries)
+	}
+}
+
+func TestParseTokenFile(t *testing.T) {
+	t.Setenv(EnvTokenFile, "/run/secrets/env-token")
+	cfg, err := Parse([]string{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.TokenFile != "/run/secrets/env-token" {
+		t.Errorf("TokenFile = %q, want the %s path", cfg.TokenFile, EnvTokenFile)
+	}
+
+	cfg, err = Parse([]string{"--token-file", "/run/secrets/gh-token"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.TokenFile != "/run/secrets/gh-token" {
+		t.Errorf("TokenFile = %q, want the flag path", cfg.TokenFile)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	orig := time.Local
+	time.Local = time.FixedZone("EST", -5*60*60)
+	defer func() { time.Local = orig }()
+
+	ts := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+	cfg, err := Parse([]string{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := cfg.FormatTime(ts, "15:04 MST"); got != "09:30 EST" {
+		t.Errorf("FormatTime() = %q, want local time", got)
+	}
+
+	cfg, err = Parse([]string{"--utc"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := cfg.FormatTime(ts.In(time.Local), "15:04 MST"); got != "14:30 UTC" {
+		t.Errorf("FormatTime() with --utc = %q, want UTC", got)
+	}
+	if cfg.TimeZoneLabel() != "UTC" {
+		t.Errorf("TimeZoneLabel() = %q, want UTC", cfg.TimeZoneLabel())
+	}
+}
+
+func TestParseExportFormat(t *testing.T) {
+	cfg, err := Parse([]string{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.ExportFormat != ExportFormatTxt {
+		t.Errorf("ExportFormat = %q, want %q by default", cfg.ExportFormat, ExportFormatTxt)
+	}
+
+	cfg, err = Parse([]string{"--export-format", "json"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.ExportFormat != ExportFormatJSON {
+		t.Errorf("ExportFormat = %q, want %q", cfg.ExportFormat, ExportFormatJSON)
+	}
+
+	if _, err := Parse([]string{"--export-format", "pdf"}); err == nil {
+		t.Error("Parse() with --export-format pdf should fail")
+	}
+}
+
+func TestParseBranchPattern(t *testing.T) {
+	cfg, err := Parse([]string{"--branch-pattern", "release/*"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.BranchPattern != "release/*" || cfg.BranchLabel() != "release/*" {
+		t.Errorf("BranchPattern = %q, label = %q", cfg.BranchPattern, cfg.BranchLabel())
+	}
+
+	for _, args := range [][]string{
+		{"--branch-pattern", "release/[1-"},
+		{"--branch-pattern", "release/*", "--branch", "main"},
+		{"--branch-pattern", "release/*", "--branch", "all"},
+		{"--branch-pattern", "release/*", "--plain"},
+		{"--branch-pattern", "release/*", "--run", "3"},
+	} {
+		if _, err := Parse(args); err == nil {
+			t.Errorf("Parse(%q) should fail", args)
+		}
+	}
+}
+
+func TestParseRunSelectionFlags(t *testing.T) {
+	cfg, err := Parse([]string{"--run", "457"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.RunNumber != 457 || !cfg.HasRunSelection() {
+		t.Errorf("RunNumber = %d, HasRunSelection = %v, want 457/true", cfg.RunNumber, cfg.HasRunSelection())
+	}
+
+	invalid := [][]string{
+		{"--run", "-1"},
+		{"--run", "1", "--run-id", "2"},
+		{"--run-id", "2", "--limit", "5"},
+	}
+	for _, args := range invalid {
+		if _, err := Parse(args); err == nil {
+			t.Errorf("Parse(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseFailFastFlag(t *testing.T) {
+	cfg, err := Parse([]string{"-w", "--fail-fast"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cfg.FailFast {
+		t.Error("FailFast = false, want true")
+	}
+	if _, err := Parse([]string{"--fail-fast"}); err == nil {
+		t.Error("Parse(--fail-fast) without --watch should fail")
+	}
+}
+
+func TestParseWatchNewFlag(t *testing.T) {
+	cfg, err := Parse([]string{"--watch-new", "--fail-fast"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cfg.WatchNew || !cfg.Watch {
+		t.Errorf("WatchNew = %v, Watch = %v; want both set", cfg.WatchNew, cfg.Watch)
+	}
+	if _, err := Parse([]string{"--watch-new", "--run", "12"}); err == nil {
+		t.Error("Parse(--watch-new --run) should fail")
+	}
+}
+
+func TestParseNoJobsFlag(t *testing.T) {
+	cfg, err := Parse([]string{"--json", "--no-jobs"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cfg.NoJobs {
+		t.Error("NoJobs = false, want true")
+	}
+	for _, args := range [][]string{
+		{"--no-jobs", "--limit", "5", "--with-jobs"},
+		{"--no-jobs", "-w", "--fail-fast"},
+	} {
+		if _, err := Parse(args); err == nil {
+			t.Errorf("Parse(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseOpenFlag(t *testing.T) {
+	cfg, err := Parse([]string{"--open", "--run", "123"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cfg.Open || cfg.RunNumber != 123 {
+		t.Errorf("Open = %v, RunNumber = %d, want true/123", cfg.Open, cfg.RunNumber)
+	}
+	for _, conflict := range []string{"--watch", "--wait", "--plain", "--json"} {
+		if _, err := Parse([]string{"--open", conflict}); err == nil {
+			t.Errorf("Parse(--open %s) should fail", conflict)
+		}
+	}
+}
+
+func TestParseTailFlag(t *testing.T) {
+	cfg, err := Parse([]string{"--tail", "200"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.Tail != 200 {
+		t.Errorf("Tail = %d, want 200", cfg.Tail)
+	}
+	if _, err := Parse([]string{"--tail", "-1"}); err == nil {
+		t.Error("Parse(--tail -1) should fail")
+	}
+}
+
+func TestParseBranchAll(t *testing.T) {
+	cfg, err := Parse([]string{"--repo", "o/r", "--branch", "all"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !cfg.AllBranches || cfg.Branch != "" {
+		t.Errorf("--branch all: AllBranches = %v, Branch = %q, want true and empty", cfg.AllBranches, cfg.Branch)
+	}
+	if got := cfg.BranchLabel(); got != "all branches" {
+		t.Errorf("BranchLabel() = %q, want %q", got, "all branches")
+	}
+
+	// Resolve must not replace the empty branch with the checked-out one
+	if err := cfg.Resolve(); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.Branch != "" {
+		t.Errorf("Resolve() set Branch = %q in all-branches mode", cfg.Branch)
+	}
+
+	// Subcommands only pass through Resolve
+	sub := &Config{Owner: "o", Repo: "r", Branch: AllBranches}
+	if err := sub.Resolve(); err != nil || !sub.AllBranches || sub.Branch != "" {
+		t.Errorf("Resolve(--branch all): err = %v, AllBranches = %v, Branch = %q", err, sub.AllBranches, sub.Branch)
+	}
+
+	if got := (&Config{Branch: "main"}).BranchLabel(); got != "main" {
+		t.Errorf("BranchLabel() = %q, want main", got)
+	}
+}
+
+func TestParsePollFloor(t *testing.T) {
+	if _, err := Parse([]string{"--repo", "o/r", "--poll", "500ms"}); err == nil || !strings.Contains(err.Error(), "at least 2s") {
+		t.Errorf("--poll 500ms: err = %v, want minimum error", err)
+	}
+	cfg, err := Parse([]string{"--repo", "o/r", "--poll", "2s"})
+	if err != nil || cfg.Poll != 2*time.Second {
+		t.Errorf("--poll 2s: err = %v, poll = %s", err, cfg.Poll)
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	cfg, err := Parse([]string{"--repo", "o/r", "--event", "pull_request"})
+	if err != nil || cfg.Event != "pull_request" {
+		t.Errorf("--event pull_request: err = %v, event = %q", err, cfg.Event)
+	}
+	if _, err := Parse([]string{"--repo", "o/r", "--event", "Push"}); err == nil || !strings.Contains(err.Error(), "invalid --event") {
+		t.Errorf("--event Push: err = %v, want invalid event error", err)
+	}
+}
+
+func TestPollWarning(t *testing.T) {
+	repos := func(n int) []RepoSpec {
+		specs := make([]RepoSpec, n)
+		for i := range specs {
+			specs[i] = RepoSpec{Owner: "o", Repo: fmt.Sprintf("r%d", i)}
+		}
+		return specs
+	}
+
+	tests := []struct {
+		name      string
+		cfg       Config
+		wantCalls int
+		wantWarn  bool
+	}{
+		{"default single repo", Config{Poll: DefaultPollInterval}, 24, false},
+		{"fastest single repo", Config{Poll: MinPollInterval}, 60, false},
+		{"four repos", Config{Poll: DefaultPollInterval, Repositories: repos(4)}, 48, false},
+		{"ten repos", Config{Poll: DefaultPollInterval, Repositories: repos(10)}, 120, true},
+		{"many repos slow poll", Config{Poll: 30 * time.Second, Repositories: repos(10)}, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.PollCallsPerMinute(); got != tt.wantCalls {
+				t.Errorf("PollCallsPerMinute() = %d, want %d", got, tt.wantCalls)
+			}
+			warning := tt.cfg.PollWarning()
+			if (warning != "") != tt.wantWarn {
+				t.Errorf("PollWarning() = %q, want warning = %v", warning, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestResolveOwner(t *testing.T) {
+	gitRepo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(gitRepo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitConfig := "[remote \"origin\"]\n\turl = git@github.com:acme/api.git\n"
+	if err := os.WriteFile(filepath.Join(gitRepo, ".git", "config"), []byte(gitConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	noRepo := t.TempDir()
+
+	tests := []struct {
+		name         string
+		dir          string
+		defaultOwner string
+		wantOwner    string
+		wantErr      bool
+	}{
+		{"owner from git remote", gitRepo, "", "acme", false},
+		{"git remote wins over default_owner", gitRepo, "other", "acme", false},
+		{"default_owner outside git", noRepo, "other", "other", false},
+		{"no owner anywhere", noRepo, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Repo: "web", DefaultOwner: tt.defaultOwner}
+			err := cfg.resolveOwner(tt.dir)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNoRepo) || !strings.Contains(err.Error(), "default_owner") {
+					t.Errorf("resolveOwner() error = %v, want ErrNoRepo mentioning default_owner", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveOwner() error = %v", err)
+			}
+			if cfg.Owner != tt.wantOwner || cfg.Repo != "web" {
+				t.Errorf("resolved %s/%s, want %s/web", cfg.Owner, cfg.Repo, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestGitHubHost(t *testing.T) {
+	cfg := &Config{}
+	t.Setenv(EnvHost, "")
+	if got := cfg.GitHubHost(); got != "github.com" {
+		t.Errorf("GitHubHost() = %q, want github.com", got)
+	}
+	t.Setenv(EnvHost, "github.mycorp.com")
+	if got := cfg.GitHubHost(); got != "github.mycorp.com" {
+		t.Errorf("GitHubHost() with %s = %q", EnvHost, got)
+	}
+}
+
+func TestRepoInfoProvider(t *testing.T) {
+	t.Setenv(EnvHost, "")
+	t.Setenv(gitlab.EnvHost, "")
+	newRepo := func(remote string) string {
+		dir := t.TempDir()
+		if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		gitConfig := fmt.Sprintf("[remote \"origin\"]\n\turl = %s\n", remote)
+		if err := os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(gitConfig), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	githubRepo := newRepo("git@github.com:acme/api.git")
+	gitlabRepo := newRepo("https://gitlab.com/acme/web.git")
+
+	tests := []struct {
+		name         string
+		dir          string
+		provider     string
+		wantRepo     string
+		wantProvider string
+		wantErr      bool
+	}{
+		{"github remote", githubRepo, "", "api", "", false},
+		{"gitlab remote detected", gitlabRepo, "", "web", ProviderGitLab, false},
+		{"explicit gitlab", gitlabRepo, ProviderGitLab, "web", ProviderGitLab, false},
+		{"explicit github on a gitlab remote", gitlabRepo, ProviderGitHub, "", ProviderGitHub, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Provider: tt.provider}
+			info, err := cfg.repoInfo(tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("repoInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if info.Repo != tt.wantRepo || cfg.Provider != tt.wantProvider {
+				t.Errorf("repoInfo() = %+v, provider %q; want repo %q, provider %q", info, cfg.Provider, tt.wantRepo, tt.wantProvider)
+			}
+		})
+	}
+
+	if _, err := Parse([]string{"--repo", "o/r", "--provider", "jenkins"}); err == nil || !strings.Contains(err.Error(), "invalid --provider") {
+		t.Errorf("--provider jenkins: err = %v, want invalid provider error", err)
+	}
+}
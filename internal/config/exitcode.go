@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExitOnPending is an --exit-on value matching runs that haven't completed
+const ExitOnPending = "pending"
+
+// ExitTimeout is the exit code when --timeout runs out before the run
+// completes, the same code timeout(1) uses
+const ExitTimeout = 124
+
+// exitOnValues are the values accepted by --exit-on: the run
+// conclusions, and pending
+var exitOnValues = []string{
+	"success",
+	"failure",
+	"cancelled",
+	"skipped",
+	"timed_out",
+	"action_required",
+	"neutral",
+	ExitOnPending,
+}
+
+// ParseExitOn parses the --exit-on flag: a comma-separated list of
+// conclusions (or "pending") that should produce exit code 1
+func ParseExitOn(flag string) ([]string, error) {
+	var result []string
+	for _, v := range strings.Split(flag, ",") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if !isExitOnValue(v) {
+			return nil, fmt.Errorf("invalid --exit-on value %q: expected one of %s", v, strings.Join(exitOnValues, ", "))
+		}
+		result = append(result, v)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("--exit-on requires at least one conclusion")
+	}
+	return result, nil
+}
+
+// ValidateExpect checks --expect, which only applies to --wait, and that
+// --timeout isn't negative (ValidateWatch checks what it applies to).
+// exitOn says whether --exit-on was also given, which --expect replaces.
+func (c *Config) ValidateExpect(exitOn bool) error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative (got %s)", c.Timeout)
+	}
+	if c.Expect == "" {
+		return nil
+	}
+	c.Expect = strings.ToLower(strings.TrimSpace(c.Expect))
+	if c.Expect == ExitOnPending || !isExitOnValue(c.Expect) {
+		conclusions := exitOnValues[:len(exitOnValues)-1] // all but pending
+		return fmt.Errorf("invalid --expect value %q: expected one of %s", c.Expect, strings.Join(conclusions, ", "))
+	}
+	if !c.Wait {
+		return fmt.Errorf("--expect requires --wait")
+	}
+	if exitOn {
+		return fmt.Errorf("--expect cannot be combined with --exit-on")
+	}
+	if c.Json || c.Template != "" || c.Limit > 1 {
+		return fmt.Errorf("--expect cannot be combined with --json, --template or --limit")
+	}
+	return nil
+}
+
+func isExitOnValue(v string) bool {
+	for _, valid := range exitOnValues {
+		if v == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ExitCode returns the process exit code for a run with the given
+// conclusion, "" while it hasn't completed: 1 if it matches --exit-on (or,
+// by default, if the run failed), else 0
+func (c *Config) ExitCode(conclusion string, failed bool) int {
+	// Default semantics: failure, cancelled, timed_out, action_required
+	if len(c.ExitOn) == 0 {
+		if failed {
+			return 1
+		}
+		return 0
+	}
+
+	outcome := conclusion
+	if outcome == "" {
+		outcome = ExitOnPending
+	}
+	for _, v := range c.ExitOn {
+		if v == outcome {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExitOn(t *testing.T) {
+	tests := []struct {
+		flag    string
+		want    []string
+		wantErr bool
+	}{
+		{"failure,cancelled", []string{"failure", "cancelled"}, false},
+		{" Failure , timed_out ", []string{"failure", "timed_out"}, false},
+		{"pending", []string{"pending"}, false},
+		{"failure,bogus", nil, true},
+		{",", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			got, err := ParseExitOn(tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExitOn(%q) error = %v, wantErr %v", tt.flag, err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseExitOn(%q) = %v, want %v", tt.flag, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseExitOn(%q)[%d] = %q, want %q", tt.flag, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseExpect(t *testing.T) {
+	cfg, err := Parse([]string{"--wait", "--expect", "Success", "--timeout", "30m"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.Expect != "success" || cfg.Timeout != 30*time.Minute {
+		t.Errorf("Expect = %q, Timeout = %s", cfg.Expect, cfg.Timeout)
+	}
+
+	if cfg, err := Parse([]string{"--watch", "--timeout", "1h"}); err != nil || cfg.Timeout != time.Hour {
+		t.Errorf("Parse(--watch --timeout 1h) = %v, %v", cfg, err)
+	}
+
+	for _, args := range [][]string{
+		{"--expect", "success"},
+		{"--timeout", "5m"},
+		{"--wait", "--timeout", "-1m"},
+		{"--wait", "--expect", "pending"},
+		{"--wait", "--expect", "green"},
+		{"--wait", "--expect", "success", "--exit-on", "failure"},
+		{"--wait", "--expect", "success", "--json"},
+	} {
+		if _, err := Parse(args); err == nil {
+			t.Errorf("Parse(%q) should fail", args)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		exitOn  []string
+		outcome string // Conclusion, "" while running
+		failed  bool
+		want    int
+	}{
+		{"default success", nil, "success", false, 0},
+		{"default failure", nil, "failure", true, 1},
+		{"default cancelled", nil, "cancelled", true, 1},
+		{"default in progress", nil, "", false, 0},
+		{"custom cancelled", []string{"cancelled"}, "cancelled", true, 1},
+		{"custom ignores failure", []string{"cancelled"}, "failure", true, 0},
+		{"custom skipped ok", []string{"failure"}, "skipped", false, 0},
+		{"custom pending", []string{"pending"}, "", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ExitOn: tt.exitOn}
+			if got := cfg.ExitCode(tt.outcome, tt.failed); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,14 +4,153 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // FileConfig represents the cimon.yml configuration file structure
 type FileConfig struct {
-	Repositories []string `yaml:"repositories"` // owner/repo format
+	Repositories []RepoEntry `yaml:"repositories"` // owner/repo, or a mapping with per-repo options
+
+	// Owner for a bare --repo name when not inside a git repo
+	DefaultOwner string `yaml:"default_owner"`
+
+	// Notification templates, overridden by --notify-title-template/--notify-body-template
+	NotifyTitleTemplate string `yaml:"notify_title_template"`
+	NotifyBodyTemplate  string `yaml:"notify_body_template"`
+
+	// Named sets of settings, selected with --profile
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	// Flag values by flag name, e.g. poll: 10s, used unless given as flags
+	// or set by the profile
+	Defaults map[string]string `yaml:"defaults"`
+}
+
+// RepoEntry is a repository in cimon.yml: either a plain "owner/repo", or
+// a mapping that also gives the repo its own branch and status filter
+type RepoEntry struct {
+	Repo   string `yaml:"repo"`
+	Branch string `yaml:"branch"` // "" or "all" for every branch
+	Status string `yaml:"status"` // One of RunStatusFilters; "" for every run
+}
+
+// UnmarshalYAML accepts the plain "owner/repo" form as well as the mapping
+func (e *RepoEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Repo = value.Value
+		return nil
+	}
+	type plain RepoEntry // Without the method, to decode the mapping normally
+	return value.Decode((*plain)(e))
+}
+
+// Profile is a named set of settings in cimon.yml. Flags given on the
+// command line take precedence over it.
+type Profile struct {
+	Repositories []RepoEntry   `yaml:"repos"` // Same forms as the top-level repositories
+	Branch       string        `yaml:"branch"`
+	Event        string        `yaml:"event"`
+	Poll         time.Duration `yaml:"poll"`
+	Watch        *bool         `yaml:"watch"`  // nil when the profile doesn't set it
+	Notify       *bool         `yaml:"notify"` // nil when the profile doesn't set it
+	Hook         string        `yaml:"hook"`
+}
+
+// ApplyProfile applies the profile named by --profile to settings not given
+// as flags. A profile's repos replace the top-level repositories.
+func (f *FileConfig) ApplyProfile(cfg *Config) error {
+	if cfg.Profile == "" {
+		return nil
+	}
+	if f == nil {
+		return fmt.Errorf("--profile %s: no usable %s found", cfg.Profile, DefaultConfigPath())
+	}
+	p, ok := f.Profiles[cfg.Profile]
+	if !ok {
+		return fmt.Errorf("--profile %s: no such profile in %s (profiles: %s)", cfg.Profile, DefaultConfigPath(), f.profileNames())
+	}
+
+	if len(p.Repositories) > 0 && !cfg.changed("repos") && !cfg.changed("repos-file") && !cfg.changed("repo") {
+		specs, err := repoSpecs(p.Repositories)
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", cfg.Profile, err)
+		}
+		cfg.Repositories = specs
+	}
+	if p.Branch != "" && !cfg.changed("branch") {
+		cfg.Branch = p.Branch
+		cfg.applyAllBranches()
+	}
+	if p.Event != "" && !cfg.changed("event") {
+		cfg.Event = p.Event
+	}
+	if p.Poll != 0 && !cfg.changed("poll") {
+		cfg.Poll = p.Poll
+	}
+	if p.Hook != "" && !cfg.changed("hook") {
+		cfg.Hook = p.Hook
+	}
+	if p.Watch != nil && !cfg.changed("watch") && !cfg.WatchNew {
+		cfg.Watch = *p.Watch
+	}
+	if p.Notify != nil && !cfg.changed("notify") {
+		cfg.Notify = *p.Notify
+	}
+
+	// The profile's settings get the same checks as flags
+	for _, validate := range []func() error{cfg.ValidatePoll, cfg.ValidateEvent, cfg.ValidateBranchPattern, cfg.ValidateWatch} {
+		if err := validate(); err != nil {
+			return fmt.Errorf("profile %s: %w", cfg.Profile, err)
+		}
+	}
+	return nil
+}
+
+// profileNames lists the profile names, sorted, for error messages
+func (f *FileConfig) profileNames() string {
+	if len(f.Profiles) == 0 {
+		return "none defined"
+	}
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// FlagDefaults returns the defaults section for ParseWithDefaults
+func (f *FileConfig) FlagDefaults() map[string]string {
+	if f == nil {
+		return nil
+	}
+	return f.Defaults
+}
+
+// ApplyNotifyTemplates fills in notification templates not set by flags
+func (f *FileConfig) ApplyNotifyTemplates(cfg *Config) {
+	if f == nil {
+		return
+	}
+	if cfg.NotifyTitleTemplate == "" {
+		cfg.NotifyTitleTemplate = f.NotifyTitleTemplate
+	}
+	if cfg.NotifyBodyTemplate == "" {
+		cfg.NotifyBodyTemplate = f.NotifyBodyTemplate
+	}
+}
+
+// ApplyDefaultOwner sets the owner used to complete a bare --repo name
+func (f *FileConfig) ApplyDefaultOwner(cfg *Config) {
+	if f == nil {
+		return
+	}
+	cfg.DefaultOwner = f.DefaultOwner
 }
 
 // LoadConfigFile loads configuration from a YAML file.
@@ -35,13 +174,17 @@ func LoadConfigFile(path string) (*FileConfig, error) {
 
 // ToRepoSpecs converts FileConfig repositories to RepoSpec slice
 func (f *FileConfig) ToRepoSpecs() ([]RepoSpec, error) {
-	if f == nil || len(f.Repositories) == 0 {
+	if f == nil {
 		return nil, nil
 	}
+	return repoSpecs(f.Repositories)
+}
 
+// repoSpecs parses repository entries, skipping blank ones
+func repoSpecs(repos []RepoEntry) ([]RepoSpec, error) {
 	var specs []RepoSpec
-	for _, r := range f.Repositories {
-		r = strings.TrimSpace(r)
+	for _, entry := range repos {
+		r := strings.TrimSpace(entry.Repo)
 		if r == "" {
 			continue
 		}
@@ -49,7 +192,15 @@ func (f *FileConfig) ToRepoSpecs() ([]RepoSpec, error) {
 		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
 			return nil, fmt.Errorf("invalid repo format %q in config file: expected owner/repo", r)
 		}
-		specs = append(specs, RepoSpec{Owner: parts[0], Repo: parts[1]})
+
+		spec := RepoSpec{Owner: parts[0], Repo: parts[1], Branch: entry.Branch, Status: entry.Status}
+		if spec.Branch == AllBranches {
+			spec.Branch = ""
+		}
+		if spec.Status != "" && !slices.Contains(RunStatusFilters, spec.Status) {
+			return nil, fmt.Errorf("invalid status %q for %s in config file: expected one of %s", spec.Status, r, strings.Join(RunStatusFilters, ", "))
+		}
+		specs = append(specs, spec)
 	}
 
 	return specs, nil
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfigFile(t *testing.T) {
@@ -27,6 +29,16 @@ func TestLoadConfigFile(t *testing.T) {
 `,
 			wantRepos: 0,
 		},
+		{
+			name: "per-repo options",
+			content: `repositories:
+  - owner1/repo1
+  - repo: owner2/repo2
+    branch: develop
+    status: failure
+`,
+			wantRepos: 2,
+		},
 		{
 			name:     "invalid yaml",
 			content:  "invalid: [yaml: content",
@@ -79,7 +91,7 @@ func TestFileConfigToRepoSpecs(t *testing.T) {
 		{
 			name: "valid repos",
 			cfg: &FileConfig{
-				Repositories: []string{"owner1/repo1", "owner2/repo2"},
+				Repositories: []RepoEntry{{Repo: "owner1/repo1"}, {Repo: "owner2/repo2"}},
 			},
 			want: []RepoSpec{
 				{Owner: "owner1", Repo: "repo1"},
@@ -93,13 +105,13 @@ func TestFileConfigToRepoSpecs(t *testing.T) {
 		},
 		{
 			name: "empty repos",
-			cfg:  &FileConfig{Repositories: []string{}},
+			cfg:  &FileConfig{Repositories: []RepoEntry{}},
 			want: nil,
 		},
 		{
 			name: "skip empty strings",
 			cfg: &FileConfig{
-				Repositories: []string{"owner1/repo1", "", "owner2/repo2"},
+				Repositories: []RepoEntry{{Repo: "owner1/repo1"}, {}, {Repo: "owner2/repo2"}},
 			},
 			want: []RepoSpec{
 				{Owner: "owner1", Repo: "repo1"},
@@ -109,7 +121,7 @@ func TestFileConfigToRepoSpecs(t *testing.T) {
 		{
 			name: "invalid format",
 			cfg: &FileConfig{
-				Repositories: []string{"invalid"},
+				Repositories: []RepoEntry{{Repo: "invalid"}},
 			},
 			wantErr: true,
 		},
@@ -137,3 +149,318 @@ func TestFileConfigToRepoSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestPerRepoOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cimon.yml")
+	content := `repositories:
+  - org/tools
+  - repo: org/api
+    branch: main
+    status: failure
+  - repo: org/web
+    branch: all
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	specs, err := cfg.ToRepoSpecs()
+	if err != nil {
+		t.Fatalf("ToRepoSpecs() error = %v", err)
+	}
+	want := []RepoSpec{
+		{Owner: "org", Repo: "tools"},
+		{Owner: "org", Repo: "api", Branch: "main", Status: "failure"},
+		{Owner: "org", Repo: "web"},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("ToRepoSpecs() = %+v, want %+v", specs, want)
+	}
+	for i := range want {
+		if specs[i] != want[i] {
+			t.Errorf("specs[%d] = %+v, want %+v", i, specs[i], want[i])
+		}
+	}
+
+	bad := &FileConfig{Repositories: []RepoEntry{{Repo: "org/api", Status: "broken"}}}
+	if _, err := bad.ToRepoSpecs(); err == nil || !strings.Contains(err.Error(), "org/api") {
+		t.Errorf("ToRepoSpecs() with an unknown status: error = %v", err)
+	}
+}
+
+func TestApplyNotifyTemplates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cimon.yml")
+	content := "notify_title_template: \"{{.Icon}} {{.Repo}}\"\nnotify_body_template: \"{{.Branch}}\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+
+	// Flags take precedence over the file
+	cfg := &Config{NotifyBodyTemplate: "from flag"}
+	fileCfg.ApplyNotifyTemplates(cfg)
+	if cfg.NotifyTitleTemplate != "{{.Icon}} {{.Repo}}" {
+		t.Errorf("NotifyTitleTemplate = %q, want value from file", cfg.NotifyTitleTemplate)
+	}
+	if cfg.NotifyBodyTemplate != "from flag" {
+		t.Errorf("NotifyBodyTemplate = %q, want flag value kept", cfg.NotifyBodyTemplate)
+	}
+}
+
+func TestApplyDefaultOwner(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cimon.yml")
+	if err := os.WriteFile(path, []byte("default_owner: acme\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+
+	cfg := &Config{}
+	fileCfg.ApplyDefaultOwner(cfg)
+	if cfg.DefaultOwner != "acme" {
+		t.Errorf("DefaultOwner = %q, want acme", cfg.DefaultOwner)
+	}
+
+	// No config file leaves it unset
+	var none *FileConfig
+	none.ApplyDefaultOwner(cfg)
+	if cfg.DefaultOwner != "acme" {
+		t.Errorf("nil FileConfig changed DefaultOwner to %q", cfg.DefaultOwner)
+	}
+}
+
+func loadProfiles(t *testing.T) *FileConfig {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cimon.yml")
+	content := `profiles:
+  prod:
+    repos:
+      - acme/api
+      - acme/web
+    branch: main
+    poll: 10s
+    notify: true
+  nightly:
+    event: schedule
+    branch: all
+    watch: true
+  once:
+    watch: false
+    notify: false
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	return fileCfg
+}
+
+func TestApplyProfile(t *testing.T) {
+	fileCfg := loadProfiles(t)
+
+	cfg, err := Parse([]string{"--profile", "prod"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if len(cfg.Repositories) != 2 || cfg.Repositories[1].Slug() != "acme/web" {
+		t.Errorf("Repositories = %v, want acme/api and acme/web", cfg.Repositories)
+	}
+	if cfg.Branch != "main" {
+		t.Errorf("Branch = %q, want main", cfg.Branch)
+	}
+	if cfg.Poll != 10*time.Second {
+		t.Errorf("Poll = %v, want 10s", cfg.Poll)
+	}
+	if !cfg.Notify {
+		t.Error("Notify = false, want true from profile")
+	}
+
+	// branch: all in a profile means every branch, as with the flag
+	cfg, err = Parse([]string{"--profile", "nightly"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if !cfg.AllBranches || cfg.Branch != "" {
+		t.Errorf("AllBranches = %v, Branch = %q, want every branch", cfg.AllBranches, cfg.Branch)
+	}
+	if cfg.Event != "schedule" {
+		t.Errorf("Event = %q, want schedule", cfg.Event)
+	}
+
+	// A profile's watch counts for the flags that need it
+	cfg, err = Parse([]string{"--profile", "nightly", "--fail-fast", "--timeout", "1h"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if !cfg.Watch || !cfg.FailFast {
+		t.Errorf("Watch = %v, FailFast = %v, want both", cfg.Watch, cfg.FailFast)
+	}
+}
+
+func TestApplyProfileFlagsWin(t *testing.T) {
+	fileCfg := loadProfiles(t)
+
+	cfg, err := Parse([]string{"--profile", "prod", "--branch", "release", "--poll", "30s", "--repo", "acme/cli"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if cfg.Branch != "release" {
+		t.Errorf("Branch = %q, want release from the flag", cfg.Branch)
+	}
+	if cfg.Poll != 30*time.Second {
+		t.Errorf("Poll = %v, want 30s from the flag", cfg.Poll)
+	}
+	if len(cfg.Repositories) != 0 {
+		t.Errorf("Repositories = %v, want none with --repo given", cfg.Repositories)
+	}
+	if !cfg.Notify {
+		t.Error("Notify = false, want true from profile")
+	}
+
+	// A profile can turn watch and notify off, but not against the flags
+	cfg, err = Parse([]string{"--profile", "once", "--notify"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	cfg.Watch = true // As if from the defaults
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if cfg.Watch || !cfg.Notify {
+		t.Errorf("Watch = %v, Notify = %v, want watch off from the profile and notify from the flag", cfg.Watch, cfg.Notify)
+	}
+	cfg, err = Parse([]string{"--profile", "once", "--watch-new"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if !cfg.Watch {
+		t.Error("Watch = false, want --watch-new to keep watching")
+	}
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	fileCfg := loadProfiles(t)
+
+	cfg, err := Parse([]string{"--profile", "staging"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	err = fileCfg.ApplyProfile(cfg)
+	if err == nil || !strings.Contains(err.Error(), "nightly, once, prod") {
+		t.Errorf("ApplyProfile() error = %v, want unknown profile listing nightly, once, prod", err)
+	}
+
+	// Flags that need --watch are checked once the profile is applied
+	for _, args := range [][]string{
+		{"--profile", "prod", "--fail-fast"},
+		{"--profile", "once", "--timeout", "1h"},
+	} {
+		cfg, err := Parse(args)
+		if err != nil {
+			t.Fatalf("Parse(%v) error = %v", args, err)
+		}
+		if err := fileCfg.ApplyProfile(cfg); err == nil {
+			t.Errorf("ApplyProfile() with %v: want error", args)
+		}
+	}
+
+	var none *FileConfig
+	if err := none.ApplyProfile(cfg); err == nil {
+		t.Error("ApplyProfile() with no config file: want error")
+	}
+
+	// No --profile is a no-op
+	if err := fileCfg.ApplyProfile(&Config{}); err != nil {
+		t.Errorf("ApplyProfile() without --profile error = %v", err)
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cimon.yml")
+	content := `defaults:
+  poll: 20s
+  notify: true
+  event: push
+  symbols: true
+profiles:
+  prod:
+    poll: 10s
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+
+	// Defaults beat the built-in values
+	cfg, err := ParseWithDefaults([]string{}, fileCfg.FlagDefaults())
+	if err != nil {
+		t.Fatalf("ParseWithDefaults() error = %v", err)
+	}
+	if cfg.Poll != 20*time.Second || !cfg.Notify || cfg.Event != "push" || !cfg.Symbols {
+		t.Errorf("defaults not applied: poll = %v, notify = %v, event = %q, symbols = %v", cfg.Poll, cfg.Notify, cfg.Event, cfg.Symbols)
+	}
+
+	// A profile beats the defaults, and flags beat both
+	cfg, err = ParseWithDefaults([]string{"--profile", "prod", "--event", "schedule"}, fileCfg.FlagDefaults())
+	if err != nil {
+		t.Fatalf("ParseWithDefaults() error = %v", err)
+	}
+	if err := fileCfg.ApplyProfile(cfg); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+	if cfg.Poll != 10*time.Second {
+		t.Errorf("Poll = %v, want 10s from the profile", cfg.Poll)
+	}
+	if cfg.Event != "schedule" {
+		t.Errorf("Event = %q, want schedule from the flag", cfg.Event)
+	}
+	if !cfg.Notify {
+		t.Error("Notify = false, want true from the defaults")
+	}
+
+	// Defaults are checked like flags
+	for _, defaults := range []map[string]string{
+		{"pol": "10s"},
+		{"poll": "soon"},
+		{"poll": "1s"},
+	} {
+		if _, err := ParseWithDefaults([]string{}, defaults); err == nil {
+			t.Errorf("ParseWithDefaults(%v) should fail", defaults)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State is the TUI state remembered between sessions, keyed by "owner/repo"
+type State struct {
+	Repos map[string]RepoState `json:"repos"`
+}
+
+// RepoState is the last-used branch and run status filter for one repo
+type RepoState struct {
+	Branch       string `json:"branch,omitempty"`
+	StatusFilter string `json:"status_filter,omitempty"`
+}
+
+// DefaultStatePath returns the state file location,
+// e.g. ~/.cache/cimon/state.json on Linux
+func DefaultStatePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "cimon", "state.json"), nil
+}
+
+// LoadState reads the state file. A missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	state := &State{Repos: make(map[string]RepoState)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	if state.Repos == nil {
+		state.Repos = make(map[string]RepoState)
+	}
+	return state, nil
+}
+
+// Save writes the state file via a temp file and rename, so a crash never
+// leaves a partial file behind
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+// SaveRepoState records one repo's state in the state file at path
+func SaveRepoState(path, slug string, repoState RepoState) error {
+	state, err := LoadState(path)
+	if err != nil {
+		// Start over rather than failing forever on a corrupt file
+		state = &State{Repos: make(map[string]RepoState)}
+	}
+	state.Repos[slug] = repoState
+	return state.Save(path)
+}
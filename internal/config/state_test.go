@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadStateMissingFile(t *testing.T) {
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if state.Repos == nil || len(state.Repos) != 0 {
+		t.Errorf("LoadState() of missing file = %+v, want empty state", state)
+	}
+}
+
+func TestSaveRepoState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cimon", "state.json")
+
+	if err := SaveRepoState(path, "org/api", RepoState{Branch: "develop", StatusFilter: "failure"}); err != nil {
+		t.Fatalf("SaveRepoState() error = %v", err)
+	}
+	if err := SaveRepoState(path, "org/web", RepoState{Branch: "main"}); err != nil {
+		t.Fatalf("SaveRepoState() error = %v", err)
+	}
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if got := state.Repos["org/api"]; got != (RepoState{Branch: "develop", StatusFilter: "failure"}) {
+		t.Errorf("org/api state = %+v", got)
+	}
+	if got := state.Repos["org/web"]; got != (RepoState{Branch: "main"}) {
+		t.Errorf("org/web state = %+v", got)
+	}
+}
+
+func TestLoadStateInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(path); err == nil {
+		t.Error("LoadState() of invalid JSON should fail")
+	}
+
+	// Saving replaces a corrupt file instead of failing
+	if err := SaveRepoState(path, "org/api", RepoState{Branch: "main"}); err != nil {
+		t.Fatalf("SaveRepoState() over corrupt file error = %v", err)
+	}
+	if state, err := LoadState(path); err != nil || state.Repos["org/api"].Branch != "main" {
+		t.Errorf("after save: state = %+v, err = %v", state, err)
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// FormatTime formats t with layout in UTC when --utc (or the TUI's toggle)
+// is on and in local time otherwise. API timestamps arrive in UTC, so every
+// displayed time goes through here to stay consistent.
+func (c *Config) FormatTime(t time.Time, layout string) string {
+	if c.UTC {
+		return t.UTC().Format(layout)
+	}
+	return t.Local().Format(layout)
+}
+
+// TimeZoneLabel names the zone FormatTime renders in, e.g. "UTC" or "CEST"
+func (c *Config) TimeZoneLabel() string {
+	if c.UTC {
+		return "UTC"
+	}
+	zone, _ := time.Now().Zone()
+	return zone
+}
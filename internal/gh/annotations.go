@@ -0,0 +1,37 @@
+package gh
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// FetchAnnotations fetches the check-run annotations for every job of a
+// workflow run, in job order. Each Actions job is backed by a check run
+// with the same ID.
+func (c *Client) FetchAnnotations(owner, repo string, runID int64) ([]Annotation, error) {
+	jobs, err := c.FetchJobs(owner, repo, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []Annotation
+	for _, job := range jobs {
+		path := fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations?per_page=100",
+			url.PathEscape(owner),
+			url.PathEscape(repo),
+			job.ID,
+		)
+
+		var jobAnnotations []Annotation
+		if err := c.Get(path, &jobAnnotations); err != nil {
+			return nil, fmt.Errorf("annotations for job %s: %w", job.Name, err)
+		}
+		for i := range jobAnnotations {
+			jobAnnotations[i].JobID = job.ID
+			jobAnnotations[i].JobName = job.Name
+		}
+		annotations = append(annotations, jobAnnotations...)
+	}
+
+	return annotations, nil
+}
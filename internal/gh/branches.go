@@ -3,6 +3,7 @@ package gh
 import (
 	"fmt"
 	"net/url"
+	"path"
 )
 
 // Branch represents a git branch
@@ -51,3 +52,20 @@ func (c *Client) FetchBranch(owner, repo, branch string) (*Branch, error) {
 
 	return &branchInfo, nil
 }
+
+// MatchBranches returns the names of the branches matching a path.Match
+// glob such as release/*, in the order given. A * doesn't cross a /, so
+// release/* matches release/1.2 but not release/1.2/hotfix.
+func MatchBranches(branches []Branch, pattern string) ([]string, error) {
+	var names []string
+	for _, branch := range branches {
+		ok, err := path.Match(pattern, branch.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+		}
+		if ok {
+			names = append(names, branch.Name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,41 @@
+package gh
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchBranches(t *testing.T) {
+	branches := []Branch{
+		{Name: "main"},
+		{Name: "release/1.2"},
+		{Name: "release/1.3"},
+		{Name: "release/1.3/hotfix"},
+		{Name: "release-notes"},
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"release/*", []string{"release/1.2", "release/1.3"}},
+		{"release/1.[3-9]", []string{"release/1.3"}},
+		{"release*", []string{"release-notes"}},
+		{"main", []string{"main"}},
+		{"feature/*", nil},
+	}
+	for _, tt := range tests {
+		got, err := MatchBranches(branches, tt.pattern)
+		if err != nil {
+			t.Errorf("MatchBranches(%q) error = %v", tt.pattern, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("MatchBranches(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+
+	if _, err := MatchBranches(branches, "release/[1-"); err == nil {
+		t.Error("MatchBranches() with a malformed pattern should fail")
+	}
+}
@@ -2,14 +2,18 @@ package gh
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/auth"
@@ -18,22 +22,97 @@ import (
 // Client wraps the GitHub REST API client
 type Client struct {
 	rest      *api.RESTClient
-	authToken string // Token for raw HTTP requests
+	authToken string      // Token for raw HTTP requests
+	baseURL   string      // API root for all requests; "" = go-gh's host and defaultAPIURL
+	retry     RetryConfig // Retry policy for API requests
+	logCache  *LogCache   // Optional on-disk cache for completed job logs
+
+	maxLogBytes int64 // Cap on log bytes read into memory; 0 = no limit
+
+	apiClient      *http.Client // Raw API requests; returns redirects instead of following them
+	downloadClient *http.Client // Unauthenticated downloads from pre-signed redirect URLs
+}
+
+// ClientOptions configures a Client
+type ClientOptions struct {
+	Retry      RetryConfig // Retry policy for API requests
+	CACertFile string      // Optional PEM bundle of extra trusted CAs
+
+	// Skip TLS certificate verification (testing against self-signed servers only)
+	InsecureSkipVerify bool
+
+	// Transport replaces the transport built from CACertFile/InsecureSkipVerify
+	Transport http.RoundTripper
+	// BaseURL sends every request to this API root instead of GitHub, e.g.
+	// an httptest.Server in tests
+	BaseURL string
+	// AuthToken is used instead of token variables or gh CLI authentication
+	AuthToken string
+	// TokenFile is read for the token when AuthToken is empty, and beats
+	// token variables (e.g. a mounted Kubernetes or Vault secret)
+	TokenFile string
+	// MaxLogBytes caps how much of a job log is downloaded or extracted into
+	// memory; 0 means no limit
+	MaxLogBytes int64
 }
 
-// NewClient creates a new GitHub API client.
-// It tries to use gh CLI authentication first, then falls back to GITHUB_TOKEN.
+// DefaultMaxLogBytes is the default cap on a job log held in memory
+const DefaultMaxLogBytes = 50 << 20
+
+// defaultAPIURL is the API root for raw requests when no BaseURL is set
+const defaultAPIURL = "https://api.github.com"
+
+// NewClient creates a new GitHub API client with the default retry policy.
+// It uses GH_TOKEN/GITHUB_TOKEN (or their enterprise variants) if set,
+// otherwise gh CLI authentication.
 func NewClient() (*Client, error) {
+	return NewClientWithOptions(ClientOptions{Retry: DefaultRetryConfig(), MaxLogBytes: DefaultMaxLogBytes})
+}
+
+// NewClientWithOptions creates a new GitHub API client with the given retry
+// policy and TLS settings.
+func NewClientWithOptions(options ClientOptions) (*Client, error) {
+	transport := options.Transport
+	if transport == nil {
+		t, err := NewTransport(options.CACertFile, options.InsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		transport = t
+	}
+
 	// Try go-gh which uses gh CLI auth
 	opts := api.ClientOptions{
 		EnableCache: false,
+		Transport:   transport,
+	}
+
+	baseURL := strings.TrimSuffix(options.BaseURL, "/")
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid API base URL %q", options.BaseURL)
+		}
+		// go-gh only sends the token to its configured host
+		opts.Host = u.Hostname()
 	}
 
 	// Store token for raw HTTP requests
 	var authToken string
 
-	// Check if GITHUB_TOKEN is set as override
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+	if options.AuthToken == "" && options.TokenFile != "" {
+		token, err := ReadTokenFile(options.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		options.AuthToken = token
+	}
+
+	// An explicit token, then the token variables gh honors as override
+	if options.AuthToken != "" {
+		opts.AuthToken = options.AuthToken
+		authToken = options.AuthToken
+	} else if token := resolveToken(tokenEnv(), opts.Host); token != "" {
 		opts.AuthToken = token
 		authToken = token
 	} else {
@@ -47,7 +126,86 @@ func NewClient() (*Client, error) {
 		return nil, &AuthError{Err: err}
 	}
 
-	return &Client{rest: rest, authToken: authToken}, nil
+	return &Client{
+		rest:           rest,
+		authToken:      authToken,
+		baseURL:        baseURL,
+		retry:          options.Retry,
+		maxLogBytes:    options.MaxLogBytes,
+		apiClient:      newAPIHTTPClient(transport),
+		downloadClient: newDownloadClient(transport),
+	}, nil
+}
+
+// Environment variables gh reads tokens from
+const (
+	envGHToken               = "GH_TOKEN"
+	envGitHubToken           = "GITHUB_TOKEN"
+	envGHEnterpriseToken     = "GH_ENTERPRISE_TOKEN"
+	envGitHubEnterpriseToken = "GITHUB_ENTERPRISE_TOKEN"
+	envGHHost                = "GH_HOST"
+)
+
+// tokenEnv returns the environment variables resolveToken reads
+func tokenEnv() map[string]string {
+	env := map[string]string{}
+	for _, key := range []string{envGHToken, envGitHubToken, envGHEnterpriseToken, envGitHubEnterpriseToken, envGHHost} {
+		env[key] = os.Getenv(key)
+	}
+	return env
+}
+
+// resolveToken picks a token from env in gh's precedence: GH_TOKEN, then
+// GITHUB_TOKEN for github.com; GH_ENTERPRISE_TOKEN, then
+// GITHUB_ENTERPRISE_TOKEN for any other host. Enterprise hosts fall back to
+// the github.com variables, which cimon has always honored everywhere.
+// host is the API host ("" = GH_HOST, or github.com). Returns "" if none is set.
+func resolveToken(env map[string]string, host string) string {
+	return env[resolveTokenVar(env, host)]
+}
+
+// resolveTokenVar returns the name of the variable resolveToken takes the
+// token from, or "" if none is set
+func resolveTokenVar(env map[string]string, host string) string {
+	if host == "" {
+		host = env[envGHHost]
+	}
+	keys := []string{envGHToken, envGitHubToken}
+	if host != "" && host != "github.com" && host != "api.github.com" {
+		keys = append([]string{envGHEnterpriseToken, envGitHubEnterpriseToken}, keys...)
+	}
+	for _, key := range keys {
+		if env[key] != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// ReadTokenFile reads a token from a file, trimming the surrounding
+// whitespace and trailing newline secret mounts usually have
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// TokenSource names where NewClient finds its token: a token variable such
+// as GH_TOKEN, "gh auth" for gh CLI authentication, or "" if there's none
+func TokenSource() string {
+	if key := resolveTokenVar(tokenEnv(), ""); key != "" {
+		return key
+	}
+	if token, _ := getGHCLIToken(); token != "" {
+		return "gh auth"
+	}
+	return ""
 }
 
 // getGHCLIToken tries to get the auth token from gh CLI
@@ -59,20 +217,32 @@ func getGHCLIToken() (string, error) {
 
 // Get performs a GET request to the GitHub API with retry logic
 func (c *Client) Get(path string, response interface{}) error {
-	config := DefaultRetryConfig()
-	return RetryWithBackoff(func() error {
-		err := c.rest.Get(path, response)
+	return c.GetContext(context.Background(), path, response)
+}
+
+// GetContext performs a GET request like Get, abandoning the request and
+// any retries when ctx is done
+func (c *Client) GetContext(ctx context.Context, path string, response interface{}) error {
+	return RetryWithBackoffContext(ctx, func() error {
+		err := c.rest.DoWithContext(ctx, http.MethodGet, c.restPath(path), nil, response)
 		if err != nil {
 			return c.wrapError(err)
 		}
 		return nil
-	}, config)
+	}, c.retry)
 }
 
-// Post performs a POST request to the GitHub API with retry logic
+// Post performs a POST request to the GitHub API with retry logic,
+// discarding the response body
 func (c *Client) Post(path string, payload interface{}) error {
-	config := DefaultRetryConfig()
-	return RetryWithBackoff(func() error {
+	return c.PostWithResponse(path, payload, nil)
+}
+
+// PostWithResponse performs a POST request like Post and decodes the JSON
+// response into out, for endpoints that return what they created. A nil
+// out discards the body.
+func (c *Client) PostWithResponse(path string, payload, out interface{}) error {
+	return c.withRetry(func() error {
 		var body bytes.Buffer
 		if payload != nil {
 			if err := json.NewEncoder(&body).Encode(payload); err != nil {
@@ -80,12 +250,40 @@ func (c *Client) Post(path string, payload interface{}) error {
 			}
 		}
 
-		err := c.rest.Post(path, &body, nil)
+		err := c.rest.Post(c.restPath(path), &body, out)
 		if err != nil {
 			return c.wrapError(err)
 		}
 		return nil
-	}, config)
+	})
+}
+
+// restPath returns what to pass go-gh for an API path: the path itself, so
+// go-gh resolves the host, or a full URL when BaseURL overrides it
+func (c *Client) restPath(path string) string {
+	if c.baseURL == "" {
+		return path
+	}
+	return c.apiURL(path)
+}
+
+// apiURL returns the full URL of an API path for raw requests
+func (c *Client) apiURL(path string) string {
+	base := c.baseURL
+	if base == "" {
+		base = defaultAPIURL
+	}
+	return base + "/" + path
+}
+
+// SetLogCache enables caching of completed job logs; nil disables it
+func (c *Client) SetLogCache(cache *LogCache) {
+	c.logCache = cache
+}
+
+// withRetry runs fn with the client's retry policy
+func (c *Client) withRetry(fn func() error) error {
+	return RetryWithBackoff(fn, c.retry)
 }
 
 // GetRepository fetches repository information from GitHub API
@@ -109,6 +307,17 @@ func (c *Client) wrapError(err error) error {
 		return nil
 	}
 
+	// Prefer the status, headers and message go-gh keeps on the response
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if wrapped := classifyLimitError(httpErr, err); wrapped != nil {
+			return wrapped
+		}
+		if wrapped := classifyPermissionError(httpErr, err); wrapped != nil {
+			return wrapped
+		}
+	}
+
 	errStr := err.Error()
 
 	// Check for HTTP status codes in error message
@@ -143,6 +352,100 @@ func (c *Client) wrapError(err error) error {
 	return err
 }
 
+// classifyLimitError distinguishes SAML enforcement, secondary rate limits
+// and primary rate limits among 403 and 429 responses. It returns nil if the
+// response is none of these.
+func classifyLimitError(httpErr *api.HTTPError, err error) error {
+	if httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	message := strings.ToLower(httpErr.Message)
+
+	// X-GitHub-SSO: required; url=https://github.com/orgs/.../sso?authorization_request=...
+	if sso := httpErr.Headers.Get("X-GitHub-SSO"); sso != "" || strings.Contains(message, "saml enforcement") {
+		return &SAMLError{Err: err, AuthorizeURL: ssoAuthorizeURL(sso)}
+	}
+
+	if strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection") {
+		return &SecondaryRateLimitError{Err: err, RetryAfter: retryAfter(httpErr.Headers)}
+	}
+
+	if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" || strings.Contains(message, "rate limit exceeded") {
+		return &RateLimitError{Err: err, Reset: rateLimitReset(httpErr.Headers)}
+	}
+
+	return nil
+}
+
+// classifyPermissionError recognizes a 403 for a fine-grained token that
+// lacks a permission: GitHub names the permissions the endpoint accepts in
+// X-Accepted-GitHub-Permissions, and says the resource is "not accessible
+// by personal access token" (or "by integration" for app tokens). It
+// returns nil for any other response.
+func classifyPermissionError(httpErr *api.HTTPError, err error) error {
+	if httpErr.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	accepted := httpErr.Headers.Get("X-Accepted-GitHub-Permissions")
+	message := strings.ToLower(httpErr.Message)
+	if accepted == "" && !strings.Contains(message, "resource not accessible by") {
+		return nil
+	}
+	return &PermissionError{Err: err, Required: formatPermissions(accepted)}
+}
+
+// formatPermissions turns an X-Accepted-GitHub-Permissions value such as
+// "actions=read; contents=read,metadata=read" into the names shown in the
+// token settings: "Actions: read or Contents: read and Metadata: read".
+// Semicolons separate alternatives; commas join permissions needed together.
+func formatPermissions(header string) string {
+	var alternatives []string
+	for _, alternative := range strings.Split(header, ";") {
+		var perms []string
+		for _, perm := range strings.Split(alternative, ",") {
+			name, level, ok := strings.Cut(strings.TrimSpace(perm), "=")
+			if !ok || name == "" {
+				continue
+			}
+			name = strings.ReplaceAll(name, "_", " ")
+			perms = append(perms, strings.ToUpper(name[:1])+name[1:]+": "+level)
+		}
+		if len(perms) > 0 {
+			alternatives = append(alternatives, strings.Join(perms, " and "))
+		}
+	}
+	return strings.Join(alternatives, " or ")
+}
+
+// ssoAuthorizeURL extracts the authorization URL from an X-GitHub-SSO header
+func ssoAuthorizeURL(header string) string {
+	for _, part := range strings.Split(header, ";") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(part), "url="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(headers http.Header) time.Duration {
+	seconds, err := strconv.Atoi(headers.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// rateLimitReset parses an X-RateLimit-Reset header given as a Unix timestamp
+func rateLimitReset(headers http.Header) time.Time {
+	epoch, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || epoch <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(epoch, 0)
+}
+
 // CheckHTTPError checks if an error is an HTTP error with the given status code
 func CheckHTTPError(err error, statusCode int) bool {
 	if err == nil {
@@ -197,6 +500,12 @@ func (c *Client) FetchWorkflowArtifacts(owner, repo string, runID int64) ([]Arti
 
 // DownloadArtifact downloads an artifact to the current directory
 func (c *Client) DownloadArtifact(owner, repo string, artifactID int64, filename string) error {
+	return c.DownloadArtifactWithProgress(owner, repo, artifactID, filename, nil)
+}
+
+// DownloadArtifactWithProgress downloads an artifact like DownloadArtifact,
+// reporting bytes received to progress as the download runs
+func (c *Client) DownloadArtifactWithProgress(owner, repo string, artifactID int64, filename string, progress ProgressFunc) error {
 	path := fmt.Sprintf("repos/%s/%s/actions/artifacts/%d/zip",
 		url.PathEscape(owner),
 		url.PathEscape(repo),
@@ -211,24 +520,32 @@ func (c *Client) DownloadArtifact(owner, repo string, artifactID int64, filename
 	tempFileName := tempFile.Name()
 	defer func() { _ = os.Remove(tempFileName) }() // Clean up temp file on error
 
-	// Download the artifact
-	resp, err := c.getRawResponse(path)
-	if err != nil {
-		_ = tempFile.Close()
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
+	// Download the artifact into the temp file, starting over if the
+	// connection drops
+	err = c.withRetry(func() error {
+		resp, err := c.openDownload(path)
+		if err != nil {
+			return fmt.Errorf("failed to download artifact: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		_ = tempFile.Close()
-		return fmt.Errorf("download failed with status: %s", resp.Status)
-	}
+		// Discard anything a failed attempt wrote
+		if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to reset temp file: %w", err)
+		}
+		if err := tempFile.Truncate(0); err != nil {
+			return fmt.Errorf("failed to reset temp file: %w", err)
+		}
 
-	// Copy the response body to temp file
-	_, err = io.Copy(tempFile, resp.Body)
+		// Copy the response body to temp file
+		if _, err := io.Copy(tempFile, withProgress(resp.Body, resp.ContentLength, progress)); err != nil {
+			return &RetryableError{Err: fmt.Errorf("failed to download artifact: %w", err), Retryable: true}
+		}
+		return nil
+	})
 	if err != nil {
 		_ = tempFile.Close()
-		return fmt.Errorf("failed to download artifact: %w", err)
+		return err
 	}
 
 	// Close temp file before renaming
@@ -0,0 +1,571 @@
+package gh
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client that sends every request to srv
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	c, err := NewClientWithOptions(ClientOptions{
+		Retry:     RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		Transport: srv.Client().Transport,
+		BaseURL:   srv.URL,
+		AuthToken: "test-token",
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestClientFetchWorkflowRunsRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/actions/runs" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("branch"); got != "main" {
+			t.Errorf("branch = %q, want main", got)
+		}
+		if got := r.URL.Query().Get("event"); got != "push" {
+			t.Errorf("event = %q, want push", got)
+		}
+		if auth := r.Header.Get("Authorization"); !strings.Contains(auth, "test-token") {
+			t.Errorf("Authorization = %q, want the test token", auth)
+		}
+		// The first attempt hits a transient server error
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeJSON(w, WorkflowRunsResponse{TotalCount: 1, WorkflowRuns: []WorkflowRun{{ID: 42, RunNumber: 7, HeadBranch: "main"}}})
+	}))
+	defer srv.Close()
+
+	runs, err := newTestClient(t, srv).FetchWorkflowRuns("o", "r", "main", "", "push", "", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchWorkflowRuns() error = %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 42 {
+		t.Errorf("runs = %+v, want run 42", runs)
+	}
+	if calls != 2 {
+		t.Errorf("%d requests, want 2 (one retry)", calls)
+	}
+}
+
+func TestClientTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("  file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN", "env-token")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); !strings.HasSuffix(auth, " file-token") {
+			t.Errorf("Authorization = %q, want the token from the file", auth)
+		}
+		writeJSON(w, WorkflowRunsResponse{})
+	}))
+	defer srv.Close()
+
+	c, err := NewClientWithOptions(ClientOptions{
+		Transport: srv.Client().Transport,
+		BaseURL:   srv.URL,
+		TokenFile: path,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if _, err := c.FetchWorkflowRuns("o", "r", "", "", "", "", 1, 1); err != nil {
+		t.Fatalf("FetchWorkflowRuns() error = %v", err)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{empty, filepath.Join(dir, "missing")} {
+		if _, err := NewClientWithOptions(ClientOptions{BaseURL: srv.URL, TokenFile: bad}); err == nil {
+			t.Errorf("NewClientWithOptions(TokenFile: %s) succeeded, want an error", filepath.Base(bad))
+		}
+	}
+}
+
+func TestClientFindRunByNumberPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var runs []WorkflowRun
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			for i := 0; i < 100; i++ {
+				runs = append(runs, WorkflowRun{ID: int64(1000 + i), RunNumber: 500 - i})
+			}
+		case "2":
+			runs = []WorkflowRun{{ID: 7, RunNumber: 123}}
+		default:
+			t.Errorf("requested page %s after a short page", page)
+		}
+		writeJSON(w, WorkflowRunsResponse{WorkflowRuns: runs})
+	}))
+	defer srv.Close()
+
+	run, err := newTestClient(t, srv).FindRunByNumber("o", "r", 123)
+	if err != nil {
+		t.Fatalf("FindRunByNumber() error = %v", err)
+	}
+	if run.ID != 7 {
+		t.Errorf("found run %d, want 7", run.ID)
+	}
+}
+
+func TestClientWrapsErrors(t *testing.T) {
+	reset := time.Now().Add(10 * time.Minute).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/missing/actions/runs/1":
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, map[string]string{"message": "Not Found"})
+		case "/repos/o/limited/actions/runs/1":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+			w.WriteHeader(http.StatusForbidden)
+			writeJSON(w, map[string]string{"message": "API rate limit exceeded"})
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	_, err := c.FetchRun("o", "missing", 1)
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("404: error = %v (%T), want *NotFoundError", err, err)
+	}
+
+	_, err = c.FetchRun("o", "limited", 1)
+	var rateLimit *RateLimitError
+	if !errors.As(err, &rateLimit) {
+		t.Fatalf("403 rate limit: error = %v (%T), want *RateLimitError", err, err)
+	}
+	if rateLimit.Reset.Unix() != reset {
+		t.Errorf("Reset = %v, want %v", rateLimit.Reset.Unix(), reset)
+	}
+}
+
+func TestClientWrapsPermissionErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/o/scoped/actions/runs/1" {
+			w.Header().Set("X-Accepted-GitHub-Permissions", "actions=read")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by personal access token","documentation_url":"https://docs.github.com/rest/actions/workflow-runs#get-a-workflow-run","status":"403"}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	_, err := c.FetchRun("o", "scoped", 1)
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("error = %v (%T), want *PermissionError", err, err)
+	}
+	if permErr.Required != "Actions: read" || !strings.Contains(permErr.Hint(), "grant Actions: read") {
+		t.Errorf("Required = %q, hint = %q", permErr.Required, permErr.Hint())
+	}
+	if strings.Contains(err.Error(), "\n") {
+		t.Errorf("error = %q, want one line with the hint kept apart", err)
+	}
+
+	// Without the header the message alone identifies it
+	_, err = c.FetchRun("o", "other", 1)
+	if !errors.As(err, &permErr) || permErr.Required != "" {
+		t.Errorf("error = %v (%T), want *PermissionError without Required", err, err)
+	}
+}
+
+func TestFormatPermissions(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"actions=read", "Actions: read"},
+		{"actions=write; pull_requests=write", "Actions: write or Pull requests: write"},
+		{"contents=read,metadata=read", "Contents: read and Metadata: read"},
+		{"", ""},
+		{"garbage", ""},
+	}
+	for _, tt := range tests {
+		if got := formatPermissions(tt.header); got != tt.want {
+			t.Errorf("formatPermissions(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestClientFetchJobLogsFollowsRedirect(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, step := range []struct{ name, content string }{
+		{"1_Set up job.txt", "runner ready"},
+		{"2_Run tests.txt", "FAIL TestFoo"},
+	} {
+		f, _ := zw.Create("build/" + step.name)
+		_, _ = f.Write([]byte(step.content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/actions/jobs/9/logs", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-token" {
+			t.Errorf("API Authorization = %q", auth)
+		}
+		http.Redirect(w, r, srv.URL+"/storage/logs.zip?sig=abc", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/logs.zip", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("storage request sent Authorization %q", auth)
+		}
+		_, _ = w.Write(archive.Bytes())
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	logs, err := c.FetchJobLogs("o", "r", 9, true)
+	if err != nil {
+		t.Fatalf("FetchJobLogs() error = %v", err)
+	}
+	if !strings.Contains(logs, "runner ready") || !strings.Contains(logs, "FAIL TestFoo") {
+		t.Errorf("logs = %q, want both steps", logs)
+	}
+
+	parsed, err := c.FetchJobLogsStructured("o", "r", 9, true)
+	if err != nil {
+		t.Fatalf("FetchJobLogsStructured() error = %v", err)
+	}
+	if got := parsed.GetStep(2); got != "FAIL TestFoo" {
+		t.Errorf("step 2 log = %q", got)
+	}
+}
+
+func TestClientDownloadRetries(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	f, _ := zw.Create("build/1_Run tests.txt")
+	_, _ = f.Write([]byte("ok"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var srv *httptest.Server
+	var logHits, artifactHits int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/actions/jobs/9/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/storage/logs.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/logs.zip", func(w http.ResponseWriter, r *http.Request) {
+		logHits++
+		if logHits == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write(archive.Bytes())
+	})
+	mux.HandleFunc("/repos/o/r/actions/artifacts/5/zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/storage/artifact.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/artifact.zip", func(w http.ResponseWriter, r *http.Request) {
+		artifactHits++
+		w.WriteHeader(http.StatusForbidden)
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	logs, err := c.FetchJobLogs("o", "r", 9, false)
+	if err != nil {
+		t.Fatalf("FetchJobLogs() error = %v, want success after a retry", err)
+	}
+	if !strings.Contains(logs, "ok") || logHits != 2 {
+		t.Errorf("logs = %q after %d storage requests, want the log after 2", logs, logHits)
+	}
+
+	err = c.DownloadArtifact("o", "r", 5, filepath.Join(t.TempDir(), "a.zip"))
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("DownloadArtifact() error = %v, want the 403", err)
+	}
+	if artifactHits != 1 {
+		t.Errorf("storage 403 requested %d times, want no retries", artifactHits)
+	}
+}
+
+func TestNewClientWithOptionsInvalidBaseURL(t *testing.T) {
+	if _, err := NewClientWithOptions(ClientOptions{BaseURL: "not a url", AuthToken: "x"}); err == nil {
+		t.Error("NewClientWithOptions() with an invalid BaseURL should fail")
+	}
+}
+
+func TestExplainNoRuns(t *testing.T) {
+	var workflows []Workflow
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/actions/workflows":
+			writeJSON(w, WorkflowsResponse{TotalCount: len(workflows), Workflows: workflows})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	err := ExplainNoRuns(c, "o", "r", "main")
+	if !errors.Is(err, ErrNoWorkflows) || !errors.Is(err, ErrNoRuns) {
+		t.Errorf("no workflows: error = %v, want ErrNoWorkflows", err)
+	}
+
+	workflows = []Workflow{{ID: 1, Name: "CI", Path: ".github/workflows/ci.yml", State: "active"}}
+	err = ExplainNoRuns(c, "o", "r", "main")
+	if errors.Is(err, ErrNoWorkflows) || !errors.Is(err, ErrNoRuns) || err.Error() != "workflows exist in o/r but none have run on main" {
+		t.Errorf("workflows without runs: error = %v", err)
+	}
+
+	// Listing the workflows fails: no runs, without claiming why
+	err = ExplainNoRuns(c, "o", "missing", "main")
+	var noRunsErr *NoRunsError
+	if !errors.Is(err, ErrNoRuns) || errors.As(err, &noRunsErr) || !strings.Contains(err.Error(), "couldn't list workflows") {
+		t.Errorf("list failure: error = %v, want ErrNoRuns with the listing failure", err)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		host string
+		want string
+	}{
+		{"none", map[string]string{}, "", ""},
+		{"GITHUB_TOKEN", map[string]string{"GITHUB_TOKEN": "classic"}, "", "classic"},
+		{"GH_TOKEN wins", map[string]string{"GH_TOKEN": "gh", "GITHUB_TOKEN": "classic"}, "", "gh"},
+		{"enterprise ignored for github.com", map[string]string{"GH_ENTERPRISE_TOKEN": "ghe", "GITHUB_TOKEN": "classic"}, "github.com", "classic"},
+		{"GH_ENTERPRISE_TOKEN for GH_HOST", map[string]string{"GH_HOST": "github.mycorp.com", "GH_ENTERPRISE_TOKEN": "ghe", "GH_TOKEN": "gh"}, "", "ghe"},
+		{"GITHUB_ENTERPRISE_TOKEN for an enterprise host", map[string]string{"GITHUB_ENTERPRISE_TOKEN": "ghe", "GH_TOKEN": "gh"}, "github.mycorp.com", "ghe"},
+		{"enterprise host falls back to GH_TOKEN", map[string]string{"GH_TOKEN": "gh"}, "github.mycorp.com", "gh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveToken(tt.env, tt.host); got != tt.want {
+				t.Errorf("resolveToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTokenVar(t *testing.T) {
+	env := map[string]string{"GH_HOST": "github.mycorp.com", "GITHUB_ENTERPRISE_TOKEN": "ghe", "GH_TOKEN": "gh"}
+	if got := resolveTokenVar(env, ""); got != "GITHUB_ENTERPRISE_TOKEN" {
+		t.Errorf("resolveTokenVar() = %q, want GITHUB_ENTERPRISE_TOKEN", got)
+	}
+	if got := resolveTokenVar(map[string]string{}, ""); got != "" {
+		t.Errorf("resolveTokenVar() with no token = %q, want empty", got)
+	}
+}
+
+func TestClientFetchRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		writeJSON(w, map[string]interface{}{
+			"resources": map[string]interface{}{
+				"core":   map[string]interface{}{"limit": 5000, "remaining": 4321, "reset": 1700000000},
+				"search": map[string]interface{}{"limit": 30, "remaining": 30, "reset": 1700000000},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	limit, err := newTestClient(t, srv).FetchRateLimit()
+	if err != nil {
+		t.Fatalf("FetchRateLimit() error = %v", err)
+	}
+	if limit.Limit != 5000 || limit.Remaining != 4321 || !limit.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("FetchRateLimit() = %+v", limit)
+	}
+}
+
+func TestClientFetchRunTiming(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/actions/runs/42/timing" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		writeJSON(w, RunTiming{Billable: map[string]BillableTiming{"UBUNTU": {TotalMS: 60000, Jobs: 2}}, RunDurationMS: 30000})
+	}))
+	defer srv.Close()
+
+	timing, err := newTestClient(t, srv).FetchRunTiming("o", "r", 42)
+	if err != nil {
+		t.Fatalf("FetchRunTiming() error = %v", err)
+	}
+	if timing.Billable["UBUNTU"].Jobs != 2 || timing.RunDuration() != 30*time.Second {
+		t.Errorf("timing = %+v", timing)
+	}
+}
+
+func TestClientCreateGist(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/gists" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload struct {
+			Public bool                `json:"public"`
+			Files  map[string]gistFile `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		if payload.Public || payload.Files["build.log"].Content != "FAIL\n" {
+			t.Errorf("payload = %+v, want a secret gist of build.log", payload)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Gist{ID: "abc", HTMLURL: "https://gist.github.com/abc"})
+	}))
+	defer srv.Close()
+
+	url, err := newTestClient(t, srv).CreateGist(map[string]string{"build.log": "FAIL\n"}, false)
+	if err != nil {
+		t.Fatalf("CreateGist() error = %v", err)
+	}
+	if url != "https://gist.github.com/abc" {
+		t.Errorf("CreateGist() = %q, want the gist URL", url)
+	}
+}
+
+func TestClientPostWithResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/o/r/things" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload["name"] != "widget" {
+			t.Errorf("payload = %v (%v), want name widget", payload, err)
+		}
+		writeJSON(w, map[string]interface{}{"id": 5, "name": "widget"})
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	var out struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := c.PostWithResponse("repos/o/r/things", map[string]string{"name": "widget"}, &out); err != nil {
+		t.Fatalf("PostWithResponse() error = %v", err)
+	}
+	if out.ID != 5 || out.Name != "widget" {
+		t.Errorf("decoded %+v, want id 5 named widget", out)
+	}
+
+	// Post still ignores the body
+	if err := c.Post("repos/o/r/things", map[string]string{"name": "widget"}); err != nil {
+		t.Errorf("Post() error = %v", err)
+	}
+}
+
+func TestClientRerunJob(t *testing.T) {
+	var posts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		posts = append(posts, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	if err := c.RerunJob("o", "r", 7); err != nil {
+		t.Fatalf("RerunJob() error = %v", err)
+	}
+	if err := c.RerunFailedJobs("o", "r", 42); err != nil {
+		t.Fatalf("RerunFailedJobs() error = %v", err)
+	}
+	want := []string{"/repos/o/r/actions/jobs/7/rerun", "/repos/o/r/actions/runs/42/rerun-failed-jobs"}
+	if strings.Join(posts, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %v, want %v", posts, want)
+	}
+}
+
+func TestClientFetchAttempt(t *testing.T) {
+	failure := ConclusionFailure
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/actions/runs/42/attempts/1":
+			writeJSON(w, WorkflowRun{ID: 42, RunAttempt: 1, Status: StatusCompleted, Conclusion: &failure})
+		case "/repos/o/r/actions/runs/42/attempts/1/jobs":
+			writeJSON(w, JobsResponse{TotalCount: 1, Jobs: []Job{{ID: 7, Name: "test", Conclusion: &failure}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	run, err := c.FetchRunAttempt("o", "r", 42, 1)
+	if err != nil {
+		t.Fatalf("FetchRunAttempt() error = %v", err)
+	}
+	if run.RunAttempt != 1 || !run.IsFailure() {
+		t.Errorf("run = %+v, want failed attempt 1", run)
+	}
+	jobs, err := c.FetchAttemptJobs("o", "r", 42, 1)
+	if err != nil {
+		t.Fatalf("FetchAttemptJobs() error = %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != 7 {
+		t.Errorf("jobs = %+v, want job 7", jobs)
+	}
+}
+
+func TestClientFetchCommitVerification(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/git/commits/abc123" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"sha": "abc123", "verification": {"verified": false, "reason": "bad_email"}}`)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	v, err := c.FetchCommitVerification("o", "r", "abc123")
+	if err != nil {
+		t.Fatalf("FetchCommitVerification() error = %v", err)
+	}
+	if v.Verified || v.Reason != "bad_email" {
+		t.Errorf("verification = %+v, want unverified with bad_email", v)
+	}
+}
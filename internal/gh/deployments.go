@@ -0,0 +1,67 @@
+package gh
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Review states for pending deployments
+const (
+	DeploymentApproved = "approved"
+	DeploymentRejected = "rejected"
+)
+
+// FetchPendingDeployments fetches the environments a run is waiting on for approval
+func (c *Client) FetchPendingDeployments(owner, repo string, runID int64) ([]PendingDeployment, error) {
+	path := pendingDeploymentsPath(owner, repo, runID)
+
+	var deployments []PendingDeployment
+	if err := c.Get(path, &deployments); err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// ApproveDeployment approves the run's pending deployments to the given environments
+func (c *Client) ApproveDeployment(owner, repo string, runID int64, environmentIDs []int64) error {
+	return c.reviewPendingDeployments(owner, repo, runID, environmentIDs, DeploymentApproved, "Approved via cimon")
+}
+
+// RejectDeployment rejects the run's pending deployments to the given environments
+func (c *Client) RejectDeployment(owner, repo string, runID int64, environmentIDs []int64) error {
+	return c.reviewPendingDeployments(owner, repo, runID, environmentIDs, DeploymentRejected, "Rejected via cimon")
+}
+
+func (c *Client) reviewPendingDeployments(owner, repo string, runID int64, environmentIDs []int64, state, comment string) error {
+	return c.Post(pendingDeploymentsPath(owner, repo, runID), reviewPayload(environmentIDs, state, comment))
+}
+
+// reviewPayload builds the body for a pending deployment review
+func reviewPayload(environmentIDs []int64, state, comment string) map[string]interface{} {
+	return map[string]interface{}{
+		"environment_ids": environmentIDs,
+		"state":           state,
+		"comment":         comment,
+	}
+}
+
+func pendingDeploymentsPath(owner, repo string, runID int64) string {
+	return fmt.Sprintf("repos/%s/%s/actions/runs/%d/pending_deployments",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		runID,
+	)
+}
+
+// EnvironmentIDs returns the environment IDs of the deployments the current
+// user is allowed to review
+func EnvironmentIDs(deployments []PendingDeployment) []int64 {
+	var ids []int64
+	for _, d := range deployments {
+		if d.CurrentUserCanApprove {
+			ids = append(ids, d.Environment.ID)
+		}
+	}
+	return ids
+}
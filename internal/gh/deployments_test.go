@@ -0,0 +1,33 @@
+package gh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnvironmentIDs(t *testing.T) {
+	deployments := []PendingDeployment{
+		{Environment: DeploymentEnvironment{ID: 1, Name: "staging"}, CurrentUserCanApprove: true},
+		{Environment: DeploymentEnvironment{ID: 2, Name: "production"}},
+		{Environment: DeploymentEnvironment{ID: 3, Name: "qa"}, CurrentUserCanApprove: true},
+	}
+
+	if got := EnvironmentIDs(deployments); !reflect.DeepEqual(got, []int64{1, 3}) {
+		t.Errorf("EnvironmentIDs() = %v, want [1 3]", got)
+	}
+	if got := EnvironmentIDs(nil); got != nil {
+		t.Errorf("EnvironmentIDs(nil) = %v, want nil", got)
+	}
+}
+
+func TestReviewPayload(t *testing.T) {
+	got := reviewPayload([]int64{7}, DeploymentRejected, "no")
+	want := map[string]interface{}{
+		"environment_ids": []int64{7},
+		"state":           "rejected",
+		"comment":         "no",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reviewPayload() = %v, want %v", got, want)
+	}
+}
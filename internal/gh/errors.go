@@ -3,6 +3,7 @@ package gh
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -17,6 +18,17 @@ var (
 
 	// ErrNoRuns is returned when no workflow runs are found
 	ErrNoRuns = errors.New("no workflow runs found for this branch")
+
+	// ErrNoWorkflows is returned when a repository has no workflows at all
+	ErrNoWorkflows = errors.New("no workflows are configured in this repository")
+
+	// ErrLogTooLarge is returned when a job's log archive is over the log
+	// size limit; a cut-off ZIP can't be extracted
+	ErrLogTooLarge = errors.New("log archive is larger than the log size limit (--max-log-bytes)")
+
+	// ErrUnsupported is returned by a Provider for actions its CI system
+	// has no equivalent for
+	ErrUnsupported = errors.New("not supported by this CI provider")
 )
 
 // AuthError wraps authentication-related errors with helpful messages
@@ -25,13 +37,32 @@ type AuthError struct {
 }
 
 func (e *AuthError) Error() string {
-	return fmt.Sprintf("GitHub authentication failed: %v\nInstall gh and run 'gh auth login' or set GITHUB_TOKEN", e.Err)
+	return fmt.Sprintf("GitHub authentication failed: %v\nInstall gh and run 'gh auth login' or set GH_TOKEN", e.Err)
 }
 
 func (e *AuthError) Unwrap() error {
 	return e.Err
 }
 
+// NoRunsError explains an empty run list. It matches ErrNoRuns, and also
+// ErrNoWorkflows when the repository has no workflows at all.
+type NoRunsError struct {
+	Repo        string // owner/name
+	Branch      string // Display label, e.g. "main" or "all branches"
+	NoWorkflows bool
+}
+
+func (e *NoRunsError) Error() string {
+	if e.NoWorkflows {
+		return fmt.Sprintf("no workflows are configured in %s", e.Repo)
+	}
+	return fmt.Sprintf("workflows exist in %s but none have run on %s", e.Repo, e.Branch)
+}
+
+func (e *NoRunsError) Is(target error) bool {
+	return target == ErrNoRuns || (target == ErrNoWorkflows && e.NoWorkflows)
+}
+
 // NotFoundError wraps 404 errors
 type NotFoundError struct {
 	Resource string
@@ -46,15 +77,78 @@ func (e *NotFoundError) Unwrap() error {
 	return e.Err
 }
 
-// RateLimitError wraps rate limit errors
+// RateLimitError wraps primary rate limit errors
 type RateLimitError struct {
-	Err error
+	Err   error
+	Reset time.Time // When the limit resets; zero if GitHub didn't say
 }
 
 func (e *RateLimitError) Error() string {
+	if !e.Reset.IsZero() {
+		return fmt.Sprintf("GitHub API rate limited: %v\nThe limit resets at %s; authenticate with gh auth login for a higher limit", e.Err, e.Reset.Local().Format("15:04:05"))
+	}
 	return fmt.Sprintf("GitHub API rate limited: %v\nWait a moment and try again, or authenticate with gh auth login", e.Err)
 }
 
 func (e *RateLimitError) Unwrap() error {
 	return e.Err
 }
+
+// SecondaryRateLimitError wraps secondary (abuse detection) rate limit errors,
+// which GitHub applies to bursts of requests regardless of the remaining quota
+type SecondaryRateLimitError struct {
+	Err        error
+	RetryAfter time.Duration // From the Retry-After header; zero if absent
+}
+
+func (e *SecondaryRateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("GitHub secondary rate limit hit: %v\nWait %s before retrying and poll less often", e.Err, e.RetryAfter)
+	}
+	return fmt.Sprintf("GitHub secondary rate limit hit: %v\nWait at least a minute before retrying and poll less often", e.Err)
+}
+
+func (e *SecondaryRateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// SAMLError wraps 403s from organizations that enforce SAML single sign-on
+// for tokens that haven't been authorized for the organization
+type SAMLError struct {
+	Err          error
+	AuthorizeURL string // URL to authorize the token, from X-GitHub-SSO; may be empty
+}
+
+func (e *SAMLError) Error() string {
+	if e.AuthorizeURL != "" {
+		return fmt.Sprintf("organization requires SAML SSO authorization: %v\nAuthorize your token at %s or run 'gh auth refresh'", e.Err, e.AuthorizeURL)
+	}
+	return fmt.Sprintf("organization requires SAML SSO authorization: %v\nRun 'gh auth refresh' to authorize your token for the organization", e.Err)
+}
+
+func (e *SAMLError) Unwrap() error {
+	return e.Err
+}
+
+// PermissionError wraps 403s for fine-grained personal access tokens (and
+// GitHub App tokens) that lack a permission the endpoint needs
+type PermissionError struct {
+	Err      error
+	Required string // Permissions the endpoint accepts, e.g. "Actions: read", from X-Accepted-GitHub-Permissions; may be empty
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("token is missing a required permission: %v", e.Err)
+}
+
+// Hint says how to give the token what it's missing
+func (e *PermissionError) Hint() string {
+	if e.Required != "" {
+		return fmt.Sprintf("Your fine-grained token lacks a permission - edit it in GitHub's token settings and grant %s for this repository", e.Required)
+	}
+	return "Your fine-grained token can't access this - add the repository to it and grant Actions: read (Actions: write to rerun or cancel)"
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
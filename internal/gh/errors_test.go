@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAuthError(t *testing.T) {
@@ -104,3 +105,39 @@ func TestSentinelErrors(t *testing.T) {
 		t.Error("ErrNoRuns has empty message")
 	}
 }
+
+func TestSecondaryRateLimitError(t *testing.T) {
+	innerErr := errors.New("HTTP 403: You have exceeded a secondary rate limit")
+
+	withRetry := &SecondaryRateLimitError{Err: innerErr, RetryAfter: 90 * time.Second}
+	if errStr := withRetry.Error(); !strings.Contains(errStr, "1m30s") {
+		t.Errorf("SecondaryRateLimitError.Error() = %q, want to contain retry delay", errStr)
+	}
+
+	withoutRetry := &SecondaryRateLimitError{Err: innerErr}
+	if errStr := withoutRetry.Error(); !strings.Contains(errStr, "secondary rate limit") {
+		t.Errorf("SecondaryRateLimitError.Error() = %q, want to contain 'secondary rate limit'", errStr)
+	}
+
+	if withRetry.Unwrap() != innerErr {
+		t.Errorf("SecondaryRateLimitError.Unwrap() = %v, want %v", withRetry.Unwrap(), innerErr)
+	}
+}
+
+func TestSAMLError(t *testing.T) {
+	innerErr := errors.New("HTTP 403: Resource protected by organization SAML enforcement")
+
+	withURL := &SAMLError{Err: innerErr, AuthorizeURL: "https://github.com/orgs/acme/sso"}
+	if errStr := withURL.Error(); !strings.Contains(errStr, "https://github.com/orgs/acme/sso") {
+		t.Errorf("SAMLError.Error() = %q, want to contain authorize URL", errStr)
+	}
+
+	withoutURL := &SAMLError{Err: innerErr}
+	if errStr := withoutURL.Error(); !strings.Contains(errStr, "gh auth refresh") {
+		t.Errorf("SAMLError.Error() = %q, want to contain 'gh auth refresh'", errStr)
+	}
+
+	if withURL.Unwrap() != innerErr {
+		t.Errorf("SAMLError.Unwrap() = %v, want %v", withURL.Unwrap(), innerErr)
+	}
+}
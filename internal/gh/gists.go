@@ -0,0 +1,31 @@
+package gh
+
+// Gist is a GitHub gist, as returned when one is created
+type Gist struct {
+	ID      string `json:"id"`
+	HTMLURL string `json:"html_url"`
+}
+
+// gistFile is one file of a new gist
+type gistFile struct {
+	Content string `json:"content"`
+}
+
+// CreateGist creates a gist from files (file name to content) and returns
+// its URL. A secret gist (public false) is unlisted, but anyone with the URL
+// can read it. Creating gists needs the token's gist scope.
+func (c *Client) CreateGist(files map[string]string, public bool) (string, error) {
+	payload := struct {
+		Public bool                `json:"public"`
+		Files  map[string]gistFile `json:"files"`
+	}{Public: public, Files: make(map[string]gistFile, len(files))}
+	for name, content := range files {
+		payload.Files[name] = gistFile{Content: content}
+	}
+
+	var gist Gist
+	if err := c.PostWithResponse("gists", payload, &gist); err != nil {
+		return "", err
+	}
+	return gist.HTMLURL, nil
+}
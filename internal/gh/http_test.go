@@ -4,6 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
 )
 
 func TestWrapError(t *testing.T) {
@@ -121,6 +124,109 @@ func TestWrapError(t *testing.T) {
 	}
 }
 
+func TestWrapErrorForbiddenResponses(t *testing.T) {
+	c := &Client{}
+
+	newHTTPError := func(status int, message string, headers map[string]string) error {
+		h := http.Header{}
+		for k, v := range headers {
+			h.Set(k, v)
+		}
+		return &api.HTTPError{StatusCode: status, Message: message, Headers: h}
+	}
+
+	t.Run("SAML enforcement header", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization.",
+			map[string]string{"X-GitHub-SSO": "required; url=https://github.com/orgs/acme/sso?authorization_request=abc"}))
+
+		var samlErr *SAMLError
+		if !errors.As(err, &samlErr) {
+			t.Fatalf("wrapError() type = %T, want *SAMLError", err)
+		}
+		if samlErr.AuthorizeURL != "https://github.com/orgs/acme/sso?authorization_request=abc" {
+			t.Errorf("AuthorizeURL = %q", samlErr.AuthorizeURL)
+		}
+	})
+
+	t.Run("SAML enforcement message without header", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"Resource protected by organization SAML enforcement.", nil))
+
+		var samlErr *SAMLError
+		if !errors.As(err, &samlErr) {
+			t.Fatalf("wrapError() type = %T, want *SAMLError", err)
+		}
+		if samlErr.AuthorizeURL != "" {
+			t.Errorf("AuthorizeURL = %q, want empty", samlErr.AuthorizeURL)
+		}
+	})
+
+	t.Run("secondary rate limit", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+			map[string]string{"Retry-After": "60"}))
+
+		var secondaryErr *SecondaryRateLimitError
+		if !errors.As(err, &secondaryErr) {
+			t.Fatalf("wrapError() type = %T, want *SecondaryRateLimitError", err)
+		}
+		if secondaryErr.RetryAfter != time.Minute {
+			t.Errorf("RetryAfter = %v, want 1m", secondaryErr.RetryAfter)
+		}
+	})
+
+	t.Run("abuse detection", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"You have triggered an abuse detection mechanism.", nil))
+
+		var secondaryErr *SecondaryRateLimitError
+		if !errors.As(err, &secondaryErr) {
+			t.Fatalf("wrapError() type = %T, want *SecondaryRateLimitError", err)
+		}
+		if secondaryErr.RetryAfter != 0 {
+			t.Errorf("RetryAfter = %v, want 0", secondaryErr.RetryAfter)
+		}
+	})
+
+	t.Run("primary rate limit", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"API rate limit exceeded for user ID 1.",
+			map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000000"}))
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			t.Fatalf("wrapError() type = %T, want *RateLimitError", err)
+		}
+		if !rateLimitErr.Reset.Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("Reset = %v, want %v", rateLimitErr.Reset, time.Unix(1700000000, 0))
+		}
+	})
+
+	t.Run("primary rate limit on 429", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusTooManyRequests, "",
+			map[string]string{"X-RateLimit-Remaining": "0"}))
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			t.Fatalf("wrapError() type = %T, want *RateLimitError", err)
+		}
+		if !rateLimitErr.Reset.IsZero() {
+			t.Errorf("Reset = %v, want zero", rateLimitErr.Reset)
+		}
+	})
+
+	t.Run("plain forbidden", func(t *testing.T) {
+		err := c.wrapError(newHTTPError(http.StatusForbidden,
+			"Must have admin rights to Repository.",
+			map[string]string{"X-RateLimit-Remaining": "4999"}))
+
+		if _, ok := err.(*AuthError); !ok {
+			t.Fatalf("wrapError() type = %T, want *AuthError", err)
+		}
+	})
+}
+
 func TestCheckHTTPError(t *testing.T) {
 	tests := []struct {
 		name       string
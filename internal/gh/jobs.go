@@ -11,7 +11,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // FetchJobs fetches all jobs for a workflow run.
@@ -30,6 +29,24 @@ func (c *Client) FetchJobs(owner, repo string, runID int64) ([]Job, error) {
 	return response.Jobs, nil
 }
 
+// FetchAttemptJobs fetches the jobs of one attempt of a workflow run;
+// FetchJobs returns only the latest attempt's
+func (c *Client) FetchAttemptJobs(owner, repo string, runID int64, attempt int) ([]Job, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/attempts/%d/jobs?per_page=100",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		runID,
+		attempt,
+	)
+
+	var response JobsResponse
+	if err := c.Get(path, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Jobs, nil
+}
+
 // FetchJobDetails fetches detailed information for a specific job including steps.
 func (c *Client) FetchJobDetails(owner, repo string, jobID int64) (*Job, error) {
 	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d",
@@ -48,56 +65,188 @@ func (c *Client) FetchJobDetails(owner, repo string, jobID int64) (*Job, error)
 
 // FetchJobLogs fetches and extracts the logs for a specific job.
 // Returns the combined log text from all log files in the ZIP.
-func (c *Client) FetchJobLogs(owner, repo string, jobID int64) (string, error) {
+// Pass completed=true for finished jobs so their logs can be cached.
+func (c *Client) FetchJobLogs(owner, repo string, jobID int64, completed bool) (string, error) {
+	return c.FetchJobLogsWithProgress(owner, repo, jobID, completed, nil)
+}
+
+// FetchJobLogsWithProgress fetches logs like FetchJobLogs, reporting bytes
+// of the log download to progress. Cached logs report nothing.
+func (c *Client) FetchJobLogsWithProgress(owner, repo string, jobID int64, completed bool, progress ProgressFunc) (string, error) {
+	zipData, err := c.fetchJobLogData(owner, repo, jobID, completed, progress)
+	if err != nil {
+		return "", err
+	}
+
+	// Extract and combine all text files from the ZIP
+	return extractLogsFromZIP(zipData, c.maxLogBytes)
+}
+
+// fetchJobLogData downloads a job's raw log data. Logs of completed jobs are
+// immutable, so they are served from and written to the log cache if set.
+func (c *Client) fetchJobLogData(owner, repo string, jobID int64, completed bool, progress ProgressFunc) ([]byte, error) {
+	useCache := completed && c.logCache != nil
+	if useCache {
+		if data, ok := c.logCache.Get(owner, repo, jobID); ok {
+			return data, nil
+		}
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		jobID,
+	)
+
+	// Download the logs ZIP file, starting over if the connection drops
+	var data []byte
+	err := c.withRetry(func() error {
+		zipResp, err := c.openDownload(path)
+		if err != nil {
+			return fmt.Errorf("failed to download logs ZIP: %w", err)
+		}
+		defer func() { _ = zipResp.Body.Close() }()
+
+		// Read the ZIP content, up to the log size limit
+		var truncated bool
+		data, truncated, err = ReadLimited(withProgress(zipResp.Body, zipResp.ContentLength, progress), c.maxLogBytes)
+		if err != nil {
+			return &RetryableError{Err: fmt.Errorf("failed to read ZIP data: %w", err), Retryable: true}
+		}
+		if truncated {
+			// Not retryable, even if the size limit happens to contain "503"
+			return &RetryableError{Err: fmt.Errorf("%w: over %d bytes", ErrLogTooLarge, c.maxLogBytes)}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if useCache {
+		// Caching is best-effort; a failed write just means a refetch later
+		_ = c.logCache.Put(owner, repo, jobID, data)
+	}
+	return data, nil
+}
+
+// LogChunk is the result of an incremental job log fetch
+type LogChunk struct {
+	Content  string // New log text if Appended, otherwise the full log
+	Size     int64  // Raw bytes of log downloaded so far; 0 if the log can't be fetched incrementally
+	Appended bool   // True if Content continues the previously fetched log
+}
+
+// TailLines returns the last n lines of content, keeping a trailing newline.
+// n <= 0 returns content unchanged. It scans back from the end, so it's
+// cheap even for multi-megabyte logs.
+func TailLines(content string, n int) string {
+	if n <= 0 {
+		return content
+	}
+	end := len(content)
+	if strings.HasSuffix(content, "\n") {
+		end-- // The final newline ends the last line rather than starting a new one
+	}
+	for i := end - 1; i >= 0; i-- {
+		if content[i] == '\n' {
+			n--
+			if n == 0 {
+				return content[i+1:]
+			}
+		}
+	}
+	return content
+}
+
+// FetchJobLogsSince fetches a job's log starting at offset raw bytes, so a
+// running job's log can be streamed without re-downloading it every poll.
+// Pass the previous chunk's Size as offset, or 0 for the full log.
+func (c *Client) FetchJobLogsSince(owner, repo string, jobID, offset int64) (*LogChunk, error) {
 	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs",
 		url.PathEscape(owner),
 		url.PathEscape(repo),
 		jobID,
 	)
 
-	// Get the redirect URL for the logs ZIP file
+	// Get the redirect URL for the log download
 	resp, err := c.getRawResponse(path)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Follow the redirect to get the ZIP file
-	if resp.StatusCode == http.StatusFound {
-		redirectURL := resp.Header.Get("Location")
-		if redirectURL == "" {
-			return "", fmt.Errorf("no redirect URL found for logs")
-		}
+	redirectURL, err := redirectLocation(resp)
+	if err != nil {
+		return nil, err
+	}
 
-		// Download the ZIP file
-		resp, err := http.Get(redirectURL)
-		if err != nil {
-			return "", fmt.Errorf("failed to download logs ZIP: %w", err)
-		}
-		defer func() { _ = resp.Body.Close() }()
+	return downloadLogsSince(c.downloadClient, redirectURL, offset, c.maxLogBytes)
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("failed to download logs ZIP: status %d", resp.StatusCode)
+// downloadLogsSince downloads a log from storage, requesting only the bytes
+// after offset with a Range header. Storage that ignores the Range header
+// returns the full log, which is handled the same as an initial fetch.
+// At most maxBytes are read per call (0 = no limit); a plain-text log over
+// that is read in pieces, since the next call resumes at the returned Size.
+func downloadLogsSince(client *http.Client, logURL string, offset, maxBytes int64) (*LogChunk, error) {
+	req, err := http.NewRequest("GET", logURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download logs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing new since the last fetch
+		return &LogChunk{Size: offset, Appended: true}, nil
+
+	case http.StatusPartialContent:
+		// Only trust the range if it starts where we asked
+		if strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			data, _, err := ReadLimited(resp.Body, maxBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read logs: %w", err)
+			}
+			return &LogChunk{Content: string(data), Size: offset + int64(len(data)), Appended: true}, nil
 		}
+		// Unexpected range - fall back to a full download
+		return downloadLogsSince(client, logURL, 0, maxBytes)
 
-		// Read the ZIP content
-		zipData, err := io.ReadAll(resp.Body)
+	case http.StatusOK:
+		data, truncated, err := ReadLimited(resp.Body, maxBytes)
 		if err != nil {
-			return "", fmt.Errorf("failed to read ZIP data: %w", err)
+			return nil, fmt.Errorf("failed to read logs: %w", err)
 		}
 
-		// Extract and combine all text files from the ZIP
-		return extractLogsFromZIP(zipData)
+		// Log archives can't be resumed at a byte offset, so Size stays 0
+		if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+			if truncated {
+				return nil, fmt.Errorf("%w: over %d bytes", ErrLogTooLarge, maxBytes)
+			}
+			content, err := extractLogsFromZIP(data, maxBytes)
+			if err != nil {
+				return nil, err
+			}
+			return &LogChunk{Content: content}, nil
+		}
+		return &LogChunk{Content: string(data), Size: int64(len(data))}, nil
 	}
 
-	return "", fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	return nil, fmt.Errorf("failed to download logs: status %d", resp.StatusCode)
 }
 
 // getRawResponse performs a GET request and returns the raw HTTP response
 func (c *Client) getRawResponse(path string) (*http.Response, error) {
-	fullURL := fmt.Sprintf("https://api.github.com/%s", path)
-
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequest("GET", c.apiURL(path), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -109,17 +258,87 @@ func (c *Client) getRawResponse(path string) (*http.Response, error) {
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	// Use a client with timeout
-	client := &http.Client{
-		Timeout: 60 * time.Second, // 60 second timeout for large file downloads
+	return c.apiClient.Do(req)
+}
+
+// openDownload requests an API path that redirects to a download and opens
+// the download. Network errors and 429/5xx responses from either host come
+// back as retryable errors. The caller must close the returned body.
+func (c *Client) openDownload(path string) (*http.Response, error) {
+	apiResp, err := c.getRawResponse(path)
+	if err != nil {
+		return nil, &RetryableError{Err: err, Retryable: true}
 	}
+	defer func() { _ = apiResp.Body.Close() }()
 
-	return client.Do(req)
+	return c.followDownloadRedirect(apiResp)
+}
+
+// followDownloadRedirect downloads the file an API response redirects to.
+// The redirect URL is pre-signed, so the download client sends no
+// Authorization header. The caller must close the returned body.
+func (c *Client) followDownloadRedirect(resp *http.Response) (*http.Response, error) {
+	redirectURL, err := redirectLocation(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	dlResp, err := c.downloadClient.Get(redirectURL)
+	if err != nil {
+		return nil, &RetryableError{Err: err, Retryable: true}
+	}
+	if dlResp.StatusCode != http.StatusOK {
+		_ = dlResp.Body.Close()
+		return nil, &RetryableError{
+			Err:       fmt.Errorf("status %d", dlResp.StatusCode),
+			Retryable: isRetryableStatus(dlResp.StatusCode),
+		}
+	}
+	return dlResp, nil
+}
+
+// redirectLocation returns the download URL from a 302 API response
+func redirectLocation(resp *http.Response) (string, error) {
+	if resp.StatusCode != http.StatusFound {
+		return "", &RetryableError{
+			Err:       fmt.Errorf("unexpected response status: %d", resp.StatusCode),
+			Retryable: isRetryableStatus(resp.StatusCode),
+		}
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("no redirect URL found in response")
+	}
+	return location, nil
+}
+
+// ReadLimited reads r to the end, or to limit bytes if limit > 0, and
+// reports whether r had more than limit bytes. Providers use it to hold
+// logs to --max-log-bytes.
+func ReadLimited(r io.Reader, limit int64) ([]byte, bool, error) {
+	if limit <= 0 {
+		data, err := io.ReadAll(r)
+		return data, false, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+	return data, false, nil
+}
+
+// LogTruncatedNotice ends a log cut off at the size limit. The ##[warning]
+// marker makes the log viewer highlight it.
+func LogTruncatedNotice(maxBytes int64) string {
+	return fmt.Sprintf("\n##[warning]cimon: log exceeded the %d byte limit and was truncated (raise it with --max-log-bytes)\n", maxBytes)
 }
 
 // extractLogsFromZIP extracts and combines all text files from a ZIP archive
-func extractLogsFromZIP(zipData []byte) (string, error) {
-	parsed, err := extractLogsFromZIPStructured(zipData)
+func extractLogsFromZIP(zipData []byte, maxBytes int64) (string, error) {
+	parsed, err := extractLogsFromZIPStructured(zipData, maxBytes)
 	if err != nil {
 		return "", err
 	}
@@ -129,7 +348,9 @@ func extractLogsFromZIP(zipData []byte) (string, error) {
 // extractLogsFromZIPStructured extracts logs with step-level structure preserved (v0.6)
 // GitHub Actions log ZIP files have format: "{step_number}_{step_name}.txt"
 // e.g., "1_Set up job.txt", "2_Checkout.txt", "3_Build.txt"
-func extractLogsFromZIPStructured(zipData []byte) (*ParsedLogs, error) {
+// Extracted text is capped at maxBytes in total (0 = no limit), so a small
+// archive can't expand into more memory than the log size limit allows.
+func extractLogsFromZIPStructured(zipData []byte, maxBytes int64) (*ParsedLogs, error) {
 	zipReader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read ZIP: %w", err)
@@ -151,11 +372,17 @@ func extractLogsFromZIPStructured(zipData []byte) (*ParsedLogs, error) {
 		content string
 	}
 	var entries []fileEntry
+	var extracted int64
+	truncated := false
 
 	for _, file := range zipReader.File {
 		if file.FileInfo().IsDir() {
 			continue
 		}
+		if maxBytes > 0 && extracted >= maxBytes {
+			truncated = true
+			break
+		}
 
 		// Open the file in the ZIP
 		rc, err := file.Open()
@@ -163,12 +390,18 @@ func extractLogsFromZIPStructured(zipData []byte) (*ParsedLogs, error) {
 			continue // Skip files we can't open
 		}
 
-		// Read the file content
-		content, err := io.ReadAll(rc)
+		// Read the file content, within what's left of the limit
+		limit := int64(0)
+		if maxBytes > 0 {
+			limit = maxBytes - extracted
+		}
+		content, cut, err := ReadLimited(rc, limit)
 		_ = rc.Close()
 		if err != nil {
 			continue // Skip files we can't read
 		}
+		extracted += int64(len(content))
+		truncated = truncated || cut
 
 		// Parse the filename to extract step number and name
 		filename := file.Name
@@ -196,6 +429,14 @@ func extractLogsFromZIPStructured(zipData []byte) (*ParsedLogs, error) {
 			key:     key,
 			content: string(content),
 		})
+		if cut {
+			break
+		}
+	}
+
+	// Say where the log was cut, at the end of the last step read
+	if truncated && len(entries) > 0 {
+		entries[len(entries)-1].content += LogTruncatedNotice(maxBytes)
 	}
 
 	// Sort by step number
@@ -225,47 +466,12 @@ func extractLogsFromZIPStructured(zipData []byte) (*ParsedLogs, error) {
 }
 
 // FetchJobLogsStructured fetches logs with step-level structure (v0.6)
-func (c *Client) FetchJobLogsStructured(owner, repo string, jobID int64) (*ParsedLogs, error) {
-	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs",
-		url.PathEscape(owner),
-		url.PathEscape(repo),
-		jobID,
-	)
-
-	// Get the redirect URL for the logs ZIP file
-	resp, err := c.getRawResponse(path)
+func (c *Client) FetchJobLogsStructured(owner, repo string, jobID int64, completed bool) (*ParsedLogs, error) {
+	zipData, err := c.fetchJobLogData(owner, repo, jobID, completed, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Follow the redirect to get the ZIP file
-	if resp.StatusCode == http.StatusFound {
-		redirectURL := resp.Header.Get("Location")
-		if redirectURL == "" {
-			return nil, fmt.Errorf("no redirect URL found for logs")
-		}
-
-		// Download the ZIP file
-		resp, err := http.Get(redirectURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to download logs ZIP: %w", err)
-		}
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to download logs ZIP: status %d", resp.StatusCode)
-		}
-
-		// Read the ZIP content
-		zipData, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read ZIP data: %w", err)
-		}
-
-		// Extract with structure preserved
-		return extractLogsFromZIPStructured(zipData)
-	}
 
-	return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	// Extract with structure preserved
+	return extractLogsFromZIPStructured(zipData, c.maxLogBytes)
 }
@@ -0,0 +1,298 @@
+package gh
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// growingLog serves a log that can be appended to between requests and
+// records how many body bytes were sent.
+type growingLog struct {
+	mu       sync.Mutex
+	content  []byte
+	sent     int64
+	useRange bool
+}
+
+func (g *growingLog) append(s string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.content = append(g.content, s...)
+}
+
+func (g *growingLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	data := append([]byte(nil), g.content...)
+	g.mu.Unlock()
+
+	if !g.useRange {
+		r.Header.Del("Range")
+	}
+	cw := &countingWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, "log.txt", time.Time{}, bytes.NewReader(data))
+
+	g.mu.Lock()
+	g.sent += cw.n
+	g.mu.Unlock()
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.ResponseWriter.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// streamLog simulates the TUI's streaming loop: an initial fetch followed by
+// polls while the log grows, returning the final content.
+func streamLog(t *testing.T, url string, log *growingLog, polls int, line string) string {
+	t.Helper()
+
+	chunk, err := downloadLogsSince(http.DefaultClient, url, 0, 0)
+	if err != nil {
+		t.Fatalf("initial downloadLogsSince() error = %v", err)
+	}
+	content, offset := chunk.Content, chunk.Size
+
+	for i := 0; i < polls; i++ {
+		log.append(line)
+		chunk, err := downloadLogsSince(http.DefaultClient, url, offset, 0)
+		if err != nil {
+			t.Fatalf("downloadLogsSince(offset=%d) error = %v", offset, err)
+		}
+		if chunk.Appended {
+			content += chunk.Content
+		} else {
+			content = chunk.Content
+		}
+		offset = chunk.Size
+	}
+	return content
+}
+
+func TestDownloadLogsSince(t *testing.T) {
+	const initialSize = 1 << 20 // 1 MB of existing output
+	const polls = 10
+	line := strings.Repeat("x", 1023) + "\n" // 1 KB added per poll
+
+	for _, useRange := range []bool{true, false} {
+		log := &growingLog{content: bytes.Repeat([]byte("y"), initialSize), useRange: useRange}
+		srv := httptest.NewServer(log)
+
+		got := streamLog(t, srv.URL, log, polls, line)
+		srv.Close()
+
+		if got != string(log.content) {
+			t.Errorf("useRange=%v: streamed content (%d bytes) differs from log (%d bytes)", useRange, len(got), len(log.content))
+		}
+
+		// With Range support each poll transfers only the new bytes; without it
+		// each poll re-downloads the whole log.
+		var want int64
+		if useRange {
+			want = initialSize + polls*int64(len(line))
+		} else {
+			want = int64(polls+1)*initialSize + int64(polls*(polls+1)/2*len(line))
+		}
+		if log.sent != want {
+			t.Errorf("useRange=%v: transferred %d bytes, want %d", useRange, log.sent, want)
+		}
+	}
+}
+
+func TestDownloadLogsSinceNoNewContent(t *testing.T) {
+	log := &growingLog{content: []byte("line 1\n"), useRange: true}
+	srv := httptest.NewServer(log)
+	defer srv.Close()
+
+	chunk, err := downloadLogsSince(http.DefaultClient, srv.URL, int64(len(log.content)), 0)
+	if err != nil {
+		t.Fatalf("downloadLogsSince() error = %v", err)
+	}
+	if !chunk.Appended || chunk.Content != "" || chunk.Size != int64(len(log.content)) {
+		t.Errorf("downloadLogsSince() = %+v, want empty append at same offset", chunk)
+	}
+}
+
+func TestLogCache(t *testing.T) {
+	cache := NewLogCache(t.TempDir())
+
+	if _, ok := cache.Get("owner", "repo", 42); ok {
+		t.Fatal("Get() on empty cache returned a hit")
+	}
+
+	data := []byte("PK\x03\x04 fake zip data")
+	if err := cache.Put("owner", "repo", 42, data); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, ok := cache.Get("owner", "repo", 42)
+	if !ok || !bytes.Equal(got, data) {
+		t.Errorf("Get() = %q, %v, want %q, true", got, ok, data)
+	}
+
+	// Cache is keyed by repo as well as job ID
+	if _, ok := cache.Get("owner", "other", 42); ok {
+		t.Error("Get() for a different repo returned a hit")
+	}
+
+	// Names can't reach outside the cache directory
+	for _, name := range [][2]string{{"..", ".."}, {"owner", "../../escaped"}, {"group/sub", "repo"}, {`..\..`, "repo"}} {
+		path := cache.path(name[0], name[1], 42)
+		if rel, err := filepath.Rel(cache.Dir, path); err != nil || strings.Count(rel, string(filepath.Separator)) != 2 || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			t.Errorf("path(%q, %q) = %s, want a file two levels inside %s", name[0], name[1], path, cache.Dir)
+		}
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok := cache.Get("owner", "repo", 42); ok {
+		t.Error("Get() after Clear() returned a hit")
+	}
+}
+
+func TestFetchJobLogDataUsesCache(t *testing.T) {
+	cache := NewLogCache(t.TempDir())
+	data := []byte("cached log")
+	if err := cache.Put("owner", "repo", 7, data); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// No REST client or token: any network access would fail, so a
+	// successful result must come from the cache
+	c := &Client{logCache: cache}
+	got, err := c.fetchJobLogData("owner", "repo", 7, true, nil)
+	if err != nil {
+		t.Fatalf("fetchJobLogData() error = %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("fetchJobLogData() = %q, want %q", got, data)
+	}
+}
+
+// endlessReader never runs out of log text
+type endlessReader struct{ read int64 }
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	r.read += int64(len(p))
+	return len(p), nil
+}
+
+func TestReadLimited(t *testing.T) {
+	r := &endlessReader{}
+	data, truncated, err := ReadLimited(r, 1000)
+	if err != nil || !truncated || len(data) != 1000 {
+		t.Errorf("ReadLimited(endless, 1000) = %d bytes, truncated %v, err %v", len(data), truncated, err)
+	}
+	if r.read > 1000+64*1024 {
+		t.Errorf("ReadLimited read %d bytes from an endless reader, want about the limit", r.read)
+	}
+
+	data, truncated, err = ReadLimited(strings.NewReader("short log"), 1000)
+	if err != nil || truncated || string(data) != "short log" {
+		t.Errorf("ReadLimited(short) = %q, truncated %v, err %v", data, truncated, err)
+	}
+
+	// 0 means no limit
+	data, truncated, err = ReadLimited(io.LimitReader(&endlessReader{}, 5000), 0)
+	if err != nil || truncated || len(data) != 5000 {
+		t.Errorf("ReadLimited(5000, 0) = %d bytes, truncated %v, err %v", len(data), truncated, err)
+	}
+}
+
+func TestExtractLogsFromZIPLimit(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, step := range []struct{ name, content string }{
+		{"1_Set up job.txt", "0123456789"},
+		{"2_Run tests.txt", "abcdefghij"},
+		{"3_Post.txt", "never read"},
+	} {
+		f, _ := zw.Create("build/" + step.name)
+		_, _ = f.Write([]byte(step.content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := extractLogsFromZIPStructured(archive.Bytes(), 15)
+	if err != nil {
+		t.Fatalf("extractLogsFromZIPStructured() error = %v", err)
+	}
+	if parsed.GetStep(1) != "0123456789" {
+		t.Errorf("step 1 = %q, want it whole", parsed.GetStep(1))
+	}
+	if got := parsed.GetStep(2); !strings.HasPrefix(got, "abcde\n") || !strings.Contains(got, "##[warning]cimon: log exceeded the 15 byte limit") {
+		t.Errorf("step 2 = %q, want 5 bytes and the truncation notice", got)
+	}
+	if strings.Contains(parsed.Combined, "never read") {
+		t.Error("extraction went past the limit")
+	}
+
+	// Without a limit everything is extracted
+	parsed, err = extractLogsFromZIPStructured(archive.Bytes(), 0)
+	if err != nil || !strings.Contains(parsed.Combined, "never read") || strings.Contains(parsed.Combined, "##[warning]") {
+		t.Errorf("unlimited extraction = %q, err %v", parsed.Combined, err)
+	}
+}
+
+func TestFetchJobLogsTooLarge(t *testing.T) {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/actions/jobs/9/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/storage/logs.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/logs.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, io.LimitReader(&endlessReader{}, 1<<20))
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	c.maxLogBytes = 64 * 1024
+
+	if _, err := c.FetchJobLogs("o", "r", 9, true); !errors.Is(err, ErrLogTooLarge) {
+		t.Errorf("FetchJobLogs() error = %v, want ErrLogTooLarge", err)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		n       int
+		want    string
+	}{
+		{"zero keeps everything", "a\nb\nc\n", 0, "a\nb\nc\n"},
+		{"last two lines", "a\nb\nc\n", 2, "b\nc\n"},
+		{"no trailing newline", "a\nb\nc", 2, "b\nc"},
+		{"more than available", "a\nb\n", 5, "a\nb\n"},
+		{"exactly available", "a\nb\n", 2, "a\nb\n"},
+		{"blank lines count", "a\n\n\nb\n", 3, "\n\nb\n"},
+		{"empty", "", 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TailLines(tt.content, tt.n); got != tt.want {
+				t.Errorf("TailLines(%q, %d) = %q, want %q", tt.content, tt.n, got, tt.want)
+			}
+		})
+	}
+}
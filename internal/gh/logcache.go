@@ -0,0 +1,90 @@
+package gh
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LogCache stores downloaded logs of completed jobs on disk. Completed job
+// logs never change, so reopening one can skip the API entirely.
+type LogCache struct {
+	Dir string
+}
+
+// NewLogCache creates a log cache rooted at dir
+func NewLogCache(dir string) *LogCache {
+	return &LogCache{Dir: dir}
+}
+
+// DefaultLogCacheDir returns the default cache location,
+// e.g. ~/.cache/cimon/logs on Linux
+func DefaultLogCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "cimon", "logs"), nil
+}
+
+// path returns the cache file for a job: {dir}/{owner}/{repo}/{jobID}.txt
+func (lc *LogCache) path(owner, repo string, jobID int64) string {
+	return filepath.Join(lc.Dir, cacheDirName(owner), cacheDirName(repo), fmt.Sprintf("%d.txt", jobID))
+}
+
+// cacheDirName escapes an owner or repo name into a single directory name,
+// so a GitLab group path or a name like ".." can't leave the cache
+func cacheDirName(name string) string {
+	name = url.PathEscape(name)
+	if name == "." || name == ".." {
+		return strings.ReplaceAll(name, ".", "%2E")
+	}
+	return name
+}
+
+// Get returns the cached raw log download for a job, if present
+func (lc *LogCache) Get(owner, repo string, jobID int64) ([]byte, bool) {
+	data, err := os.ReadFile(lc.path(owner, repo, jobID))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores a job's raw log download in the cache
+func (lc *LogCache) Put(owner, repo string, jobID int64, data []byte) error {
+	path := lc.path(owner, repo, jobID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temp file and rename so readers never see a partial log
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".log-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// Clear removes all cached logs
+func (lc *LogCache) Clear() error {
+	if err := os.RemoveAll(lc.Dir); err != nil {
+		return fmt.Errorf("failed to clear log cache: %w", err)
+	}
+	return nil
+}
@@ -2,24 +2,80 @@ package gh
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 	"time"
 )
 
 // WorkflowRun represents a GitHub Actions workflow run
 type WorkflowRun struct {
-	ID         int64     `json:"id"`
-	Name       string    `json:"name"`
-	Path       string    `json:"path"` // workflow file path, e.g. ".github/workflows/ci.yml"
-	RunNumber  int       `json:"run_number"`
-	Status     string    `json:"status"`     // queued, in_progress, completed
-	Conclusion *string   `json:"conclusion"` // success, failure, cancelled, skipped, timed_out, action_required
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	HTMLURL    string    `json:"html_url"`
-	Event      string    `json:"event"` // push, pull_request, workflow_dispatch, etc.
-	HeadBranch string    `json:"head_branch"`
-	Actor      *User     `json:"actor"`
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Path         string     `json:"path"` // workflow file path, e.g. ".github/workflows/ci.yml"
+	RunNumber    int        `json:"run_number"`
+	Status       string     `json:"status"`     // queued, in_progress, completed
+	Conclusion   *string    `json:"conclusion"` // success, failure, cancelled, skipped, timed_out, action_required
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	RunStartedAt *time.Time `json:"run_started_at"` // Start of the latest attempt
+	RunAttempt   int        `json:"run_attempt"`    // 1, or higher once re-run
+	HTMLURL      string     `json:"html_url"`
+	Event        string     `json:"event"` // push, pull_request, workflow_dispatch, etc.
+	HeadBranch   string     `json:"head_branch"`
+	HeadSHA      string     `json:"head_sha"`
+	Actor        *User      `json:"actor"`
+
+	// Pull requests the run's head commit belongs to; empty for pushes and
+	// for pull requests from forks
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
+// PullRequest is a pull request a workflow run was triggered for
+type PullRequest struct {
+	ID     int64          `json:"id"`
+	Number int            `json:"number"`
+	URL    string         `json:"url"` // API URL, not the web page
+	Head   PullRequestRef `json:"head"`
+	Base   PullRequestRef `json:"base"`
+}
+
+// PullRequestRef is the head or base branch of a pull request
+type PullRequestRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// RepoURL returns the web URL of the run's repository, derived from its
+// HTMLURL, or "" if that isn't a GitHub run URL
+func (r *WorkflowRun) RepoURL() string {
+	repoURL, _, ok := strings.Cut(r.HTMLURL, "/actions/runs/")
+	if !ok {
+		return ""
+	}
+	return repoURL
+}
+
+// ChangeLink returns a label and web URL for the change that triggered the
+// run: its pull request ("PR #42"), or for a push its commit
+// ("commit 1a2b3c4"). Both are "" if the run has neither.
+func (r *WorkflowRun) ChangeLink() (label, url string) {
+	repoURL := r.RepoURL()
+	if repoURL == "" {
+		return "", ""
+	}
+	switch {
+	case len(r.PullRequests) > 0:
+		pr := r.PullRequests[0]
+		return fmt.Sprintf("PR #%d", pr.Number), fmt.Sprintf("%s/pull/%d", repoURL, pr.Number)
+	case r.Event == "push" && r.HeadSHA != "":
+		short := r.HeadSHA
+		if len(short) > 7 {
+			short = short[:7]
+		}
+		return "commit " + short, repoURL + "/commit/" + r.HeadSHA
+	}
+	return "", ""
 }
 
 // User represents a GitHub user
@@ -39,6 +95,26 @@ func (s *SourcedRun) RepoSlug() string {
 	return s.Owner + "/" + s.Repo
 }
 
+// RepoError records a repository whose runs failed to load in multi-repo mode
+type RepoError struct {
+	Owner string
+	Repo  string
+	Err   error
+}
+
+// RepoSlug returns "owner/repo" format
+func (e *RepoError) RepoSlug() string {
+	return e.Owner + "/" + e.Repo
+}
+
+func (e *RepoError) Error() string {
+	return fmt.Sprintf("%s: %v", e.RepoSlug(), e.Err)
+}
+
+func (e *RepoError) Unwrap() error {
+	return e.Err
+}
+
 // Job represents a job within a workflow run
 type Job struct {
 	ID          int64      `json:"id"`
@@ -68,6 +144,20 @@ type WorkflowRunsResponse struct {
 	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
 }
 
+// Workflow is a workflow defined in a repository
+type Workflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`  // e.g. ".github/workflows/ci.yml"
+	State string `json:"state"` // active, disabled_manually, ...
+}
+
+// WorkflowsResponse is the API response for listing workflows
+type WorkflowsResponse struct {
+	TotalCount int        `json:"total_count"`
+	Workflows  []Workflow `json:"workflows"`
+}
+
 // JobsResponse is the API response for listing jobs
 type JobsResponse struct {
 	TotalCount int   `json:"total_count"`
@@ -86,6 +176,7 @@ const (
 	StatusQueued     = "queued"
 	StatusInProgress = "in_progress"
 	StatusCompleted  = "completed"
+	StatusWaiting    = "waiting" // Paused on an environment protection rule
 )
 
 // Conclusion constants
@@ -97,6 +188,8 @@ const (
 	ConclusionTimedOut       = "timed_out"
 	ConclusionActionRequired = "action_required"
 	ConclusionNeutral        = "neutral"
+	ConclusionStartupFailure = "startup_failure" // the workflow file was invalid, so no jobs ran
+	ConclusionStale          = "stale"           // superseded before it could complete
 )
 
 // IsCompleted returns true if the run has completed
@@ -104,6 +197,15 @@ func (r *WorkflowRun) IsCompleted() bool {
 	return r.Status == StatusCompleted
 }
 
+// Outcome returns the conclusion of a completed run, or "" while it's
+// still going
+func (r *WorkflowRun) Outcome() string {
+	if !r.IsCompleted() || r.Conclusion == nil {
+		return ""
+	}
+	return *r.Conclusion
+}
+
 // IsSuccess returns true if the run completed successfully
 func (r *WorkflowRun) IsSuccess() bool {
 	if r.Conclusion == nil {
@@ -122,6 +224,25 @@ func (r *WorkflowRun) IsFailure() bool {
 	return c == ConclusionFailure || c == ConclusionCancelled || c == ConclusionTimedOut || c == ConclusionActionRequired
 }
 
+// IsActionRequired returns true if the run is held for manual approval, e.g.
+// a first-time contributor's pull request. It is approved on the run's page.
+func (r *WorkflowRun) IsActionRequired() bool {
+	return r.Conclusion != nil && *r.Conclusion == ConclusionActionRequired
+}
+
+// DisplayName returns the workflow name for display. GitHub leaves the name
+// empty for some events, so it falls back to the workflow file name
+// (e.g. "ci.yml"), then to "workflow".
+func (r *WorkflowRun) DisplayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.Path != "" {
+		return path.Base(r.Path)
+	}
+	return "workflow"
+}
+
 // ActorLogin returns the login of the actor who triggered the run
 func (r *WorkflowRun) ActorLogin() string {
 	if r.Actor == nil {
@@ -130,6 +251,89 @@ func (r *WorkflowRun) ActorLogin() string {
 	return r.Actor.Login
 }
 
+// WallDuration returns how long the run has taken: until its last update
+// once completed, or until now while it's still going
+func (r *WorkflowRun) WallDuration(now time.Time) time.Duration {
+	start := r.CreatedAt
+	if r.RunStartedAt != nil {
+		start = *r.RunStartedAt
+	}
+	end := now
+	if r.IsCompleted() {
+		end = r.UpdatedAt
+	}
+	if start.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// minTypicalRuns is how many comparable runs TypicalDuration needs before
+// it gives an estimate
+const minTypicalRuns = 3
+
+// TypicalDuration averages the wall-clock duration of completed runs of the
+// workflow called name, as an estimate of how long a new run will take.
+// Cancelled and skipped runs stop early, so they're left out; ok is false
+// when fewer than three runs are left to average.
+func TypicalDuration(runs []WorkflowRun, name string) (d time.Duration, ok bool) {
+	var total time.Duration
+	count := 0
+	for i := range runs {
+		run := &runs[i]
+		if run.Name != name || !run.IsCompleted() || run.Conclusion == nil {
+			continue
+		}
+		if c := *run.Conclusion; c == ConclusionCancelled || c == ConclusionSkipped {
+			continue
+		}
+		if wall := run.WallDuration(run.UpdatedAt); wall > 0 {
+			total += wall
+			count++
+		}
+	}
+	if count < minTypicalRuns {
+		return 0, false
+	}
+	return total / time.Duration(count), true
+}
+
+// BlockingRun guesses which run a queued run is waiting on: GitHub holds
+// runs back while an earlier run in the same concurrency group is going,
+// and the API doesn't say so, so this picks the oldest in-progress run of
+// the same workflow created before it. It's a heuristic; nil means none.
+func BlockingRun(runs []WorkflowRun, run *WorkflowRun) *WorkflowRun {
+	if run == nil || run.Status == StatusInProgress || run.Status == StatusWaiting || run.IsCompleted() {
+		return nil
+	}
+	var blocking *WorkflowRun
+	for i := range runs {
+		other := &runs[i]
+		if other.ID == run.ID || other.Status != StatusInProgress || !other.CreatedAt.Before(run.CreatedAt) {
+			continue
+		}
+		if other.Path != run.Path || other.Name != run.Name {
+			continue
+		}
+		if blocking == nil || other.CreatedAt.Before(blocking.CreatedAt) {
+			blocking = other
+		}
+	}
+	return blocking
+}
+
+// JobTime sums the durations of completed jobs and returns the total and
+// how many jobs it covers
+func JobTime(jobs []Job) (total time.Duration, count int) {
+	for i := range jobs {
+		if d := jobs[i].Duration(); d > 0 {
+			total += d
+			count++
+		}
+	}
+	return total, count
+}
+
 // Duration returns the duration of a completed job
 func (j *Job) Duration() time.Duration {
 	if j.StartedAt == nil || j.CompletedAt == nil {
@@ -143,6 +347,35 @@ func (j *Job) IsCompleted() bool {
 	return j.Status == StatusCompleted
 }
 
+// RunningStep returns the step the job is currently executing, or nil if
+// none is in progress (e.g. between steps, or once the job has finished)
+func (j *Job) RunningStep() *JobStep {
+	for i := range j.Steps {
+		if j.Steps[i].Status == StatusInProgress {
+			return &j.Steps[i]
+		}
+	}
+	return nil
+}
+
+// IsFailure returns true if the job failed
+func (j *Job) IsFailure() bool {
+	if j.Conclusion == nil {
+		return false
+	}
+	c := *j.Conclusion
+	return c == ConclusionFailure || c == ConclusionCancelled || c == ConclusionTimedOut
+}
+
+// IsFailure returns true if the step failed
+func (s *JobStep) IsFailure() bool {
+	if s.Conclusion == nil {
+		return false
+	}
+	c := *s.Conclusion
+	return c == ConclusionFailure || c == ConclusionCancelled || c == ConclusionTimedOut
+}
+
 // Content represents a file or directory from the GitHub Contents API
 type Content struct {
 	Name        string `json:"name"`
@@ -158,6 +391,100 @@ type Content struct {
 	Encoding    string `json:"encoding"` // "base64" for files
 }
 
+// Annotation levels reported by the checks API
+const (
+	AnnotationNotice  = "notice"
+	AnnotationWarning = "warning"
+	AnnotationFailure = "failure"
+)
+
+// Annotation is a check-run annotation pointing at the file and line of an
+// error or warning reported by a job
+type Annotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"` // notice, warning, failure
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+
+	// Job the annotation belongs to, filled in by FetchAnnotations
+	JobID   int64  `json:"-"`
+	JobName string `json:"-"`
+}
+
+// Location returns "path:line" (or "path:start-end"), or "" if the annotation
+// has no file
+func (a *Annotation) Location() string {
+	if a.Path == "" {
+		return ""
+	}
+	switch {
+	case a.StartLine == 0:
+		return a.Path
+	case a.EndLine > a.StartLine:
+		return fmt.Sprintf("%s:%d-%d", a.Path, a.StartLine, a.EndLine)
+	default:
+		return fmt.Sprintf("%s:%d", a.Path, a.StartLine)
+	}
+}
+
+// RunTiming is a run's billable time per runner OS and its total duration
+type RunTiming struct {
+	Billable      map[string]BillableTiming `json:"billable"` // Keyed by OS: UBUNTU, MACOS, WINDOWS
+	RunDurationMS int64                     `json:"run_duration_ms"`
+}
+
+// BillableTiming is the billable time of a run's jobs on one runner OS
+type BillableTiming struct {
+	TotalMS int64 `json:"total_ms"`
+	Jobs    int   `json:"jobs"`
+}
+
+// RunDuration returns the run's duration as measured by GitHub
+func (t *RunTiming) RunDuration() time.Duration {
+	return time.Duration(t.RunDurationMS) * time.Millisecond
+}
+
+// BillableSummary describes the billable time per OS, e.g.
+// "UBUNTU 12m, MACOS 4m", or "" if nothing was billed (public repos and
+// self-hosted runners aren't)
+func (t *RunTiming) BillableSummary(format func(time.Duration) string) string {
+	oses := make([]string, 0, len(t.Billable))
+	for runnerOS, timing := range t.Billable {
+		if timing.TotalMS > 0 {
+			oses = append(oses, runnerOS)
+		}
+	}
+	sort.Strings(oses)
+	parts := make([]string, len(oses))
+	for i, runnerOS := range oses {
+		parts[i] = runnerOS + " " + format(time.Duration(t.Billable[runnerOS].TotalMS)*time.Millisecond)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CommitVerification is GitHub's check of a commit's GPG, SSH or S/MIME
+// signature
+type CommitVerification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason"` // valid, unsigned, unknown_key, bad_email, ...
+}
+
+// PendingDeployment is an environment a run is waiting on for approval
+type PendingDeployment struct {
+	Environment           DeploymentEnvironment `json:"environment"`
+	WaitTimer             int                   `json:"wait_timer"` // Minutes to wait before the deployment can proceed
+	CurrentUserCanApprove bool                  `json:"current_user_can_approve"`
+}
+
+// DeploymentEnvironment identifies the environment of a pending deployment
+type DeploymentEnvironment struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
 // Artifact represents a GitHub Actions artifact
 type Artifact struct {
 	ID                 int64     `json:"id"`
@@ -169,6 +496,7 @@ type Artifact struct {
 	Expired            bool      `json:"expired"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+	ExpiresAt          time.Time `json:"expires_at"`
 }
 
 // ArtifactsResponse is the API response for listing artifacts
@@ -255,6 +255,46 @@ func TestJobIsCompleted(t *testing.T) {
 	}
 }
 
+func TestJobRunningStep(t *testing.T) {
+	job := Job{Status: StatusInProgress, Steps: []JobStep{
+		{Number: 1, Name: "Set up job", Status: StatusCompleted},
+		{Number: 2, Name: "Build", Status: StatusInProgress},
+		{Number: 3, Name: "Test", Status: "pending"},
+	}}
+	if step := job.RunningStep(); step == nil || step.Number != 2 {
+		t.Errorf("RunningStep() = %v, want step 2", step)
+	}
+
+	job.Steps[1].Status = StatusCompleted
+	if step := job.RunningStep(); step != nil {
+		t.Errorf("RunningStep() = %v between steps, want nil", step)
+	}
+}
+
+func TestJobIsFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		conclusion *string
+		want       bool
+	}{
+		{"nil conclusion", nil, false},
+		{"success", strPtr(ConclusionSuccess), false},
+		{"skipped", strPtr(ConclusionSkipped), false},
+		{"failure", strPtr(ConclusionFailure), true},
+		{"cancelled", strPtr(ConclusionCancelled), true},
+		{"timed out", strPtr(ConclusionTimedOut), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := Job{Status: StatusCompleted, Conclusion: tt.conclusion}
+			if got := job.IsFailure(); got != tt.want {
+				t.Errorf("IsFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJobDurationNilTimes(t *testing.T) {
 	job := Job{}
 	if got := job.Duration(); got != 0 {
@@ -391,3 +431,214 @@ func TestRepositoryParsing(t *testing.T) {
 		t.Errorf("DefaultBranch = %q, want %q", repo.DefaultBranch, "main")
 	}
 }
+
+func TestWorkflowRunDisplayName(t *testing.T) {
+	tests := []struct {
+		name string
+		run  WorkflowRun
+		want string
+	}{
+		{"name set", WorkflowRun{Name: "CI", Path: ".github/workflows/ci.yml"}, "CI"},
+		{"empty name with path", WorkflowRun{Path: ".github/workflows/deploy.yml"}, "deploy.yml"},
+		{"empty name without path", WorkflowRun{}, "workflow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.run.DisplayName(); got != tt.want {
+				t.Errorf("DisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotationParsing(t *testing.T) {
+	jsonData := `{
+		"path": "pkg/foo_test.go",
+		"start_line": 42,
+		"end_line": 42,
+		"annotation_level": "failure",
+		"title": "TestFoo",
+		"message": "expected 1, got 2"
+	}`
+
+	var a Annotation
+	if err := json.Unmarshal([]byte(jsonData), &a); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if a.AnnotationLevel != AnnotationFailure || a.Message != "expected 1, got 2" {
+		t.Errorf("parsed annotation = %+v", a)
+	}
+	if got := a.Location(); got != "pkg/foo_test.go:42" {
+		t.Errorf("Location() = %q, want %q", got, "pkg/foo_test.go:42")
+	}
+
+	tests := []struct {
+		a    Annotation
+		want string
+	}{
+		{Annotation{Path: "a.go", StartLine: 3, EndLine: 7}, "a.go:3-7"},
+		{Annotation{Path: ".github"}, ".github"},
+		{Annotation{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Location(); got != tt.want {
+			t.Errorf("Location(%+v) = %q, want %q", tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestRunAndJobTime(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		t := base.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+
+	run := WorkflowRun{Status: StatusCompleted, CreatedAt: base, RunStartedAt: at(1), UpdatedAt: *at(7)}
+	if got := run.WallDuration(base.Add(time.Hour)); got != 6*time.Minute {
+		t.Errorf("completed WallDuration() = %s, want 6m", got)
+	}
+	run.Status = StatusInProgress
+	if got := run.WallDuration(*at(3)); got != 2*time.Minute {
+		t.Errorf("in-progress WallDuration() = %s, want 2m", got)
+	}
+
+	jobs := []Job{
+		{StartedAt: at(1), CompletedAt: at(5)},
+		{StartedAt: at(1), CompletedAt: at(4)},
+		{StartedAt: at(2)}, // still running
+	}
+	if total, count := JobTime(jobs); total != 7*time.Minute || count != 2 {
+		t.Errorf("JobTime() = %s, %d; want 7m, 2", total, count)
+	}
+}
+
+func TestTypicalDuration(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	success, failure, cancelled := ConclusionSuccess, ConclusionFailure, ConclusionCancelled
+	completed := func(name string, conclusion *string, minutes int) WorkflowRun {
+		return WorkflowRun{Name: name, Status: StatusCompleted, Conclusion: conclusion, CreatedAt: base, UpdatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	runs := []WorkflowRun{
+		{Name: "CI", Status: StatusInProgress, CreatedAt: base},
+		completed("CI", &success, 6),
+		completed("CI", &failure, 8),
+		completed("CI", &cancelled, 1), // stopped early
+		completed("Release", &success, 30),
+	}
+
+	if _, ok := TypicalDuration(runs, "CI"); ok {
+		t.Error("TypicalDuration() with two comparable runs should give no estimate")
+	}
+	runs = append(runs, completed("CI", &success, 7))
+	if d, ok := TypicalDuration(runs, "CI"); !ok || d != 7*time.Minute {
+		t.Errorf("TypicalDuration() = %s, %v; want 7m", d, ok)
+	}
+}
+
+func TestBlockingRun(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func(id int64, name, status string, minutes int) WorkflowRun {
+		return WorkflowRun{ID: id, Name: name, RunNumber: int(id), Status: status, CreatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	queued := run(5, "Deploy", StatusQueued, 10)
+	runs := []WorkflowRun{
+		queued,
+		run(4, "Deploy", StatusInProgress, 8),
+		run(3, "Deploy", StatusInProgress, 5),
+		run(2, "CI", StatusInProgress, 1),      // other workflow
+		run(6, "Deploy", StatusInProgress, 12), // started after the queued run
+	}
+
+	if got := BlockingRun(runs, &queued); got == nil || got.ID != 3 {
+		t.Errorf("BlockingRun() = %v, want run 3", got)
+	}
+
+	running := runs[1]
+	if got := BlockingRun(runs, &running); got != nil {
+		t.Errorf("BlockingRun() for a running run = %v, want nil", got)
+	}
+
+	alone := run(7, "Release", StatusQueued, 20)
+	if got := BlockingRun(runs, &alone); got != nil {
+		t.Errorf("BlockingRun() with no other runs of the workflow = %v, want nil", got)
+	}
+}
+
+func TestRunTimingParsing(t *testing.T) {
+	jsonData := `{
+		"billable": {
+			"UBUNTU": {"total_ms": 720000, "jobs": 3},
+			"MACOS": {"total_ms": 240000, "jobs": 1},
+			"WINDOWS": {"total_ms": 0, "jobs": 0}
+		},
+		"run_duration_ms": 360000
+	}`
+
+	var timing RunTiming
+	if err := json.Unmarshal([]byte(jsonData), &timing); err != nil {
+		t.Fatalf("failed to parse RunTiming: %v", err)
+	}
+	if timing.RunDuration() != 6*time.Minute {
+		t.Errorf("RunDuration() = %s, want 6m", timing.RunDuration())
+	}
+	if got := timing.BillableSummary(time.Duration.String); got != "MACOS 4m0s, UBUNTU 12m0s" {
+		t.Errorf("BillableSummary() = %q", got)
+	}
+	if got := (&RunTiming{}).BillableSummary(time.Duration.String); got != "" {
+		t.Errorf("BillableSummary() with nothing billed = %q, want empty", got)
+	}
+}
+
+func TestWorkflowRunPullRequestsParsing(t *testing.T) {
+	jsonData := `{
+		"id": 9,
+		"event": "pull_request",
+		"html_url": "https://github.com/o/r/actions/runs/9",
+		"head_sha": "1a2b3c4d5e6f",
+		"pull_requests": [
+			{
+				"id": 1001,
+				"number": 42,
+				"url": "https://api.github.com/repos/o/r/pulls/42",
+				"head": {"ref": "feature", "sha": "1a2b3c4d5e6f", "repo": {"id": 1, "name": "r"}},
+				"base": {"ref": "main", "sha": "ffffff", "repo": {"id": 1, "name": "r"}}
+			}
+		]
+	}`
+
+	var run WorkflowRun
+	if err := json.Unmarshal([]byte(jsonData), &run); err != nil {
+		t.Fatalf("failed to parse WorkflowRun: %v", err)
+	}
+	if len(run.PullRequests) != 1 {
+		t.Fatalf("got %d pull requests, want 1", len(run.PullRequests))
+	}
+	pr := run.PullRequests[0]
+	if pr.Number != 42 || pr.Head.Ref != "feature" || pr.Base.Ref != "main" || pr.Head.SHA != run.HeadSHA {
+		t.Errorf("pull request = %+v", pr)
+	}
+	if label, url := run.ChangeLink(); label != "PR #42" || url != "https://github.com/o/r/pull/42" {
+		t.Errorf("ChangeLink() = %q, %q", label, url)
+	}
+}
+
+func TestWorkflowRunChangeLink(t *testing.T) {
+	run := WorkflowRun{Event: "push", HeadSHA: "1a2b3c4d5e6f", HTMLURL: "https://github.com/o/r/actions/runs/9"}
+	if label, url := run.ChangeLink(); label != "commit 1a2b3c4" || url != "https://github.com/o/r/commit/1a2b3c4d5e6f" {
+		t.Errorf("push ChangeLink() = %q, %q", label, url)
+	}
+
+	// A pull request from a fork has no pull_requests entry
+	run.Event = "pull_request"
+	if label, url := run.ChangeLink(); label != "" || url != "" {
+		t.Errorf("fork pull_request ChangeLink() = %q, %q, want none", label, url)
+	}
+
+	// Runs from other providers have no GitHub URLs to build on
+	gitlab := WorkflowRun{Event: "push", HeadSHA: "abc", HTMLURL: "https://gitlab.com/o/r/-/pipelines/9"}
+	if label, _ := gitlab.ChangeLink(); label != "" {
+		t.Errorf("non-GitHub ChangeLink() = %q, want none", label)
+	}
+}
@@ -0,0 +1,36 @@
+package gh
+
+import "io"
+
+// ProgressFunc receives download progress: bytes received so far and the
+// expected total, or -1 if the server didn't send a Content-Length
+type ProgressFunc func(done, total int64)
+
+// progressReader counts bytes read through it and reports them to a ProgressFunc
+type progressReader struct {
+	r        io.Reader
+	done     int64
+	total    int64
+	progress ProgressFunc
+}
+
+// withProgress wraps r to report progress against total; with a nil
+// ProgressFunc it returns r unchanged
+func withProgress(r io.Reader, total int64, progress ProgressFunc) io.Reader {
+	if progress == nil {
+		return r
+	}
+	if total <= 0 {
+		total = -1
+	}
+	return &progressReader{r: r, total: total, progress: progress}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.done += int64(n)
+		p.progress(p.done, p.total)
+	}
+	return n, err
+}
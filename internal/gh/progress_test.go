@@ -0,0 +1,39 @@
+package gh
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWithProgress(t *testing.T) {
+	var calls [][2]int64
+	r := withProgress(strings.NewReader("hello world"), 11, func(done, total int64) {
+		calls = append(calls, [2]int64{done, total})
+	})
+
+	data, err := io.ReadAll(r)
+	if err != nil || string(data) != "hello world" {
+		t.Fatalf("read %q, err = %v", data, err)
+	}
+	if len(calls) == 0 || calls[len(calls)-1] != [2]int64{11, 11} {
+		t.Errorf("progress calls = %v, want last (11, 11)", calls)
+	}
+
+	// Unknown length reports a total of -1
+	calls = nil
+	if _, err := io.ReadAll(withProgress(strings.NewReader("abc"), -1, func(done, total int64) {
+		calls = append(calls, [2]int64{done, total})
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) == 0 || calls[len(calls)-1] != [2]int64{3, -1} {
+		t.Errorf("progress calls = %v, want last (3, -1)", calls)
+	}
+
+	// No ProgressFunc leaves the reader untouched
+	src := strings.NewReader("x")
+	if withProgress(src, 1, nil) != io.Reader(src) {
+		t.Error("withProgress(nil) wrapped the reader")
+	}
+}
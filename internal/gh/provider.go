@@ -0,0 +1,56 @@
+package gh
+
+import (
+	"context"
+	"time"
+)
+
+// Provider is the CI backend the TUI and CLI work against. *Client
+// implements it for GitHub Actions; other backends (see internal/gitlab)
+// map their pipelines and jobs onto the same run and job types, and return
+// ErrUnsupported for actions their CI system has no equivalent for.
+type Provider interface {
+	// Runs
+	FetchWorkflowRuns(owner, repo, branch, status, event, created string, page, perPage int) ([]WorkflowRun, error)
+	FetchWorkflowRunsContext(ctx context.Context, owner, repo, branch, status, event, created string, page, perPage int) ([]WorkflowRun, error)
+	FetchLatestRun(owner, repo, branch, event, created string) (*WorkflowRun, error)
+	FetchRun(owner, repo string, runID int64) (*WorkflowRun, error)
+	FetchRunAttempt(owner, repo string, runID int64, attempt int) (*WorkflowRun, error)
+	FindRunByNumber(owner, repo string, number int) (*WorkflowRun, error)
+	FetchRunTiming(owner, repo string, runID int64) (*RunTiming, error)
+	FetchCommitVerification(owner, repo, sha string) (*CommitVerification, error)
+	GetRepository(owner, repo string) (*Repository, error)
+	FetchBranches(owner, repo string) ([]Branch, error)
+
+	// Jobs and logs
+	FetchJobs(owner, repo string, runID int64) ([]Job, error)
+	FetchAttemptJobs(owner, repo string, runID int64, attempt int) ([]Job, error)
+	FetchJobDetails(owner, repo string, jobID int64) (*Job, error)
+	FetchJobLogs(owner, repo string, jobID int64, completed bool) (string, error)
+	FetchJobLogsWithProgress(owner, repo string, jobID int64, completed bool, progress ProgressFunc) (string, error)
+	FetchJobLogsStructured(owner, repo string, jobID int64, completed bool) (*ParsedLogs, error)
+	FetchJobLogsSince(owner, repo string, jobID, offset int64) (*LogChunk, error)
+	FetchAnnotations(owner, repo string, runID int64) ([]Annotation, error)
+
+	// Workflow files and artifacts
+	FetchWorkflows(owner, repo string) ([]Workflow, error)
+	FetchWorkflowContent(owner, repo, path string) (string, error)
+	FetchWorkflowArtifacts(owner, repo string, runID int64) ([]Artifact, error)
+	DownloadArtifactWithProgress(owner, repo string, artifactID int64, filename string, progress ProgressFunc) error
+
+	// Actions
+	RerunWorkflow(owner, repo string, runID int64) error
+	RerunJob(owner, repo string, jobID int64) error
+	RerunFailedJobs(owner, repo string, runID int64) error
+	CancelWorkflow(owner, repo string, runID int64) error
+	DispatchWorkflow(owner, repo, workflowFile, ref string) error
+	FetchDispatchedRun(owner, repo, workflowFile, ref string, since time.Time) (*WorkflowRun, error)
+	FetchPendingDeployments(owner, repo string, runID int64) ([]PendingDeployment, error)
+	ApproveDeployment(owner, repo string, runID int64, environmentIDs []int64) error
+	RejectDeployment(owner, repo string, runID int64, environmentIDs []int64) error
+
+	// Sharing
+	CreateGist(files map[string]string, public bool) (string, error)
+}
+
+var _ Provider = (*Client)(nil)
@@ -0,0 +1,34 @@
+package gh
+
+import "time"
+
+// RateLimit is the token's core REST API rate limit
+type RateLimit struct {
+	Limit     int       // Requests allowed per hour
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window resets
+}
+
+// FetchRateLimit fetches the token's core rate limit. Checking it doesn't
+// count against the limit, so it doubles as a cheap reachability probe.
+func (c *Client) FetchRateLimit() (*RateLimit, error) {
+	var response struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	if err := c.Get("rate_limit", &response); err != nil {
+		return nil, err
+	}
+
+	core := response.Resources.Core
+	return &RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
@@ -1,8 +1,12 @@
 package gh
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"net/http"
 	"time"
 )
 
@@ -11,6 +15,39 @@ type RetryConfig struct {
 	MaxRetries int
 	BaseDelay  time.Duration
 	MaxDelay   time.Duration
+
+	// Jitter randomizes a computed backoff delay so concurrent clients don't
+	// retry in lockstep. If nil, EqualJitter is used.
+	Jitter func(delay time.Duration) time.Duration
+}
+
+// EqualJitter returns a random delay between delay/2 and delay
+func EqualJitter(delay time.Duration) time.Duration {
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// backoffDelay computes the jittered exponential backoff delay for an attempt,
+// capped at MaxDelay
+func backoffDelay(config RetryConfig, attempt int) time.Duration {
+	delay := time.Duration(float64(config.BaseDelay) * math.Pow(2, float64(attempt)))
+	if delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
+
+	jitter := config.Jitter
+	if jitter == nil {
+		jitter = EqualJitter
+	}
+	delay = jitter(delay)
+
+	if delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
+	return delay
 }
 
 // DefaultRetryConfig returns sensible defaults for API retries
@@ -43,7 +80,8 @@ func IsRetryable(err error) bool {
 	}
 
 	// Check for HTTP status codes that indicate temporary issues
-	if httpErr, ok := err.(*RetryableError); ok {
+	var httpErr *RetryableError
+	if errors.As(err, &httpErr) {
 		return httpErr.Retryable
 	}
 
@@ -68,6 +106,12 @@ func IsRetryable(err error) bool {
 	return false
 }
 
+// isRetryableStatus reports whether an HTTP status is worth retrying:
+// rate limiting or a server-side failure
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
 // containsIgnoreCase checks if a string contains a substring (case-insensitive)
 func containsIgnoreCase(s, substr string) bool {
 	return len(s) >= len(substr) &&
@@ -100,6 +144,12 @@ func equalIgnoreCase(a, b string) bool {
 
 // RetryWithBackoff executes a function with exponential backoff retry logic
 func RetryWithBackoff(fn func() error, config RetryConfig) error {
+	return RetryWithBackoffContext(context.Background(), fn, config)
+}
+
+// RetryWithBackoffContext retries like RetryWithBackoff, but stops waiting
+// and gives up as soon as ctx is done
+func RetryWithBackoffContext(ctx context.Context, fn func() error, config RetryConfig) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
@@ -116,17 +166,18 @@ func RetryWithBackoff(fn func() error, config RetryConfig) error {
 		}
 
 		// Check if error is retryable
-		if !IsRetryable(err) {
+		if !IsRetryable(err) || ctx.Err() != nil {
 			break // Don't retry non-retryable errors
 		}
 
-		// Calculate delay with exponential backoff
-		delay := time.Duration(float64(config.BaseDelay) * math.Pow(2, float64(attempt)))
-		if delay > config.MaxDelay {
-			delay = config.MaxDelay
+		// Exponential backoff with jitter
+		timer := time.NewTimer(backoffDelay(config, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up retrying: %w", ctx.Err())
+		case <-timer.C:
 		}
-
-		time.Sleep(delay)
 	}
 
 	return fmt.Errorf("failed after %d retries: %w", config.MaxRetries, lastErr)
@@ -1,6 +1,7 @@
 package gh
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -193,3 +194,104 @@ func TestRetryWithBackoff_MaxRetriesExhausted(t *testing.T) {
 		t.Errorf("RetryWithBackoff() called fn %d times, want 3", callCount)
 	}
 }
+
+func TestBackoffDelayBounds(t *testing.T) {
+	cfg := RetryConfig{
+		MaxRetries: 6,
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   2 * time.Second,
+	}
+
+	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
+		base := cfg.BaseDelay << attempt
+		if base > cfg.MaxDelay {
+			base = cfg.MaxDelay
+		}
+		for i := 0; i < 100; i++ {
+			got := backoffDelay(cfg, attempt)
+			if got < base/2 || got > base {
+				t.Fatalf("backoffDelay(attempt=%d) = %v, want between %v and %v", attempt, got, base/2, base)
+			}
+		}
+	}
+}
+
+func TestBackoffDelayCustomJitter(t *testing.T) {
+	var seen []time.Duration
+	cfg := RetryConfig{
+		BaseDelay: 1 * time.Second,
+		MaxDelay:  3 * time.Second,
+		Jitter: func(d time.Duration) time.Duration {
+			seen = append(seen, d)
+			return d * 2 // Exceeds MaxDelay on purpose
+		},
+	}
+
+	if got := backoffDelay(cfg, 0); got != 2*time.Second {
+		t.Errorf("backoffDelay(attempt=0) = %v, want 2s", got)
+	}
+	// Jittered delay is capped at MaxDelay
+	if got := backoffDelay(cfg, 1); got != 3*time.Second {
+		t.Errorf("backoffDelay(attempt=1) = %v, want 3s (capped)", got)
+	}
+	if len(seen) != 2 || seen[0] != 1*time.Second || seen[1] != 2*time.Second {
+		t.Errorf("Jitter called with %v, want [1s 2s]", seen)
+	}
+}
+
+func TestClientUsesCustomRetryConfig(t *testing.T) {
+	// Fails more times than DefaultRetryConfig allows, so success proves the
+	// client's own policy is used
+	failures := DefaultRetryConfig().MaxRetries + 1
+
+	callCount := 0
+	fn := func() error {
+		callCount++
+		if callCount <= failures {
+			return errors.New("503 Service Unavailable")
+		}
+		return nil
+	}
+
+	c := &Client{retry: RetryConfig{
+		MaxRetries: failures,
+		BaseDelay:  1 * time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}}
+
+	if err := c.withRetry(fn); err != nil {
+		t.Errorf("withRetry() error = %v, want nil", err)
+	}
+	if callCount != failures+1 {
+		t.Errorf("withRetry() called fn %d times, want %d", callCount, failures+1)
+	}
+}
+
+func TestRetryWithBackoffContext_StopsWhenDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	callCount := 0
+	fn := func() error {
+		callCount++
+		return errors.New("503 Service Unavailable")
+	}
+
+	cfg := RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  time.Hour,
+		MaxDelay:   time.Hour,
+	}
+
+	start := time.Now()
+	err := RetryWithBackoffContext(ctx, fn, cfg)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RetryWithBackoffContext() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RetryWithBackoffContext() waited %v after the context expired", elapsed)
+	}
+	if callCount != 1 {
+		t.Errorf("RetryWithBackoffContext() called fn %d times, want 1", callCount)
+	}
+}
@@ -1,14 +1,21 @@
 package gh
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
+// maxRunLookupPages bounds how far back FindRunByNumber searches
+const maxRunLookupPages = 10
+
 // FetchLatestRun fetches the most recent workflow run for a branch.
+// The optional event and created filters restrict runs by trigger and
+// creation time (see FetchWorkflowRuns).
 // Returns ErrNoRuns if no runs are found.
-func (c *Client) FetchLatestRun(owner, repo, branch string) (*WorkflowRun, error) {
-	runs, err := c.FetchWorkflowRuns(owner, repo, branch, "", 1, 1)
+func (c *Client) FetchLatestRun(owner, repo, branch, event, created string) (*WorkflowRun, error) {
+	runs, err := c.FetchWorkflowRuns(owner, repo, branch, "", event, created, 1, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -20,8 +27,60 @@ func (c *Client) FetchLatestRun(owner, repo, branch string) (*WorkflowRun, error
 	return &runs[0], nil
 }
 
+// FindRunByNumber finds a workflow run by its run number, searching the most
+// recent runs across all branches. Run numbers are per workflow, so it's an
+// error if runs of several workflows share the number.
+// Returns ErrNoRuns if no run has that number.
+func (c *Client) FindRunByNumber(owner, repo string, number int) (*WorkflowRun, error) {
+	const perPage = 100
+	var matches []WorkflowRun
+
+	for page := 1; page <= maxRunLookupPages; page++ {
+		runs, err := c.FetchWorkflowRuns(owner, repo, "", "", "", "", page, perPage)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, matchRunNumber(runs, number)...)
+		if len(runs) < perPage {
+			break
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, ErrNoRuns
+	case 1:
+		return &matches[0], nil
+	}
+
+	names := make([]string, len(matches))
+	for i, run := range matches {
+		names[i] = fmt.Sprintf("%s (ID %d)", run.DisplayName(), run.ID)
+	}
+	return nil, fmt.Errorf("run #%d matches several workflows: %s; use --run-id instead", number, strings.Join(names, ", "))
+}
+
+// matchRunNumber returns the runs with the given run number
+func matchRunNumber(runs []WorkflowRun, number int) []WorkflowRun {
+	var matches []WorkflowRun
+	for _, run := range runs {
+		if run.RunNumber == number {
+			matches = append(matches, run)
+		}
+	}
+	return matches
+}
+
 // FetchWorkflowRuns fetches workflow runs with pagination and optional filtering.
-func (c *Client) FetchWorkflowRuns(owner, repo, branch, status string, page, perPage int) ([]WorkflowRun, error) {
+// event is the triggering event such as "push" or "pull_request".
+// created is a GitHub date qualifier such as ">=2024-01-01" or "2024-01-01..2024-01-31".
+func (c *Client) FetchWorkflowRuns(owner, repo, branch, status, event, created string, page, perPage int) ([]WorkflowRun, error) {
+	return c.FetchWorkflowRunsContext(context.Background(), owner, repo, branch, status, event, created, page, perPage)
+}
+
+// FetchWorkflowRunsContext fetches workflow runs like FetchWorkflowRuns,
+// giving up when ctx is done
+func (c *Client) FetchWorkflowRunsContext(ctx context.Context, owner, repo, branch, status, event, created string, page, perPage int) ([]WorkflowRun, error) {
 	path := fmt.Sprintf("repos/%s/%s/actions/runs?page=%d&per_page=%d",
 		url.PathEscape(owner),
 		url.PathEscape(repo),
@@ -39,14 +98,61 @@ func (c *Client) FetchWorkflowRuns(owner, repo, branch, status string, page, per
 		path += "&status=" + url.QueryEscape(status)
 	}
 
+	// Add event filter if specified
+	if event != "" {
+		path += "&event=" + url.QueryEscape(event)
+	}
+
+	// Add creation time filter if specified
+	if created != "" {
+		path += "&created=" + url.QueryEscape(created)
+	}
+
 	var response WorkflowRunsResponse
-	if err := c.Get(path, &response); err != nil {
+	if err := c.GetContext(ctx, path, &response); err != nil {
 		return nil, err
 	}
 
 	return response.WorkflowRuns, nil
 }
 
+// FetchRunTiming fetches a run's billable time per runner OS and its duration.
+func (c *Client) FetchRunTiming(owner, repo string, runID int64) (*RunTiming, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/timing",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		runID,
+	)
+
+	var timing RunTiming
+	if err := c.Get(path, &timing); err != nil {
+		return nil, err
+	}
+
+	return &timing, nil
+}
+
+// FetchCommitVerification fetches the signature verification of a commit,
+// such as a run's head commit.
+func (c *Client) FetchCommitVerification(owner, repo, sha string) (*CommitVerification, error) {
+	// The git commits endpoint carries the verification without the diff
+	// the repo commits endpoint would load for a large commit
+	path := fmt.Sprintf("repos/%s/%s/git/commits/%s",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		url.PathEscape(sha),
+	)
+
+	var commit struct {
+		Verification CommitVerification `json:"verification"`
+	}
+	if err := c.Get(path, &commit); err != nil {
+		return nil, err
+	}
+
+	return &commit.Verification, nil
+}
+
 // FetchRun fetches a specific workflow run by ID.
 func (c *Client) FetchRun(owner, repo string, runID int64) (*WorkflowRun, error) {
 	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d",
@@ -62,3 +168,21 @@ func (c *Client) FetchRun(owner, repo string, runID int64) (*WorkflowRun, error)
 
 	return &run, nil
 }
+
+// FetchRunAttempt fetches a workflow run as it was on one attempt, so an
+// earlier attempt's status and conclusion survive a re-run
+func (c *Client) FetchRunAttempt(owner, repo string, runID int64, attempt int) (*WorkflowRun, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/attempts/%d",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		runID,
+		attempt,
+	)
+
+	var run WorkflowRun
+	if err := c.Get(path, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,33 @@
+package gh
+
+import "testing"
+
+func TestMatchRunNumber(t *testing.T) {
+	runs := []WorkflowRun{
+		{ID: 1, Name: "CI", RunNumber: 12},
+		{ID: 2, Name: "Deploy", RunNumber: 12},
+		{ID: 3, Name: "CI", RunNumber: 11},
+	}
+
+	tests := []struct {
+		number  int
+		wantIDs []int64
+	}{
+		{11, []int64{3}},
+		{12, []int64{1, 2}},
+		{99, nil},
+	}
+
+	for _, tt := range tests {
+		got := matchRunNumber(runs, tt.number)
+		if len(got) != len(tt.wantIDs) {
+			t.Errorf("matchRunNumber(%d) returned %d runs, want %d", tt.number, len(got), len(tt.wantIDs))
+			continue
+		}
+		for i, run := range got {
+			if run.ID != tt.wantIDs[i] {
+				t.Errorf("matchRunNumber(%d)[%d].ID = %d, want %d", tt.number, i, run.ID, tt.wantIDs[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gh
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// NewTransport builds the HTTP transport for GitHub requests. It honors
+// HTTPS_PROXY/NO_PROXY like the default transport, and if caCertFile is set,
+// trusts the certificates in that PEM bundle in addition to the system roots
+// (for TLS-inspecting proxies and enterprise hosts with private CAs).
+// insecureSkipVerify disables certificate verification entirely; it exists
+// only for testing against self-signed servers.
+func NewTransport(caCertFile string, insecureSkipVerify bool) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS12,
+		}
+		return transport, nil
+	}
+	if caCertFile == "" {
+		return transport, nil
+	}
+
+	pem, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", caCertFile)
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return transport, nil
+}
+
+// httpTimeout bounds raw API requests and downloads, including large log and
+// artifact files
+const httpTimeout = 60 * time.Second
+
+// newAPIHTTPClient returns the client for authenticated raw API requests.
+// It doesn't follow redirects, so callers can pass the pre-signed Location
+// to the download client without leaking the token to the storage host.
+func newAPIHTTPClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   httpTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// newDownloadClient returns the client for downloads from pre-signed
+// redirect URLs. It shares the API transport (proxy and CA settings) but
+// never sets an Authorization header.
+func newDownloadClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   httpTimeout,
+	}
+}
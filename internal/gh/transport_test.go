@@ -0,0 +1,118 @@
+package gh
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransportCACert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// Without the server's CA the handshake must fail
+	transport, err := NewTransport("", false)
+	if err != nil {
+		t.Fatalf("NewTransport(\"\") error = %v", err)
+	}
+	if _, err := (&http.Client{Transport: transport}).Get(srv.URL); err == nil {
+		t.Fatal("request to self-signed server succeeded without --ca-cert")
+	}
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err = NewTransport(caFile, false)
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request with CA bundle failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// Missing files and files without certificates are errors
+	notPEM := filepath.Join(dir, "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{filepath.Join(dir, "missing.pem"), notPEM} {
+		if _, err := NewTransport(file, false); err == nil {
+			t.Errorf("NewTransport(%q) should fail", file)
+		}
+	}
+}
+
+func TestFollowDownloadRedirect(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("storage request sent Authorization %q", auth)
+		}
+		_, _ = w.Write([]byte("log data"))
+	}))
+	defer storage.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, storage.URL+"/signed", http.StatusFound)
+	}))
+	defer api.Close()
+
+	c := &Client{
+		apiClient:      newAPIHTTPClient(http.DefaultTransport),
+		downloadClient: newDownloadClient(http.DefaultTransport),
+	}
+
+	// The API client must hand back the 302 rather than follow it with the token
+	req, _ := http.NewRequest("GET", api.URL, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := c.apiClient.Do(req)
+	if err != nil {
+		t.Fatalf("apiClient.Do() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("apiClient status = %d, want 302", resp.StatusCode)
+	}
+
+	dl, err := c.followDownloadRedirect(resp)
+	if err != nil {
+		t.Fatalf("followDownloadRedirect() error = %v", err)
+	}
+	defer func() { _ = dl.Body.Close() }()
+	body, _ := io.ReadAll(dl.Body)
+	if string(body) != "log data" {
+		t.Errorf("downloaded %q, want %q", body, "log data")
+	}
+
+	if _, err := c.followDownloadRedirect(&http.Response{StatusCode: http.StatusOK}); err == nil {
+		t.Error("followDownloadRedirect() of a non-redirect should fail")
+	}
+}
+
+func TestNewTransportInsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	transport, err := NewTransport("", true)
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request to self-signed server failed with verification skipped: %v", err)
+	}
+	_ = resp.Body.Close()
+}
@@ -3,6 +3,7 @@ package gh
 import (
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // RerunWorkflow triggers a rerun of the specified workflow run
@@ -17,6 +18,30 @@ func (c *Client) RerunWorkflow(owner, repo string, runID int64) error {
 	return c.Post(path, nil)
 }
 
+// RerunJob reruns one job of a completed workflow run, along with the jobs
+// that depend on it
+func (c *Client) RerunJob(owner, repo string, jobID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/rerun",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		jobID,
+	)
+
+	return c.Post(path, nil)
+}
+
+// RerunFailedJobs reruns the failed jobs of a completed workflow run, along
+// with the jobs that depend on them
+func (c *Client) RerunFailedJobs(owner, repo string, runID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun-failed-jobs",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		runID,
+	)
+
+	return c.Post(path, nil)
+}
+
 // CancelWorkflow cancels the specified workflow run
 func (c *Client) CancelWorkflow(owner, repo string, runID int64) error {
 	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/cancel",
@@ -43,3 +68,66 @@ func (c *Client) DispatchWorkflow(owner, repo, workflowFile, ref string) error {
 
 	return c.Post(path, payload)
 }
+
+// FetchDispatchedRun finds the run started by dispatching workflowFile on
+// ref at or after since. The dispatch API doesn't return the run, so this
+// picks the newest workflow_dispatch run of that workflow created since
+// then; it returns nil if the run hasn't appeared yet.
+func (c *Client) FetchDispatchedRun(owner, repo, workflowFile, ref string, since time.Time) (*WorkflowRun, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/runs?event=workflow_dispatch&branch=%s&per_page=10",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+		url.PathEscape(workflowFile),
+		url.QueryEscape(ref),
+	)
+
+	var response WorkflowRunsResponse
+	if err := c.Get(path, &response); err != nil {
+		return nil, err
+	}
+
+	return newestRunSince(response.WorkflowRuns, since), nil
+}
+
+// newestRunSince returns the most recently created run created at or after
+// since, or nil if there is none
+func newestRunSince(runs []WorkflowRun, since time.Time) *WorkflowRun {
+	var newest *WorkflowRun
+	for i := range runs {
+		run := &runs[i]
+		if run.CreatedAt.Before(since) {
+			continue
+		}
+		if newest == nil || run.CreatedAt.After(newest.CreatedAt) {
+			newest = run
+		}
+	}
+	return newest
+}
+
+// FetchWorkflows lists the workflows defined in a repository
+func (c *Client) FetchWorkflows(owner, repo string) ([]Workflow, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows?per_page=100",
+		url.PathEscape(owner),
+		url.PathEscape(repo),
+	)
+
+	var response WorkflowsResponse
+	if err := c.Get(path, &response); err != nil {
+		return nil, err
+	}
+	return response.Workflows, nil
+}
+
+// ExplainNoRuns says why no runs were found on branch (a display label such
+// as "main" or "all branches"): a *NoRunsError telling an unconfigured
+// repository from one whose workflows haven't run there. If the workflows
+// can't be listed, it returns ErrNoRuns wrapped with the listing failure,
+// which claims neither.
+func ExplainNoRuns(p Provider, owner, repo, branch string) error {
+	workflows, err := p.FetchWorkflows(owner, repo)
+	if err != nil {
+		return fmt.Errorf("%w (couldn't list workflows to say why: %v)", ErrNoRuns, err)
+	}
+	return &NoRunsError{Repo: owner + "/" + repo, Branch: branch, NoWorkflows: len(workflows) == 0}
+}
@@ -0,0 +1,26 @@
+package gh
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewestRunSince(t *testing.T) {
+	dispatched := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	runs := []WorkflowRun{
+		{ID: 3, CreatedAt: dispatched.Add(2 * time.Second)},
+		{ID: 2, CreatedAt: dispatched.Add(-time.Minute)},
+		{ID: 4, CreatedAt: dispatched.Add(5 * time.Second)},
+		{ID: 1, CreatedAt: dispatched.Add(-time.Hour)},
+	}
+
+	if run := newestRunSince(runs, dispatched); run == nil || run.ID != 4 {
+		t.Errorf("newestRunSince() = %v, want run 4", run)
+	}
+	if run := newestRunSince(runs, dispatched.Add(time.Minute)); run != nil {
+		t.Errorf("newestRunSince() before the run appears = run %d, want nil", run.ID)
+	}
+	if run := newestRunSince(nil, dispatched); run != nil {
+		t.Errorf("newestRunSince(nil) = run %d, want nil", run.ID)
+	}
+}
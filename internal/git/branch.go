@@ -14,6 +14,8 @@ var (
 
 // GetCurrentBranch reads the current branch name from .git/HEAD.
 // Returns ErrDetachedHead if in detached HEAD state.
+// HEAD pointing at a remote-tracking branch (refs/remotes/origin/main) gives
+// the branch name on the remote (main).
 func GetCurrentBranch(gitDir string) (string, error) {
 	headPath := filepath.Join(gitDir, "HEAD")
 
@@ -24,15 +26,25 @@ func GetCurrentBranch(gitDir string) (string, error) {
 
 	content := strings.TrimSpace(string(data))
 
-	// Normal branch reference format: ref: refs/heads/branch-name
-	const refPrefix = "ref: refs/heads/"
-	if strings.HasPrefix(content, refPrefix) {
-		return strings.TrimPrefix(content, refPrefix), nil
+	// Symbolic ref format: ref: refs/heads/branch-name
+	if ref, ok := strings.CutPrefix(content, "ref:"); ok {
+		ref = strings.TrimSpace(ref)
+		if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok && branch != "" {
+			return branch, nil
+		}
+		// refs/remotes/<remote>/<branch>; the remote's own HEAD names no branch
+		if rest, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+			if _, branch, ok := strings.Cut(rest, "/"); ok && branch != "" && branch != "HEAD" {
+				return branch, nil
+			}
+		}
+		// Tags and other namespaces aren't branches
+		return "", ErrDetachedHead
 	}
 
-	// If it's a commit SHA, we're in detached HEAD state
-	// SHA-1 hashes are 40 hex characters
-	if len(content) == 40 && isHexString(content) {
+	// If it's a commit SHA, we're in detached HEAD state.
+	// SHA-1 hashes are 40 hex characters, SHA-256 hashes 64
+	if (len(content) == 40 || len(content) == 64) && isHexString(content) {
 		return "", ErrDetachedHead
 	}
 
@@ -43,6 +43,31 @@ func TestGetCurrentBranch(t *testing.T) {
 			headData: "ABC123DEF456789012345678901234567890ABCD",
 			wantErr:  ErrDetachedHead,
 		},
+		{
+			name:     "detached HEAD (SHA-256)",
+			headData: "3f1c0a9e5b7d2c4f6e8a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e\n",
+			wantErr:  ErrDetachedHead,
+		},
+		{
+			name:       "branch with CRLF",
+			headData:   "ref: refs/heads/main\r\n",
+			wantBranch: "main",
+		},
+		{
+			name:       "remote-tracking branch",
+			headData:   "ref: refs/remotes/origin/release/1.2\n",
+			wantBranch: "release/1.2",
+		},
+		{
+			name:     "remote HEAD",
+			headData: "ref: refs/remotes/origin/HEAD\n",
+			wantErr:  ErrDetachedHead,
+		},
+		{
+			name:     "tag",
+			headData: "ref: refs/tags/v1.0.0\n",
+			wantErr:  ErrDetachedHead,
+		},
 	}
 
 	for _, tt := range tests {
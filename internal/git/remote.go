@@ -41,7 +41,8 @@ func FindGitRoot(startDir string) (string, error) {
 
 // GetRemoteURL reads the git config file and extracts the URL for the
 // remote named "origin". If origin doesn't exist, it returns the first
-// remote URL found.
+// remote URL found. url.<base>.insteadOf rules from the repo's config or
+// the user's global config are applied, as git does.
 func GetRemoteURL(gitDir string) (string, error) {
 	configPath := filepath.Join(gitDir, "config")
 
@@ -51,16 +52,75 @@ func GetRemoteURL(gitDir string) (string, error) {
 	}
 	defer func() { _ = file.Close() }()
 
-	return parseGitConfig(file)
+	url, rewrites, err := parseGitConfig(file)
+	if err != nil {
+		return "", err
+	}
+	return rewriteURL(url, append(rewrites, globalURLRewrites()...)), nil
+}
+
+// urlRewrite is a url.<base>.insteadOf rule: URLs starting with prefix
+// start with base instead
+type urlRewrite struct {
+	base   string
+	prefix string
+}
+
+// rewriteURL applies the insteadOf rule with the longest matching prefix
+func rewriteURL(url string, rewrites []urlRewrite) string {
+	var best *urlRewrite
+	for i, r := range rewrites {
+		if strings.HasPrefix(url, r.prefix) && (best == nil || len(r.prefix) > len(best.prefix)) {
+			best = &rewrites[i]
+		}
+	}
+	if best == nil {
+		return url
+	}
+	return best.base + strings.TrimPrefix(url, best.prefix)
+}
+
+// globalConfigPaths returns the user's global git config files
+func globalConfigPaths() []string {
+	var paths []string
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "git", "config"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if os.Getenv("XDG_CONFIG_HOME") == "" {
+			paths = append(paths, filepath.Join(home, ".config", "git", "config"))
+		}
+		paths = append(paths, filepath.Join(home, ".gitconfig"))
+	}
+	return paths
+}
+
+// globalURLRewrites returns the insteadOf rules from the global git config
+func globalURLRewrites() []urlRewrite {
+	var rewrites []urlRewrite
+	for _, path := range globalConfigPaths() {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		_, fileRewrites, _ := parseGitConfig(file)
+		_ = file.Close()
+		rewrites = append(rewrites, fileRewrites...)
+	}
+	return rewrites
 }
 
-// parseGitConfig parses a git config file and extracts the remote origin URL.
-func parseGitConfig(file *os.File) (string, error) {
+// parseGitConfig parses a git config file and extracts the remote origin
+// URL and any url.<base>.insteadOf rules.
+func parseGitConfig(file *os.File) (string, []urlRewrite, error) {
 	scanner := bufio.NewScanner(file)
 
 	var inRemoteOrigin bool
 	var inAnyRemote bool
 	var firstRemoteURL string
+	var originURL string
+	var rewriteBase string // base URL of the current [url "..."] section
+	var rewrites []urlRewrite
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -69,6 +129,19 @@ func parseGitConfig(file *os.File) (string, error) {
 		if strings.HasPrefix(line, "[") {
 			inRemoteOrigin = line == `[remote "origin"]`
 			inAnyRemote = strings.HasPrefix(line, `[remote "`)
+			rewriteBase = ""
+			if strings.HasPrefix(line, `[url "`) && strings.HasSuffix(line, `"]`) {
+				rewriteBase = strings.TrimSuffix(strings.TrimPrefix(line, `[url "`), `"]`)
+			}
+			continue
+		}
+
+		// Look for insteadOf = ... lines in [url "..."] sections
+		if rewriteBase != "" {
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) == 2 && strings.EqualFold(strings.TrimSpace(parts[0]), "insteadOf") {
+				rewrites = append(rewrites, urlRewrite{base: rewriteBase, prefix: strings.TrimSpace(parts[1])})
+			}
 			continue
 		}
 
@@ -77,8 +150,8 @@ func parseGitConfig(file *os.File) (string, error) {
 			parts := strings.SplitN(line, "=", 2)
 			if len(parts) == 2 {
 				url := strings.TrimSpace(parts[1])
-				if inRemoteOrigin {
-					return url, nil
+				if inRemoteOrigin && originURL == "" {
+					originURL = url
 				}
 				if inAnyRemote && firstRemoteURL == "" {
 					firstRemoteURL = url
@@ -88,28 +161,46 @@ func parseGitConfig(file *os.File) (string, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	// Fall back to first remote if origin not found
-	if firstRemoteURL != "" {
-		return firstRemoteURL, nil
+	switch {
+	case originURL != "":
+		return originURL, rewrites, nil
+	case firstRemoteURL != "":
+		return firstRemoteURL, rewrites, nil
 	}
 
-	return "", ErrNoRemote
+	return "", rewrites, ErrNoRemote
 }
 
-// GetRepoInfo finds the git root and parses the remote URL to get owner/repo.
-func GetRepoInfo(startDir string) (RepoInfo, error) {
-	gitDir, err := FindGitRoot(startDir)
+// GetRepoInfo finds the git root and parses the remote URL to get
+// owner/repo. The remote must be on host (e.g. a GitHub Enterprise host);
+// an empty host means github.com.
+func GetRepoInfo(startDir, host string) (RepoInfo, error) {
+	url, err := repoRemoteURL(startDir)
 	if err != nil {
 		return RepoInfo{}, err
 	}
+	return ParseRemoteURL(url, host)
+}
 
-	url, err := GetRemoteURL(gitDir)
+// GetGitLabRepoInfo is GetRepoInfo for a remote on a GitLab host, whose
+// owner can be a nested group (see ParseGitLabURL)
+func GetGitLabRepoInfo(startDir, host string) (RepoInfo, error) {
+	url, err := repoRemoteURL(startDir)
 	if err != nil {
 		return RepoInfo{}, err
 	}
+	return ParseGitLabURL(url, host)
+}
 
-	return ParseGitHubURL(url)
+// repoRemoteURL finds the git root above startDir and returns its remote URL
+func repoRemoteURL(startDir string) (string, error) {
+	gitDir, err := FindGitRoot(startDir)
+	if err != nil {
+		return "", err
+	}
+	return GetRemoteURL(gitDir)
 }
@@ -169,7 +169,7 @@ func TestGetRepoInfo(t *testing.T) {
 		t.Fatalf("failed to write config: %v", err)
 	}
 
-	info, err := GetRepoInfo(tmpDir)
+	info, err := GetRepoInfo(tmpDir, "")
 	if err != nil {
 		t.Fatalf("GetRepoInfo() error: %v", err)
 	}
@@ -181,3 +181,87 @@ func TestGetRepoInfo(t *testing.T) {
 		t.Errorf("Repo = %q, want %q", info.Repo, "testrepo")
 	}
 }
+
+func TestGetRemoteURLInsteadOf(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	globalConfig := `[url "git@github.com:"]
+	insteadOf = gh:
+[url "git@github.mycorp.com:"]
+	insteadOf = corp:
+`
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(globalConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		configData string
+		wantURL    string
+	}{
+		{
+			name:       "global rule",
+			configData: "[remote \"origin\"]\n\turl = gh:owner/repo.git\n",
+			wantURL:    "git@github.com:owner/repo.git",
+		},
+		{
+			name: "repo rule with the longest prefix wins",
+			configData: `[remote "origin"]
+	url = corp:team/service
+[url "https://github.mycorp.com/team/"]
+	insteadOf = corp:team/
+`,
+			wantURL: "https://github.mycorp.com/team/service",
+		},
+		{
+			name:       "no matching rule",
+			configData: "[remote \"origin\"]\n\turl = https://github.com/owner/repo\n",
+			wantURL:    "https://github.com/owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitDir := filepath.Join(t.TempDir(), ".git")
+			if err := os.Mkdir(gitDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(tt.configData), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := GetRemoteURL(gitDir)
+			if err != nil {
+				t.Fatalf("GetRemoteURL() error: %v", err)
+			}
+			if got != tt.wantURL {
+				t.Errorf("GetRemoteURL() = %q, want %q", got, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestGetRepoInfoEnterpriseHost(t *testing.T) {
+	tmpDir := t.TempDir()
+	gitDir := filepath.Join(tmpDir, ".git")
+	if err := os.Mkdir(gitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configData := "[remote \"origin\"]\n\turl = https://github.mycorp.com/team/service.git\n"
+	if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(configData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := GetRepoInfo(tmpDir, "github.mycorp.com")
+	if err != nil {
+		t.Fatalf("GetRepoInfo() error: %v", err)
+	}
+	if info.Owner != "team" || info.Repo != "service" {
+		t.Errorf("GetRepoInfo() = %s/%s, want team/service", info.Owner, info.Repo)
+	}
+
+	if _, err := GetRepoInfo(tmpDir, ""); err == nil {
+		t.Error("GetRepoInfo() matched an enterprise remote against github.com")
+	}
+}
@@ -12,37 +12,72 @@ type RepoInfo struct {
 	Repo  string
 }
 
-var (
-	// ErrInvalidURL is returned when the URL cannot be parsed
-	ErrInvalidURL = errors.New("invalid GitHub URL")
+// DefaultHost is the host remotes are matched against unless another
+// (GitHub Enterprise) host is configured
+const DefaultHost = "github.com"
 
-	// SSH format: git@github.com:owner/repo.git
-	sshPattern = regexp.MustCompile(`^git@github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
+// ErrInvalidURL is returned when the URL cannot be parsed
+var ErrInvalidURL = errors.New("invalid GitHub URL")
 
-	// HTTPS format: https://github.com/owner/repo.git or https://github.com/owner/repo
-	httpsPattern = regexp.MustCompile(`^https?://github\.com/([^/]+)/([^/]+?)(?:\.git)?/?$`)
-)
+// remotePatterns matches the remote URL forms git accepts for a host
+type remotePatterns struct {
+	ssh    *regexp.Regexp // SCP-like: git@host:owner/repo.git
+	sshURL *regexp.Regexp // ssh://git@host[:port]/owner/repo.git
+	https  *regexp.Regexp // https://[user@]host[:port]/owner/repo[.git][/]
+}
+
+// patternsForHost builds the remote URL patterns for host. With nested, the
+// owner is every path segment before the repo, for GitLab's subgroups.
+func patternsForHost(host string, nested bool) remotePatterns {
+	h := `(?i:` + regexp.QuoteMeta(host) + `)`
+	owner := `([^/]+)`
+	if nested {
+		owner = `((?:[^/]+/)*[^/]+)`
+	}
+	path := `/` + owner + `/([^/]+?)(?:\.git)?/?$`
+	return remotePatterns{
+		ssh:    regexp.MustCompile(`^[\w.-]+@` + h + `:/?` + owner + `/([^/]+?)(?:\.git)?$`),
+		sshURL: regexp.MustCompile(`^ssh://(?:[^@/]+@)?` + h + `(?::\d+)?` + path),
+		https:  regexp.MustCompile(`^https?://(?:[^@/]+@)?` + h + `(?::\d+)?` + path),
+	}
+}
+
+var defaultPatterns = patternsForHost(DefaultHost, false)
 
-// ParseGitHubURL extracts owner and repo from a GitHub remote URL.
+// ParseGitHubURL extracts owner and repo from a github.com remote URL.
 // Supports both SSH (git@github.com:owner/repo.git) and HTTPS
 // (https://github.com/owner/repo.git or https://github.com/owner/repo) formats.
 func ParseGitHubURL(url string) (RepoInfo, error) {
-	url = strings.TrimSpace(url)
+	return parseRemoteURL(url, defaultPatterns)
+}
 
-	// Try SSH format first
-	if matches := sshPattern.FindStringSubmatch(url); matches != nil {
-		return RepoInfo{
-			Owner: matches[1],
-			Repo:  matches[2],
-		}, nil
+// ParseRemoteURL extracts owner and repo from a remote URL on host, e.g. a
+// GitHub Enterprise host like github.mycorp.com. An empty host means
+// github.com.
+func ParseRemoteURL(url, host string) (RepoInfo, error) {
+	if host == "" || strings.EqualFold(host, DefaultHost) {
+		return parseRemoteURL(url, defaultPatterns)
 	}
+	return parseRemoteURL(url, patternsForHost(host, false))
+}
+
+// ParseGitLabURL extracts the namespace and project from a remote URL on a
+// GitLab host. The namespace can be a nested group: a remote for
+// group/subgroup/project has owner group/subgroup.
+func ParseGitLabURL(url, host string) (RepoInfo, error) {
+	return parseRemoteURL(url, patternsForHost(host, true))
+}
+
+func parseRemoteURL(url string, patterns remotePatterns) (RepoInfo, error) {
+	url = strings.TrimSpace(url)
 
-	// Try HTTPS format
-	if matches := httpsPattern.FindStringSubmatch(url); matches != nil {
-		return RepoInfo{
-			Owner: matches[1],
-			Repo:  matches[2],
-		}, nil
+	for _, pattern := range []*regexp.Regexp{patterns.ssh, patterns.sshURL, patterns.https} {
+		if matches := pattern.FindStringSubmatch(url); matches != nil {
+			return RepoInfo{
+				Owner: matches[1],
+				Repo:  matches[2],
+			}, nil
+		}
 	}
 
 	return RepoInfo{}, ErrInvalidURL
@@ -126,3 +126,86 @@ func TestParseGitHubURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRemoteURL(t *testing.T) {
+	const host = "github.mycorp.com"
+	tests := []struct {
+		name      string
+		url       string
+		host      string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{name: "enterprise SSH", url: "git@github.mycorp.com:team/service.git", host: host, wantOwner: "team", wantRepo: "service"},
+		{name: "enterprise HTTPS", url: "https://github.mycorp.com/team/service", host: host, wantOwner: "team", wantRepo: "service"},
+		{name: "enterprise ssh:// with port", url: "ssh://git@github.mycorp.com:2222/team/service.git", host: host, wantOwner: "team", wantRepo: "service"},
+		{name: "enterprise HTTPS with user", url: "https://ci@github.mycorp.com/team/service.git", host: host, wantOwner: "team", wantRepo: "service"},
+		{name: "host case-insensitive", url: "git@GitHub.MyCorp.com:team/service.git", host: host, wantOwner: "team", wantRepo: "service"},
+		{name: "github.com remote with enterprise host", url: "git@github.com:owner/repo.git", host: host, wantErr: true},
+		{name: "host prefix of another host", url: "https://github.mycorp.com.evil.io/team/service", host: host, wantErr: true},
+		{name: "empty host means github.com", url: "ssh://git@github.com/owner/repo.git", wantOwner: "owner", wantRepo: "repo"},
+		{name: "host glued to owner", url: "https://github.comowner/repo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRemoteURL(tt.url, tt.host)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRemoteURL(%q, %q) = %+v, want error", tt.url, tt.host, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRemoteURL(%q, %q) error: %v", tt.url, tt.host, err)
+			}
+			if got.Owner != tt.wantOwner || got.Repo != tt.wantRepo {
+				t.Errorf("ParseRemoteURL(%q, %q) = %s/%s, want %s/%s", tt.url, tt.host, got.Owner, got.Repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestParseGitLabURL(t *testing.T) {
+	const host = "gitlab.com"
+	tests := []struct {
+		name      string
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{name: "SSH", url: "git@gitlab.com:group/project.git", wantOwner: "group", wantRepo: "project"},
+		{name: "SSH subgroup", url: "git@gitlab.com:group/sub/project.git", wantOwner: "group/sub", wantRepo: "project"},
+		{name: "HTTPS nested subgroups", url: "https://gitlab.com/group/sub/team/project.git", wantOwner: "group/sub/team", wantRepo: "project"},
+		{name: "HTTPS subgroup trailing slash", url: "https://gitlab.com/group/sub/project/", wantOwner: "group/sub", wantRepo: "project"},
+		{name: "ssh:// subgroup with port", url: "ssh://git@gitlab.com:2222/group/sub/project.git", wantOwner: "group/sub", wantRepo: "project"},
+		{name: "no namespace", url: "https://gitlab.com/project", wantErr: true},
+		{name: "empty segment", url: "https://gitlab.com/group//project", wantErr: true},
+		{name: "other host", url: "git@github.com:group/sub/project.git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGitLabURL(tt.url, host)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseGitLabURL(%q) = %+v, want error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseGitLabURL(%q) error: %v", tt.url, err)
+			}
+			if got.Owner != tt.wantOwner || got.Repo != tt.wantRepo {
+				t.Errorf("ParseGitLabURL(%q) = %s/%s, want %s/%s", tt.url, got.Owner, got.Repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+
+	// GitHub remotes stay owner/repo
+	if _, err := ParseRemoteURL("https://github.com/group/sub/project", ""); err == nil {
+		t.Error("ParseRemoteURL() accepted a nested path on github.com")
+	}
+}
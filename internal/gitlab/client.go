@@ -0,0 +1,185 @@
+// Package gitlab is a gh.Provider for GitLab CI. It maps pipelines onto
+// workflow runs and pipeline jobs onto jobs, so the TUI and CLI work the
+// same way against a GitLab project as against a GitHub repository.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+const (
+	// DefaultHost is the GitLab host used unless GITLAB_HOST is set
+	DefaultHost = "gitlab.com"
+
+	// EnvHost names a self-managed GitLab host, e.g. gitlab.mycorp.com
+	EnvHost = "GITLAB_HOST"
+
+	// EnvToken holds a personal, project or CI job access token
+	EnvToken = "GITLAB_TOKEN"
+)
+
+// Host returns the GitLab host: GITLAB_HOST if set, otherwise gitlab.com
+func Host() string {
+	if host := os.Getenv(EnvHost); host != "" {
+		return host
+	}
+	return DefaultHost
+}
+
+// Client talks to the GitLab REST API (v4)
+type Client struct {
+	baseURL string // API root, e.g. https://gitlab.com/api/v4
+	token   string
+	retry   gh.RetryConfig
+	http    *http.Client
+
+	maxLogBytes int64 // Cap on trace bytes read into memory; 0 = no limit
+}
+
+// ClientOptions configures a Client
+type ClientOptions struct {
+	Retry      gh.RetryConfig // Retry policy for API requests
+	CACertFile string         // Optional PEM bundle of extra trusted CAs
+
+	// Skip TLS certificate verification (testing against self-signed servers only)
+	InsecureSkipVerify bool
+
+	// Transport replaces the transport built from CACertFile/InsecureSkipVerify
+	Transport http.RoundTripper
+	// BaseURL is the API root; "" means https://<Host()>/api/v4
+	BaseURL string
+	// Token is used instead of GITLAB_TOKEN; public projects need none
+	Token string
+	// MaxLogBytes caps how much of a job trace is read into memory; longer
+	// traces are truncated with a notice. 0 means no limit.
+	MaxLogBytes int64
+}
+
+var _ gh.Provider = (*Client)(nil)
+
+// NewClient creates a GitLab API client
+func NewClient(options ClientOptions) (*Client, error) {
+	transport := options.Transport
+	if transport == nil {
+		t, err := gh.NewTransport(options.CACertFile, options.InsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		transport = t
+	}
+
+	baseURL := strings.TrimSuffix(options.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://" + Host() + "/api/v4"
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid GitLab API URL %q: %w", baseURL, err)
+	}
+
+	token := options.Token
+	if token == "" {
+		token = os.Getenv(EnvToken)
+	}
+
+	return &Client{
+		baseURL: baseURL,
+		token:   token,
+		retry:   options.Retry,
+		http:    &http.Client{Transport: transport, Timeout: 30 * time.Second},
+
+		maxLogBytes: options.MaxLogBytes,
+	}, nil
+}
+
+// projectPath returns the API path of a project, addressed by its
+// URL-encoded "namespace/name" path
+func projectPath(owner, repo string) string {
+	return "projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+// get fetches path and decodes the JSON response into response
+func (c *Client) get(ctx context.Context, path string, response interface{}) error {
+	body, err := c.do(ctx, http.MethodGet, path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("decoding GitLab response: %w", err)
+	}
+	return nil
+}
+
+// do sends a request, retrying transient failures, and returns the body of
+// a successful response
+func (c *Client) do(ctx context.Context, method, path string) ([]byte, error) {
+	body, _, err := c.doLimited(ctx, method, path, 0)
+	return body, err
+}
+
+// doLimited sends a request like do, reading at most limit bytes of the
+// response if limit > 0, and reports whether the body was cut off
+func (c *Client) doLimited(ctx context.Context, method, path string, limit int64) ([]byte, bool, error) {
+	var body []byte
+	var truncated bool
+	err := gh.RetryWithBackoffContext(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/"+path, nil)
+		if err != nil {
+			return err
+		}
+		if c.token != "" {
+			req.Header.Set("PRIVATE-TOKEN", c.token)
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		body, truncated, err = gh.ReadLimited(resp.Body, limit)
+		if err != nil {
+			return err
+		}
+		return statusError(resp.StatusCode, path, body)
+	}, c.retry)
+	return body, truncated, err
+}
+
+// statusError converts an unsuccessful response into the errors the gh
+// package uses, so callers handle both providers alike
+func statusError(status int, path string, body []byte) error {
+	if status < 300 {
+		return nil
+	}
+
+	var apiErr struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+	}
+	_ = json.Unmarshal(body, &apiErr)
+	message := apiErr.Error
+	if apiErr.Message != nil {
+		message = fmt.Sprint(apiErr.Message)
+	}
+	err := fmt.Errorf("GitLab API %s: HTTP %d: %s", path, status, message)
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return fmt.Errorf("GitLab authentication failed: %w\nSet %s to a token with the read_api scope", err, EnvToken)
+	case status == http.StatusNotFound:
+		return &gh.NotFoundError{Resource: "GitLab project or pipeline", Err: err}
+	case status == http.StatusTooManyRequests || status >= 500:
+		return &gh.RetryableError{Err: err, Retryable: true}
+	default:
+		return err
+	}
+}
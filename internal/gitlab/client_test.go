@@ -0,0 +1,194 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+// newTestClient returns a Client that sends every request to srv
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	c, err := NewClient(ClientOptions{
+		Retry:     gh.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		Transport: srv.Client().Transport,
+		BaseURL:   srv.URL,
+		Token:     "test-token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestClientFetchWorkflowRuns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); got != "/projects/acme%2Fweb/pipelines" {
+			t.Errorf("path = %q", got)
+		}
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "test-token" {
+			t.Errorf("PRIVATE-TOKEN = %q, want the test token", got)
+		}
+		query := r.URL.Query()
+		for key, want := range map[string]string{"ref": "main", "status": "failed", "source": "merge_request_event", "per_page": "5"} {
+			if got := query.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+		fmt.Fprint(w, `[{"id": 901, "iid": 12, "status": "failed", "source": "merge_request_event", "ref": "main",
+			"web_url": "https://gitlab.com/acme/web/-/pipelines/901", "created_at": "2026-01-02T10:00:00Z",
+			"user": {"username": "dev"}}]`)
+	}))
+	defer srv.Close()
+
+	runs, err := newTestClient(t, srv).FetchWorkflowRuns("acme", "web", "main", gh.ConclusionFailure, "pull_request", "", 1, 5)
+	if err != nil {
+		t.Fatalf("FetchWorkflowRuns() error = %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(runs))
+	}
+	run := runs[0]
+	if run.ID != 901 || run.RunNumber != 12 || run.HeadBranch != "main" || run.Actor == nil || run.Actor.Login != "dev" {
+		t.Errorf("run = %+v", run)
+	}
+	if run.Status != gh.StatusCompleted || run.Conclusion == nil || *run.Conclusion != gh.ConclusionFailure {
+		t.Errorf("status = %s/%v, want completed/failure", run.Status, run.Conclusion)
+	}
+}
+
+func TestClientFetchJobs(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page != "1" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		// A full first page means another page is requested
+		jobs := make([]string, jobsPerPage)
+		for i := range jobs {
+			jobs[i] = fmt.Sprintf(`{"id": %d, "name": "unit", "stage": "test", "status": "running", "runner": {"description": "shared-1"}}`, i+1)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(jobs, ","))
+	}))
+	defer srv.Close()
+
+	jobs, err := newTestClient(t, srv).FetchJobs("acme", "web", 901)
+	if err != nil {
+		t.Fatalf("FetchJobs() error = %v", err)
+	}
+	if len(jobs) != jobsPerPage || len(pages) != 2 {
+		t.Fatalf("got %d jobs over pages %v", len(jobs), pages)
+	}
+	if jobs[0].Name != "test: unit" || jobs[0].Status != gh.StatusInProgress || jobs[0].RunnerName != "shared-1" {
+		t.Errorf("jobs[0] = %+v", jobs[0])
+	}
+}
+
+func TestClientFetchJobLogsSince(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/jobs/7/trace") {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		fmt.Fprint(w, "line 1\nline 2\n")
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	chunk, err := c.FetchJobLogsSince("acme", "web", 7, 7)
+	if err != nil {
+		t.Fatalf("FetchJobLogsSince() error = %v", err)
+	}
+	if !chunk.Appended || chunk.Content != "line 2\n" || chunk.Size != 14 {
+		t.Errorf("chunk = %+v, want the appended second line", chunk)
+	}
+
+	// An offset past the end means the log was replaced
+	chunk, err = c.FetchJobLogsSince("acme", "web", 7, 100)
+	if err != nil {
+		t.Fatalf("FetchJobLogsSince() error = %v", err)
+	}
+	if chunk.Appended || chunk.Content != "line 1\nline 2\n" {
+		t.Errorf("chunk = %+v, want the whole log", chunk)
+	}
+}
+
+func TestClientFetchJobLogsLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	c.maxLogBytes = 10
+
+	// A trace over --max-log-bytes is cut off with a notice
+	logs, err := c.FetchJobLogs("acme", "web", 7, true)
+	if err != nil {
+		t.Fatalf("FetchJobLogs() error = %v", err)
+	}
+	if !strings.HasPrefix(logs, strings.Repeat("x", 10)+"\n") || !strings.Contains(logs, "truncated") {
+		t.Errorf("logs = %q, want 10 bytes and a truncation notice", logs)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Project Not Found"}`)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	_, err := c.FetchRun("acme", "missing", 1)
+	var notFound *gh.NotFoundError
+	if !errors.As(err, &notFound) || !strings.Contains(err.Error(), "404 Project Not Found") {
+		t.Errorf("FetchRun() error = %v, want a not-found error", err)
+	}
+
+	if err := c.DispatchWorkflow("acme", "web", ciConfigPath, "main"); !errors.Is(err, gh.ErrUnsupported) {
+		t.Errorf("DispatchWorkflow() error = %v, want ErrUnsupported", err)
+	}
+	if _, err := c.FetchWorkflowArtifacts("acme", "web", 1); !errors.Is(err, gh.ErrUnsupported) {
+		t.Errorf("FetchWorkflowArtifacts() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClientFetchWorkflows(t *testing.T) {
+	hasConfig := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.EscapedPath() == "/projects/acme%2Fweb":
+			fmt.Fprint(w, `{"name": "web", "default_branch": "main", "ci_config_path": ""}`)
+		case r.Method == http.MethodHead && r.URL.EscapedPath() == "/projects/acme%2Fweb/repository/files/.gitlab-ci.yml":
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("ref = %q, want main", r.URL.Query().Get("ref"))
+			}
+			if !hasConfig {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	workflows, err := c.FetchWorkflows("acme", "web")
+	if err != nil || len(workflows) != 0 {
+		t.Errorf("without .gitlab-ci.yml: FetchWorkflows() = %v, %v; want none", workflows, err)
+	}
+
+	hasConfig = true
+	workflows, err = c.FetchWorkflows("acme", "web")
+	if err != nil || len(workflows) != 1 || workflows[0].Path != ciConfigPath {
+		t.Errorf("with .gitlab-ci.yml: FetchWorkflows() = %v, %v; want the config file", workflows, err)
+	}
+}
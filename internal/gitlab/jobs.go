@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+// jobsPerPage is the page size for listing a pipeline's jobs (GitLab's maximum)
+const jobsPerPage = 100
+
+// FetchJobs fetches the jobs of a pipeline, leaving out retried attempts
+func (c *Client) FetchJobs(owner, repo string, runID int64) ([]gh.Job, error) {
+	var jobs []gh.Job
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("%s/pipelines/%d/jobs?per_page=%d&page=%d", projectPath(owner, repo), runID, jobsPerPage, page)
+		var batch []job
+		if err := c.get(context.Background(), path, &batch); err != nil {
+			return nil, err
+		}
+		for i := range batch {
+			jobs = append(jobs, batch[i].toJob())
+		}
+		if len(batch) < jobsPerPage {
+			return jobs, nil
+		}
+	}
+}
+
+// FetchJobDetails fetches a single job
+func (c *Client) FetchJobDetails(owner, repo string, jobID int64) (*gh.Job, error) {
+	var j job
+	if err := c.get(context.Background(), fmt.Sprintf("%s/jobs/%d", projectPath(owner, repo), jobID), &j); err != nil {
+		return nil, err
+	}
+	out := j.toJob()
+	return &out, nil
+}
+
+// FetchJobLogs fetches a job's log (its trace), up to the log size limit
+func (c *Client) FetchJobLogs(owner, repo string, jobID int64, completed bool) (string, error) {
+	path := fmt.Sprintf("%s/jobs/%d/trace", projectPath(owner, repo), jobID)
+	body, truncated, err := c.doLimited(context.Background(), http.MethodGet, path, c.maxLogBytes)
+	if err != nil {
+		return "", err
+	}
+	if truncated {
+		return string(body) + gh.LogTruncatedNotice(c.maxLogBytes), nil
+	}
+	return string(body), nil
+}
+
+// FetchJobLogsWithProgress fetches a job's log like FetchJobLogs. Traces
+// come back in one response, so progress is reported once, when done.
+func (c *Client) FetchJobLogsWithProgress(owner, repo string, jobID int64, completed bool, progress gh.ProgressFunc) (string, error) {
+	logs, err := c.FetchJobLogs(owner, repo, jobID, completed)
+	if err == nil && progress != nil {
+		progress(int64(len(logs)), int64(len(logs)))
+	}
+	return logs, err
+}
+
+// FetchJobLogsStructured fetches a job's log. GitLab jobs have no steps,
+// so the log is only available combined.
+func (c *Client) FetchJobLogsStructured(owner, repo string, jobID int64, completed bool) (*gh.ParsedLogs, error) {
+	logs, err := c.FetchJobLogs(owner, repo, jobID, completed)
+	if err != nil {
+		return nil, err
+	}
+	return &gh.ParsedLogs{StepsByKey: map[string]string{}, Combined: logs}, nil
+}
+
+// FetchJobLogsSince returns the part of a job's log after offset. GitLab
+// serves the whole trace each time, so the new part is cut out here.
+func (c *Client) FetchJobLogsSince(owner, repo string, jobID, offset int64) (*gh.LogChunk, error) {
+	logs, err := c.FetchJobLogs(owner, repo, jobID, false)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(len(logs))
+	if offset > 0 && offset <= size {
+		return &gh.LogChunk{Content: logs[offset:], Size: size, Appended: true}, nil
+	}
+	return &gh.LogChunk{Content: logs, Size: size}, nil
+}
@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+// ciConfigPath is the pipeline definition every GitLab project uses
+const ciConfigPath = ".gitlab-ci.yml"
+
+// pipeline is a GitLab CI pipeline
+type pipeline struct {
+	ID         int64      `json:"id"`
+	IID        int        `json:"iid"` // Per-project pipeline number
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	Source     string     `json:"source"` // push, merge_request_event, schedule, web, ...
+	Ref        string     `json:"ref"`
+	WebURL     string     `json:"web_url"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Duration   float64    `json:"duration"` // Seconds; only on single-pipeline responses
+	User       *user      `json:"user"`
+}
+
+// job is a job in a GitLab CI pipeline
+type job struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Stage      string     `json:"stage"`
+	Status     string     `json:"status"`
+	WebURL     string     `json:"web_url"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Runner     *runner    `json:"runner"`
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type runner struct {
+	Description string `json:"description"`
+}
+
+// project is the part of a GitLab project cimon needs
+type project struct {
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+	CIConfigPath      string `json:"ci_config_path"` // "" means .gitlab-ci.yml
+}
+
+// branch is a GitLab repository branch
+type branch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+	Commit    struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// mapStatus converts a GitLab pipeline or job status into a GitHub-style
+// status and conclusion
+func mapStatus(status string) (string, *string) {
+	conclusion := func(c string) *string { return &c }
+	switch status {
+	case "running":
+		return gh.StatusInProgress, nil
+	case "manual":
+		// Blocked until someone starts a manual job
+		return gh.StatusWaiting, nil
+	case "success":
+		return gh.StatusCompleted, conclusion(gh.ConclusionSuccess)
+	case "failed":
+		return gh.StatusCompleted, conclusion(gh.ConclusionFailure)
+	case "canceled", "canceling":
+		return gh.StatusCompleted, conclusion(gh.ConclusionCancelled)
+	case "skipped":
+		return gh.StatusCompleted, conclusion(gh.ConclusionSkipped)
+	default:
+		// created, waiting_for_resource, preparing, pending, scheduled
+		return gh.StatusQueued, nil
+	}
+}
+
+// toRun maps a pipeline onto a workflow run
+func (p *pipeline) toRun() gh.WorkflowRun {
+	status, conclusion := mapStatus(p.Status)
+	run := gh.WorkflowRun{
+		ID:           p.ID,
+		Name:         p.Name,
+		Path:         ciConfigPath,
+		RunNumber:    p.IID,
+		Status:       status,
+		Conclusion:   conclusion,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		RunStartedAt: p.StartedAt,
+		HTMLURL:      p.WebURL,
+		Event:        p.Source,
+		HeadBranch:   p.Ref,
+	}
+	if p.User != nil {
+		run.Actor = &gh.User{Login: p.User.Username}
+	}
+	return run
+}
+
+// toJob maps a pipeline job onto a job. GitLab jobs have no steps.
+func (j *job) toJob() gh.Job {
+	status, conclusion := mapStatus(j.Status)
+	out := gh.Job{
+		ID:          j.ID,
+		Name:        j.Name,
+		Status:      status,
+		Conclusion:  conclusion,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.FinishedAt,
+		HTMLURL:     j.WebURL,
+	}
+	if j.Stage != "" {
+		out.Name = j.Stage + ": " + j.Name
+	}
+	if j.Runner != nil {
+		out.RunnerName = j.Runner.Description
+	}
+	return out
+}
+
+// statusQuery maps a GitHub run status filter onto GitLab pipeline list
+// parameters
+func statusQuery(status string) (key, value string) {
+	switch status {
+	case "":
+		return "", ""
+	case gh.ConclusionSuccess:
+		return "status", "success"
+	case gh.ConclusionFailure:
+		return "status", "failed"
+	case gh.StatusInProgress:
+		return "status", "running"
+	case gh.StatusQueued:
+		return "status", "pending"
+	case gh.StatusCompleted:
+		return "scope", "finished"
+	default:
+		return "status", status
+	}
+}
+
+// eventSource maps a GitHub event filter onto a GitLab pipeline source
+func eventSource(event string) string {
+	switch event {
+	case "pull_request":
+		return "merge_request_event"
+	case "workflow_dispatch":
+		return "web"
+	default:
+		return event
+	}
+}
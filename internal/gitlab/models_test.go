@@ -0,0 +1,60 @@
+package gitlab
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+func TestMapStatus(t *testing.T) {
+	tests := []struct {
+		status, wantStatus, wantConclusion string
+	}{
+		{"running", gh.StatusInProgress, ""},
+		{"manual", gh.StatusWaiting, ""},
+		{"pending", gh.StatusQueued, ""},
+		{"success", gh.StatusCompleted, gh.ConclusionSuccess},
+		{"failed", gh.StatusCompleted, gh.ConclusionFailure},
+		{"canceled", gh.StatusCompleted, gh.ConclusionCancelled},
+		{"skipped", gh.StatusCompleted, gh.ConclusionSkipped},
+	}
+	for _, tt := range tests {
+		status, conclusion := mapStatus(tt.status)
+		got := ""
+		if conclusion != nil {
+			got = *conclusion
+		}
+		if status != tt.wantStatus || got != tt.wantConclusion {
+			t.Errorf("mapStatus(%q) = %s/%q, want %s/%q", tt.status, status, got, tt.wantStatus, tt.wantConclusion)
+		}
+	}
+}
+
+func TestParseCreated(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	next := day.Add(24 * time.Hour)
+	stamp := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	tests := []struct {
+		created              string
+		wantSince, wantUntil time.Time
+		wantErr              bool
+	}{
+		{"", time.Time{}, time.Time{}, false},
+		{">=" + stamp(day), day, time.Time{}, false},
+		{"<=" + stamp(next), time.Time{}, next, false},
+		{stamp(day) + ".." + stamp(next), day, next, false},
+		{"yesterday", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		since, until, err := parseCreated(tt.created)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCreated(%q) error = %v, wantErr %v", tt.created, err, tt.wantErr)
+			continue
+		}
+		if !since.Equal(tt.wantSince) || !until.Equal(tt.wantUntil) {
+			t.Errorf("parseCreated(%q) = %v, %v", tt.created, since, until)
+		}
+	}
+}
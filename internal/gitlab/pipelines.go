@@ -0,0 +1,233 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+// maxRunLookupPages bounds how far back FindRunByNumber searches
+const maxRunLookupPages = 10
+
+// FetchWorkflowRuns lists a project's pipelines, newest first, as runs.
+// status and event take GitHub's values and are mapped onto GitLab's
+// statuses and pipeline sources; created is a GitHub date qualifier.
+func (c *Client) FetchWorkflowRuns(owner, repo, branch, status, event, created string, page, perPage int) ([]gh.WorkflowRun, error) {
+	return c.FetchWorkflowRunsContext(context.Background(), owner, repo, branch, status, event, created, page, perPage)
+}
+
+// FetchWorkflowRunsContext lists pipelines like FetchWorkflowRuns, giving
+// up when ctx is done
+func (c *Client) FetchWorkflowRunsContext(ctx context.Context, owner, repo, branch, status, event, created string, page, perPage int) ([]gh.WorkflowRun, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	query.Set("order_by", "id")
+	query.Set("sort", "desc")
+	if branch != "" {
+		query.Set("ref", branch)
+	}
+	if key, value := statusQuery(status); key != "" {
+		query.Set(key, value)
+	}
+	if event != "" {
+		query.Set("source", eventSource(event))
+	}
+
+	// GitLab filters pipelines by update time, and anything created in the
+	// window was updated after its start, so that narrows the list and the
+	// creation times are checked here
+	since, until, err := parseCreated(created)
+	if err != nil {
+		return nil, err
+	}
+	if !since.IsZero() {
+		query.Set("updated_after", since.UTC().Format(time.RFC3339))
+	}
+
+	var pipelines []pipeline
+	if err := c.get(ctx, projectPath(owner, repo)+"/pipelines?"+query.Encode(), &pipelines); err != nil {
+		return nil, err
+	}
+
+	runs := make([]gh.WorkflowRun, 0, len(pipelines))
+	for i := range pipelines {
+		createdAt := pipelines[i].CreatedAt
+		if (!since.IsZero() && createdAt.Before(since)) || (!until.IsZero() && createdAt.After(until)) {
+			continue
+		}
+		runs = append(runs, pipelines[i].toRun())
+	}
+	return runs, nil
+}
+
+// parseCreated parses the GitHub created qualifiers config.CreatedFilter
+// produces: ">=since", "<=until" or "since..until"
+func parseCreated(created string) (since, until time.Time, err error) {
+	parse := func(value string) (time.Time, error) {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid created filter %q: %w", created, err)
+		}
+		return t, nil
+	}
+
+	switch {
+	case created == "":
+		return since, until, nil
+	case strings.HasPrefix(created, ">="):
+		since, err = parse(strings.TrimPrefix(created, ">="))
+	case strings.HasPrefix(created, "<="):
+		until, err = parse(strings.TrimPrefix(created, "<="))
+	default:
+		from, to, ok := strings.Cut(created, "..")
+		if !ok {
+			return since, until, fmt.Errorf("invalid created filter %q", created)
+		}
+		if since, err = parse(from); err == nil {
+			until, err = parse(to)
+		}
+	}
+	return since, until, err
+}
+
+// FetchLatestRun fetches the newest pipeline on a branch.
+// Returns gh.ErrNoRuns if there are none.
+func (c *Client) FetchLatestRun(owner, repo, branch, event, created string) (*gh.WorkflowRun, error) {
+	runs, err := c.FetchWorkflowRuns(owner, repo, branch, "", event, created, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, gh.ErrNoRuns
+	}
+	return &runs[0], nil
+}
+
+// FetchRun fetches a pipeline by ID
+func (c *Client) FetchRun(owner, repo string, runID int64) (*gh.WorkflowRun, error) {
+	var p pipeline
+	if err := c.get(context.Background(), fmt.Sprintf("%s/pipelines/%d", projectPath(owner, repo), runID), &p); err != nil {
+		return nil, err
+	}
+	run := p.toRun()
+	return &run, nil
+}
+
+// FindRunByNumber finds a pipeline by its per-project number (IID),
+// searching the most recent pipelines.
+// Returns gh.ErrNoRuns if no pipeline has that number.
+func (c *Client) FindRunByNumber(owner, repo string, number int) (*gh.WorkflowRun, error) {
+	const perPage = 100
+	for page := 1; page <= maxRunLookupPages; page++ {
+		runs, err := c.FetchWorkflowRuns(owner, repo, "", "", "", "", page, perPage)
+		if err != nil {
+			return nil, err
+		}
+		for i := range runs {
+			if runs[i].RunNumber == number {
+				return &runs[i], nil
+			}
+		}
+		if len(runs) < perPage {
+			break
+		}
+	}
+	return nil, gh.ErrNoRuns
+}
+
+// FetchRunTiming returns the pipeline's duration. GitLab doesn't report
+// billable time per runner OS, so Billable is empty.
+func (c *Client) FetchRunTiming(owner, repo string, runID int64) (*gh.RunTiming, error) {
+	var p pipeline
+	if err := c.get(context.Background(), fmt.Sprintf("%s/pipelines/%d", projectPath(owner, repo), runID), &p); err != nil {
+		return nil, err
+	}
+	return &gh.RunTiming{RunDurationMS: int64(p.Duration * 1000)}, nil
+}
+
+// GetRepository fetches the project's name and default branch
+func (c *Client) GetRepository(owner, repo string) (*gh.Repository, error) {
+	var p project
+	if err := c.get(context.Background(), projectPath(owner, repo), &p); err != nil {
+		return nil, err
+	}
+	return &gh.Repository{Name: p.Name, FullName: p.PathWithNamespace, DefaultBranch: p.DefaultBranch}, nil
+}
+
+// FetchBranches fetches the project's branches
+func (c *Client) FetchBranches(owner, repo string) ([]gh.Branch, error) {
+	var branches []branch
+	if err := c.get(context.Background(), projectPath(owner, repo)+"/repository/branches?per_page=100", &branches); err != nil {
+		return nil, err
+	}
+	result := make([]gh.Branch, 0, len(branches))
+	for _, b := range branches {
+		result = append(result, gh.Branch{Name: b.Name, Commit: gh.Commit{SHA: b.Commit.ID}, Protected: b.Protected})
+	}
+	return result, nil
+}
+
+// FetchWorkflows reports the project's pipeline definition as its only
+// workflow, or none if the file isn't on the default branch
+func (c *Client) FetchWorkflows(owner, repo string) ([]gh.Workflow, error) {
+	var p project
+	if err := c.get(context.Background(), projectPath(owner, repo), &p); err != nil {
+		return nil, err
+	}
+	configPath := p.CIConfigPath
+	if configPath == "" {
+		configPath = ciConfigPath
+	}
+	workflow := gh.Workflow{Name: configPath, Path: configPath, State: "active"}
+
+	// A configuration kept in another project ("path@group/project") or
+	// at a URL can't be checked here, so assume it exists
+	if strings.Contains(configPath, "@") || strings.Contains(configPath, "://") {
+		return []gh.Workflow{workflow}, nil
+	}
+	if p.DefaultBranch == "" {
+		return nil, nil // Empty repository
+	}
+
+	path := fmt.Sprintf("%s/repository/files/%s?ref=%s", projectPath(owner, repo), url.PathEscape(configPath), url.QueryEscape(p.DefaultBranch))
+	if _, err := c.do(context.Background(), http.MethodHead, path); err != nil {
+		var notFound *gh.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []gh.Workflow{workflow}, nil
+}
+
+// RerunWorkflow retries the pipeline's failed and canceled jobs
+func (c *Client) RerunWorkflow(owner, repo string, runID int64) error {
+	_, err := c.do(context.Background(), http.MethodPost, fmt.Sprintf("%s/pipelines/%d/retry", projectPath(owner, repo), runID))
+	return err
+}
+
+// RerunJob retries a single job
+func (c *Client) RerunJob(owner, repo string, jobID int64) error {
+	_, err := c.do(context.Background(), http.MethodPost, fmt.Sprintf("%s/jobs/%d/retry", projectPath(owner, repo), jobID))
+	return err
+}
+
+// RerunFailedJobs retries the pipeline's failed jobs, which is what a
+// GitLab pipeline retry does
+func (c *Client) RerunFailedJobs(owner, repo string, runID int64) error {
+	return c.RerunWorkflow(owner, repo, runID)
+}
+
+// CancelWorkflow cancels the pipeline's running jobs
+func (c *Client) CancelWorkflow(owner, repo string, runID int64) error {
+	_, err := c.do(context.Background(), http.MethodPost, fmt.Sprintf("%s/pipelines/%d/cancel", projectPath(owner, repo), runID))
+	return err
+}
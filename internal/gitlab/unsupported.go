@@ -0,0 +1,82 @@
+package gitlab
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lance0/cimon/internal/gh"
+)
+
+// unsupported reports an action GitLab CI has no equivalent for
+func unsupported(action string) error {
+	return fmt.Errorf("%s: %w (GitLab)", action, gh.ErrUnsupported)
+}
+
+// DispatchWorkflow is not supported: GitLab runs the whole pipeline, not
+// a single workflow file
+func (c *Client) DispatchWorkflow(owner, repo, workflowFile, ref string) error {
+	return unsupported("dispatch")
+}
+
+// FetchDispatchedRun is not supported (see DispatchWorkflow)
+func (c *Client) FetchDispatchedRun(owner, repo, workflowFile, ref string, since time.Time) (*gh.WorkflowRun, error) {
+	return nil, unsupported("dispatch")
+}
+
+// FetchPendingDeployments is not supported; GitLab gates deployments with
+// manual jobs, which show as waiting
+func (c *Client) FetchPendingDeployments(owner, repo string, runID int64) ([]gh.PendingDeployment, error) {
+	return nil, unsupported("deployment review")
+}
+
+// ApproveDeployment is not supported (see FetchPendingDeployments)
+func (c *Client) ApproveDeployment(owner, repo string, runID int64, environmentIDs []int64) error {
+	return unsupported("deployment review")
+}
+
+// RejectDeployment is not supported (see FetchPendingDeployments)
+func (c *Client) RejectDeployment(owner, repo string, runID int64, environmentIDs []int64) error {
+	return unsupported("deployment review")
+}
+
+// FetchWorkflowContent is not supported yet
+func (c *Client) FetchWorkflowContent(owner, repo, path string) (string, error) {
+	return "", unsupported("viewing " + ciConfigPath)
+}
+
+// FetchWorkflowArtifacts is not supported yet; GitLab keeps artifacts per job
+func (c *Client) FetchWorkflowArtifacts(owner, repo string, runID int64) ([]gh.Artifact, error) {
+	return nil, unsupported("artifacts")
+}
+
+// DownloadArtifactWithProgress is not supported (see FetchWorkflowArtifacts)
+func (c *Client) DownloadArtifactWithProgress(owner, repo string, artifactID int64, filename string, progress gh.ProgressFunc) error {
+	return unsupported("artifacts")
+}
+
+// FetchAnnotations is not supported; GitLab has no check-run annotations
+func (c *Client) FetchAnnotations(owner, repo string, runID int64) ([]gh.Annotation, error) {
+	return nil, unsupported("annotations")
+}
+
+// FetchRunAttempt is not supported; a retried GitLab job is a new job in
+// the same pipeline rather than a new attempt of the pipeline
+func (c *Client) FetchRunAttempt(owner, repo string, runID int64, attempt int) (*gh.WorkflowRun, error) {
+	return nil, unsupported("run attempts")
+}
+
+// FetchAttemptJobs is not supported (see FetchRunAttempt)
+func (c *Client) FetchAttemptJobs(owner, repo string, runID int64, attempt int) ([]gh.Job, error) {
+	return nil, unsupported("run attempts")
+}
+
+// FetchCommitVerification is not supported yet; GitLab reports signatures
+// from a separate endpoint that 404s for unsigned commits
+func (c *Client) FetchCommitVerification(owner, repo, sha string) (*gh.CommitVerification, error) {
+	return nil, unsupported("commit verification")
+}
+
+// CreateGist is not supported yet; GitLab's equivalent is snippets
+func (c *Client) CreateGist(files map[string]string, public bool) (string, error) {
+	return "", unsupported("gists")
+}
@@ -2,10 +2,14 @@
 package notify
 
 import (
+	"bytes"
 	"fmt"
 	"os/exec"
 	"runtime"
 	"strconv"
+	"strings"
+	"sync"
+	"text/template"
 )
 
 // NotificationData contains information for desktop notifications
@@ -16,8 +20,56 @@ type NotificationData struct {
 	Repo         string
 	Branch       string
 	HTMLURL      string
+	RepoInTitle  bool // Prefix the title with the repo (multi-repo mode)
+
+	// Optional text/template overrides for the title and body. Templates can
+	// use any field above plus .Icon; invalid templates fall back to the defaults.
+	TitleTemplate string
+	BodyTemplate  string
+}
+
+// templateData is the data available to notification templates
+type templateData struct {
+	NotificationData
+	Icon string
+}
+
+// renderTemplate executes a notification template, returning ok=false if
+// it fails to parse or execute
+func renderTemplate(text string, data NotificationData) (string, bool) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, templateData{NotificationData: data, Icon: getStatusIcon(data.Conclusion)}); err != nil {
+		return "", false
+	}
+	return b.String(), true
 }
 
+// ValidateTemplate reports whether text is a valid notification template
+func ValidateTemplate(text string) error {
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(&strings.Builder{}, templateData{})
+}
+
+// lookPath finds notification helpers on PATH (replaced in tests)
+var lookPath = exec.LookPath
+
+// linuxOpenAction is the notify-send action key that opens the run URL
+const linuxOpenAction = "open"
+
+// notifySendSupportsActions reports whether notify-send supports --action
+// and --wait (libnotify 0.7.9+); checked once (replaced in tests)
+var notifySendSupportsActions = sync.OnceValue(func() bool {
+	out, err := exec.Command("notify-send", "--help").Output()
+	return err == nil && bytes.Contains(out, []byte("--action"))
+})
+
 // NotifyResult contains the result of a notification attempt
 type NotifyResult struct {
 	Sent  bool
@@ -35,9 +87,9 @@ func SendDesktopNotification(data NotificationData) NotifyResult {
 
 	switch runtime.GOOS {
 	case "linux":
-		cmd = buildLinuxNotification(title, body, urgency)
+		cmd = buildLinuxNotification(title, body, urgency, data.HTMLURL)
 	case "darwin":
-		cmd = buildMacOSNotification(title, body)
+		cmd = buildMacOSNotification(title, body, data.HTMLURL)
 	case "windows":
 		cmd = buildWindowsNotification(title, body)
 	default:
@@ -63,12 +115,27 @@ func SendDesktopNotification(data NotificationData) NotifyResult {
 
 // formatTitle creates the notification title
 func formatTitle(data NotificationData) string {
+	if data.TitleTemplate != "" {
+		if title, ok := renderTemplate(data.TitleTemplate, data); ok {
+			return title
+		}
+	}
+
 	icon := getStatusIcon(data.Conclusion)
+	if data.RepoInTitle && data.Repo != "" {
+		return fmt.Sprintf("%s %s: %s #%d", icon, data.Repo, data.WorkflowName, data.RunNumber)
+	}
 	return fmt.Sprintf("%s %s #%d", icon, data.WorkflowName, data.RunNumber)
 }
 
 // formatBody creates the notification body
 func formatBody(data NotificationData) string {
+	if data.BodyTemplate != "" {
+		if body, ok := renderTemplate(data.BodyTemplate, data); ok {
+			return body
+		}
+	}
+
 	conclusion := data.Conclusion
 	if conclusion == "" {
 		conclusion = "completed"
@@ -104,23 +171,70 @@ func getUrgency(conclusion string) string {
 	}
 }
 
-// buildLinuxNotification builds a notify-send command for Linux
-func buildLinuxNotification(title, body, urgency string) *exec.Cmd {
+// linuxOpenScript runs notify-send with its arguments ($2...) and opens the
+// URL ($1) if the Open action was clicked; notify-send --wait prints the
+// chosen action key on stdout
+const linuxOpenScript = `url=$1; shift; [ "$(notify-send "$@")" = ` + linuxOpenAction + ` ] && exec xdg-open "$url"`
+
+// buildLinuxNotification builds a notify-send command for Linux. The run URL
+// is appended to the body so it can be copied, and if notify-send supports
+// actions and xdg-open is available, an "Open" action waits for a click.
+// Waiting for the click happens in a shell of its own session, so the button
+// keeps working after cimon exits or its terminal closes.
+func buildLinuxNotification(title, body, urgency, url string) *exec.Cmd {
 	// Check if notify-send is available
-	if _, err := exec.LookPath("notify-send"); err != nil {
+	if _, err := lookPath("notify-send"); err != nil {
 		return nil
 	}
-	return exec.Command("notify-send",
+
+	args := []string{
 		"-u", urgency,
 		"-a", "cimon",
 		"-i", "dialog-information",
-		title,
-		body,
-	)
+	}
+	if url == "" {
+		return exec.Command("notify-send", append(args, title, body)...)
+	}
+	body += "\n" + url
+	if _, err := lookPath("xdg-open"); err != nil || !notifySendSupportsActions() {
+		return exec.Command("notify-send", append(args, title, body)...)
+	}
+	args = append(args, "--wait", "--action="+linuxOpenAction+"=Open", title, body)
+
+	shell := append([]string{"sh", "-c", linuxOpenScript, "sh", url}, args...)
+	if _, err := lookPath("setsid"); err == nil {
+		shell = append([]string{"setsid"}, shell...)
+	}
+	return exec.Command(shell[0], shell[1:]...)
 }
 
-// buildMacOSNotification builds an osascript command for macOS
-func buildMacOSNotification(title, body string) *exec.Cmd {
+// linuxNotificationWaits reports whether a notify-send command waits for an action
+func linuxNotificationWaits(cmd *exec.Cmd) bool {
+	for _, arg := range cmd.Args {
+		if arg == "--wait" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildMacOSNotification builds a notification command for macOS. It prefers
+// terminal-notifier, which makes the notification open url when clicked,
+// and falls back to osascript.
+func buildMacOSNotification(title, body, url string) *exec.Cmd {
+	if _, err := lookPath("terminal-notifier"); err == nil {
+		args := []string{
+			"-title", title,
+			"-message", body,
+			"-sound", "default",
+			"-group", "cimon",
+		}
+		if url != "" {
+			args = append(args, "-open", url)
+		}
+		return exec.Command("terminal-notifier", args...)
+	}
+
 	script := fmt.Sprintf(`display notification "%s" with title "%s" sound name "default"`, body, title)
 	return exec.Command("osascript", "-e", script)
 }
@@ -156,7 +270,7 @@ $toast = [Windows.UI.Notifications.ToastNotification]::new($xml)
 func IsNotificationAvailable() bool {
 	switch runtime.GOOS {
 	case "linux":
-		_, err := exec.LookPath("notify-send")
+		_, err := lookPath("notify-send")
 		return err == nil
 	case "darwin":
 		// osascript is always available on macOS
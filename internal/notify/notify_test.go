@@ -1,7 +1,11 @@
 package notify
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -56,6 +60,17 @@ func TestFormatTitle(t *testing.T) {
 			},
 			expected: "● Other #200",
 		},
+		{
+			name: "repo in title",
+			data: NotificationData{
+				WorkflowName: "CI",
+				RunNumber:    42,
+				Conclusion:   "success",
+				Repo:         "org/api",
+				RepoInTitle:  true,
+			},
+			expected: "✓ org/api: CI #42",
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,6 +119,70 @@ func TestFormatBody(t *testing.T) {
 	}
 }
 
+func TestFormatTemplates(t *testing.T) {
+	data := NotificationData{
+		WorkflowName: "CI",
+		RunNumber:    7,
+		Conclusion:   "failure",
+		Repo:         "org/api",
+		Branch:       "main",
+	}
+
+	tests := []struct {
+		name      string
+		title     string
+		body      string
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name:      "custom title and body",
+			title:     "{{.Icon}} {{.Repo}} {{.Conclusion}}",
+			body:      "{{.WorkflowName}} #{{.RunNumber}} on {{.Branch}}",
+			wantTitle: "✗ org/api failure",
+			wantBody:  "CI #7 on main",
+		},
+		{
+			name:      "parse error falls back to default",
+			title:     "{{.Repo",
+			wantTitle: "✗ CI #7",
+			wantBody:  "org/api on main - failure",
+		},
+		{
+			name:      "unknown field falls back to default",
+			title:     "{{.Nope}}",
+			wantTitle: "✗ CI #7",
+			wantBody:  "org/api on main - failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := data
+			d.TitleTemplate = tt.title
+			d.BodyTemplate = tt.body
+			if got := formatTitle(d); got != tt.wantTitle {
+				t.Errorf("formatTitle() = %q, want %q", got, tt.wantTitle)
+			}
+			if got := formatBody(d); got != tt.wantBody {
+				t.Errorf("formatBody() = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	if err := ValidateTemplate("{{.Icon}} {{.Repo}}"); err != nil {
+		t.Errorf("ValidateTemplate() valid template error = %v", err)
+	}
+	if err := ValidateTemplate("{{.Repo"); err == nil {
+		t.Error("ValidateTemplate() with parse error = nil, want error")
+	}
+	if err := ValidateTemplate("{{.Nope}}"); err == nil {
+		t.Error("ValidateTemplate() with unknown field = nil, want error")
+	}
+}
+
 func TestGetStatusIcon(t *testing.T) {
 	tests := []struct {
 		conclusion string
@@ -231,7 +310,7 @@ func TestIsNotificationAvailable(t *testing.T) {
 }
 
 func TestBuildMacOSNotification(t *testing.T) {
-	cmd := buildMacOSNotification("Test Title", "Test Body")
+	cmd := buildMacOSNotification("Test Title", "Test Body", "")
 	if cmd == nil {
 		t.Fatal("buildMacOSNotification() returned nil")
 	}
@@ -248,6 +327,47 @@ func TestBuildMacOSNotification(t *testing.T) {
 	}
 }
 
+func TestBuildMacOSNotificationTerminalNotifier(t *testing.T) {
+	origLookPath := lookPath
+	defer func() { lookPath = origLookPath }()
+
+	url := "https://github.com/owner/repo/actions/runs/123"
+
+	// terminal-notifier available: clicking opens the run URL
+	lookPath = func(file string) (string, error) {
+		if file == "terminal-notifier" {
+			return "/usr/local/bin/terminal-notifier", nil
+		}
+		return "", exec.ErrNotFound
+	}
+	cmd := buildMacOSNotification("Title", "Body", url)
+	if filepath.Base(cmd.Path) != "terminal-notifier" {
+		t.Fatalf("buildMacOSNotification() command = %q, want terminal-notifier", cmd.Path)
+	}
+	if !hasArgPair(cmd.Args, "-open", url) {
+		t.Errorf("buildMacOSNotification() args = %v, want -open %s", cmd.Args, url)
+	}
+
+	// terminal-notifier missing: fall back to osascript
+	lookPath = func(file string) (string, error) {
+		return "", exec.ErrNotFound
+	}
+	cmd = buildMacOSNotification("Title", "Body", url)
+	if cmd.Args[0] != "osascript" {
+		t.Errorf("buildMacOSNotification() fallback = %v, want osascript", cmd.Args)
+	}
+}
+
+// hasArgPair reports whether args contains flag immediately followed by value
+func hasArgPair(args []string, flag, value string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag && args[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
+
 func TestBuildWindowsNotification(t *testing.T) {
 	cmd := buildWindowsNotification("Test Title", "Test Body")
 	if cmd == nil {
@@ -262,7 +382,7 @@ func TestBuildWindowsNotification(t *testing.T) {
 
 func TestBuildLinuxNotification(t *testing.T) {
 	// This may return nil if notify-send is not installed
-	cmd := buildLinuxNotification("Test Title", "Test Body", "normal")
+	cmd := buildLinuxNotification("Test Title", "Test Body", "normal", "")
 
 	// If notify-send is available, verify command structure
 	if cmd != nil {
@@ -272,6 +392,85 @@ func TestBuildLinuxNotification(t *testing.T) {
 	}
 }
 
+func TestBuildLinuxNotificationURL(t *testing.T) {
+	origLookPath, origActions := lookPath, notifySendSupportsActions
+	defer func() { lookPath, notifySendSupportsActions = origLookPath, origActions }()
+
+	lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	url := "https://github.com/owner/repo/actions/runs/123"
+
+	// Daemon supports actions: wait for the Open action
+	notifySendSupportsActions = func() bool { return true }
+	cmd := buildLinuxNotification("Title", "Body", "normal", url)
+	if !linuxNotificationWaits(cmd) || !hasArg(cmd.Args, "--action=open=Open") {
+		t.Errorf("buildLinuxNotification() args = %v, want --wait and open action", cmd.Args)
+	}
+	if body := cmd.Args[len(cmd.Args)-1]; body != "Body\n"+url {
+		t.Errorf("buildLinuxNotification() body = %q, want URL appended", body)
+	}
+
+	// No action support: URL in body only, no waiting
+	notifySendSupportsActions = func() bool { return false }
+	cmd = buildLinuxNotification("Title", "Body", "normal", url)
+	if linuxNotificationWaits(cmd) {
+		t.Errorf("buildLinuxNotification() args = %v, want no --wait", cmd.Args)
+	}
+	if body := cmd.Args[len(cmd.Args)-1]; body != "Body\n"+url {
+		t.Errorf("buildLinuxNotification() body = %q, want URL appended", body)
+	}
+}
+
+func TestLinuxNotificationOpensURL(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix-specific test")
+	}
+	origLookPath, origActions := lookPath, notifySendSupportsActions
+	defer func() { lookPath, notifySendSupportsActions = origLookPath, origActions }()
+
+	// Fake notify-send reports a click on Open; fake xdg-open records the URL
+	dir := t.TempDir()
+	opened := filepath.Join(dir, "opened")
+	for name, script := range map[string]string{
+		"notify-send": "#!/bin/sh\necho open\n",
+		"xdg-open":    "#!/bin/sh\necho \"$1\" > " + opened + "\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	lookPath = func(file string) (string, error) {
+		if file == "setsid" {
+			return "", exec.ErrNotFound
+		}
+		return filepath.Join(dir, file), nil
+	}
+	notifySendSupportsActions = func() bool { return true }
+
+	url := "https://github.com/owner/repo/actions/runs/123"
+	cmd := buildLinuxNotification("Title", "Body", "normal", url)
+	if cmd.Args[0] == "notify-send" {
+		t.Fatalf("buildLinuxNotification() args = %v, want the click handled outside cimon", cmd.Args)
+	}
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("notification command error = %v", err)
+	}
+	got, err := os.ReadFile(opened)
+	if err != nil || strings.TrimSpace(string(got)) != url {
+		t.Errorf("xdg-open got %q (err %v), want %s", got, err, url)
+	}
+}
+
+// hasArg reports whether args contains arg
+func hasArg(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
+
 func TestSendDesktopNotification_UnsupportedPlatform(t *testing.T) {
 	// We can't easily test unsupported platforms, but we can test the data path
 	data := NotificationData{
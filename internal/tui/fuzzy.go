@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyScore reports whether every rune of query appears in target in
+// order, ignoring case, and scores the match: runes that start a word or
+// follow the previous match score extra, and a gap between matches costs a
+// point per skipped rune (up to 3), so "bt" ranks "build-test" above
+// "bootstrap"
+func fuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	score, qi, prev := 0, 0, -1
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 8
+		}
+		if prev >= 0 {
+			if ti == prev+1 {
+				score += 5
+			} else {
+				score -= min(ti-prev-1, 3)
+			}
+		}
+		prev = ti
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// fuzzyFilter returns the indexes of the items that fuzzy-match query, best
+// match first; ties and an empty query keep the items' order
+func fuzzyFilter(query string, items []string) []int {
+	type match struct{ index, score int }
+	var matches []match
+	for i, item := range items {
+		if score, ok := fuzzyScore(query, item); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	indexes := make([]int, len(matches))
+	for i, match := range matches {
+		indexes[i] = match.index
+	}
+	return indexes
+}
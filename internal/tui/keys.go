@@ -8,28 +8,55 @@ type KeyMap struct {
 	Refresh      key.Binding
 	Watch        key.Binding
 	Open         key.Binding
+	OpenChange   key.Binding
 	Up           key.Binding
 	Down         key.Binding
 	Enter        key.Binding
 	Logs         key.Binding
+	TailLogs     key.Binding
 	Search       key.Binding
 	NextMatch    key.Binding
 	PrevMatch    key.Binding
 	NextRun      key.Binding
 	PrevRun      key.Binding
+	RunList      key.Binding
+	Attempt      key.Binding
+	QuickJump    key.Binding
+	NextRepo     key.Binding
+	PrevRepo     key.Binding
 	BranchSelect key.Binding
 	Filter       key.Binding
+	EventFilter  key.Binding
 	Help         key.Binding
 	Workflow     key.Binding
 	Artifacts    key.Binding
+	Annotations  key.Binding
+	Failures     key.Binding
+	JobFilter    key.Binding
+	JobSort      key.Binding
+	Rerun        key.Binding
+	RerunJob     key.Binding
+	CancelRun    key.Binding
+	Deployments  key.Binding
+	Dashboard    key.Binding
+	CopyCommand  key.Binding
+	TimeZone     key.Binding
+	Compact      key.Binding
 
 	// v0.6 Log keys
 	LogFilter     key.Binding
 	LogSave       key.Binding
+	LogGist       key.Binding
 	LogHighlight  key.Binding
 	LogCompare    key.Binding
 	LogMulti      key.Binding
 	LogViewToggle key.Binding
+	LineNumbers   key.Binding
+	Timestamps    key.Binding
+	ScrollLeft    key.Binding
+	ScrollRight   key.Binding
+	PrevStep      key.Binding
+	NextStep      key.Binding
 
 	// General UI keys
 	Escape key.Binding
@@ -55,6 +82,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "open"),
 		),
+		OpenChange: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "open PR/commit"),
+		),
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
 			key.WithHelp("↑/k", "up"),
@@ -71,6 +102,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("l"),
 			key.WithHelp("l", "view logs"),
 		),
+		TailLogs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "tail logs"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
@@ -91,6 +126,26 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("h", "left"),
 			key.WithHelp("h/←", "prev run"),
 		),
+		RunList: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "run history"),
+		),
+		Attempt: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "earlier attempt"),
+		),
+		QuickJump: key.NewBinding(
+			key.WithKeys(":", "ctrl+p"),
+			key.WithHelp(":/ctrl+p", "jump to job/run"),
+		),
+		NextRepo: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next repo"),
+		),
+		PrevRepo: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("⇧tab", "prev repo"),
+		),
 		BranchSelect: key.NewBinding(
 			key.WithKeys("b"),
 			key.WithHelp("b", "select branch"),
@@ -99,6 +154,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("f"),
 			key.WithHelp("f", "filter status"),
 		),
+		EventFilter: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "filter event"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -111,6 +170,54 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "download artifacts"),
 		),
+		Annotations: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "annotations"),
+		),
+		Failures: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "failed steps"),
+		),
+		JobFilter: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "filter jobs"),
+		),
+		JobSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "sort jobs"),
+		),
+		Rerun: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rerun workflow"),
+		),
+		RerunJob: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "rerun failed job"),
+		),
+		CancelRun: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "cancel workflow"),
+		),
+		Deployments: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "review deployment / approve run"),
+		),
+		Dashboard: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "dashboard/list"),
+		),
+		CopyCommand: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy run command"),
+		),
+		Compact: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "compact view"),
+		),
+		TimeZone: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "UTC/local time"),
+		),
 
 		// v0.6 Log keys
 		LogFilter: key.NewBinding(
@@ -121,6 +228,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "save logs"),
 		),
+		LogGist: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "share as gist"),
+		),
 		LogHighlight: key.NewBinding(
 			key.WithKeys("H"),
 			key.WithHelp("H", "toggle syntax"),
@@ -137,6 +248,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("v"),
 			key.WithHelp("v", "split/combined"),
 		),
+		LineNumbers: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "line numbers"),
+		),
+		Timestamps: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "timestamps"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("<", "shift+left"),
+			key.WithHelp("</⇧←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys(">", "shift+right"),
+			key.WithHelp(">/⇧→", "scroll right"),
+		),
+		PrevStep: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev step"),
+		),
+		NextStep: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next step"),
+		),
 
 		// General UI keys
 		Escape: key.NewBinding(
@@ -1,20 +1,30 @@
 package tui
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"os"
 	"os/exec"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lance0/cimon/internal/browser"
 	"github.com/lance0/cimon/internal/config"
 	"github.com/lance0/cimon/internal/gh"
 	"github.com/lance0/cimon/internal/notify"
+	"github.com/lance0/cimon/internal/redact"
+	"github.com/muesli/termenv"
 )
 
 // State represents the current state of the TUI
@@ -36,15 +46,37 @@ const (
 	StateMultiJobSelect // v0.6: Multi-job selection for following
 	StateCompareSelect  // v0.6: Run selection for comparison
 	StateCompareView    // v0.6: Viewing log comparison
+	StateConfirm        // Confirmation prompt for rerun/cancel/deployment review
+	StateDashboard      // Multi-repo dashboard: one row per repo
+	StateAnnotations    // Check-run annotations of the current run, grouped by job
+	StateRunList        // Table of the loaded runs; enter opens one
+	StateFailureSummary // Failed steps across every job of the current run
+	StateQuickJump      // Fuzzy finder over the loaded jobs, or runs from the run list
 )
 
+// maxLogLines caps how many log lines the viewer keeps; enormous logs show
+// only their last maxLogLines lines
+const maxLogLines = 100000
+
+// defaultTailLines is how many lines tail-only logs (L) show without --tail
+const defaultTailLines = 1000
+
+// logHScrollStep is how many columns one horizontal scroll pans the log
+const logHScrollStep = 8
+
+// logMatch is one search match: a byte range within a log line
+type logMatch struct {
+	line       int // index into logLines
+	start, end int // byte offsets within the line
+}
+
 // Model is the Bubble Tea model for the TUI
 type Model struct {
 	// Configuration
 	config *config.Config
 
-	// GitHub client
-	client *gh.Client
+	// CI provider: GitHub, or GitLab with --provider gitlab
+	client gh.Provider
 
 	// Current state
 	state State
@@ -56,13 +88,23 @@ type Model struct {
 	branches []gh.Branch // All available branches
 
 	// Navigation state
-	selectedRunIndex    int // Index of currently selected run in runs slice
-	selectedBranchIndex int // Index of currently selected branch in branch selection
+	selectedRunIndex    int    // Index of currently selected run in runs slice
+	runListCursor       int    // Highlighted row in the run history table
+	selectedBranchIndex int    // Index of currently selected branch in the filtered branch list
+	branchFilter        string // Type-to-filter text in branch selection
+
+	// Quick jump (fuzzy finder) state
+	quickJumpQuery  string
+	quickJumpCursor int  // Highlighted row among the ranked matches
+	quickJumpRuns   bool // Searching the run list rather than the run's jobs
 
 	// Filter state
 	currentStatusFilter string   // Current status filter ("", "success", "failure", "in_progress", etc.)
 	statusFilterOptions []string // Available filter options
 	selectedFilterIndex int      // Index of currently selected filter option
+	eventFilter         string   // Triggering event filter ("", "push", "pull_request", etc.)
+	jobStatusFilter     string   // Client-side job filter within a run ("", "failure", "in_progress")
+	jobSort             string   // Job sort order ("", "duration", "status")
 
 	// Job details state
 	showingJobDetails bool
@@ -72,19 +114,30 @@ type Model struct {
 	// Log viewer state
 	showingLogs       bool
 	logContent        string
+	logLines          []string // logContent split into lines once, capped at maxLogLines
+	logTruncated      int      // lines dropped from the top to stay under maxLogLines
+	logStepLines      []int    // indexes in logLines of the "=== step ===" headers, for [ and ]
+	logTail           int      // show only the last logTail lines when loaded (0 = whole log)
 	logScrollOffset   int
 	logSearchTerm     string
-	logSearchMatches  []int // line numbers with matches
-	logSearchIndex    int   // current match index
+	logSearchMatches  []logMatch // every match in the log, in order
+	logSearchIndex    int        // current match index
 	logJobID          int64
 	logLastFetch      time.Time
 	logStreaming      bool
+	logByteOffset     int64     // raw log bytes downloaded so far, for incremental streaming
 	searchInputMode   bool   // true when typing search term
 	searchInputBuffer string // buffer for search input
 	logSyntaxEnabled  bool      // v0.6: syntax highlighting on/off
+	logLineNumbers    bool      // show line number gutter
+	logHideTimestamps bool      // strip leading GitHub timestamps
+	logHScrollOffset  int       // columns the log viewer is panned right
 	logExportMessage  string    // v0.6: export success/error message
 	logExportTime     time.Time // v0.6: when message was set (for auto-clear)
 
+	// Step the streamed job is executing, refreshed with each log update
+	logRunningStep *gh.JobStep
+
 	// Log filtering state (v0.6)
 	parsedLogs           *gh.ParsedLogs // Structured log data with step-level parsing
 	logFilterStepNumbers []int          // Currently selected step numbers to display
@@ -109,9 +162,19 @@ type Model struct {
 	compareScrollOff  int      // Scroll offset for diff view
 
 	// Multi-repo state (v0.8)
-	multiRepoMode      bool             // True when monitoring multiple repos
-	sourcedRuns        []gh.SourcedRun  // Runs from all repos, sorted by time
-	selectedSourcedRun int              // Index in sourcedRuns slice
+	multiRepoMode      bool            // True when monitoring multiple repos
+	sourcedRuns        []gh.SourcedRun // Runs from all repos, sorted by time
+	repoFailures       []gh.RepoError  // Repos that failed to load on the last fetch
+	selectedSourcedRun int             // Index in sourcedRuns slice
+	dashboardCursor    int             // Selected repo row in the dashboard
+	multiRepoListView  bool            // Show the time-sorted run list instead of the dashboard
+	drilledIntoRepo    bool            // Viewing a single repo entered from the dashboard
+	dashboardStatus    string          // The dashboard's status filter, restored on the way back
+	dashboardConfig    *config.Config  // The dashboard's config while drilled in; m.config is then the repo's copy
+
+	// Multi-repo watch state: last-seen status per run and the runs notified, keyed by repo slug
+	repoRunStatus map[string]map[int64]string
+	repoNotified  map[string]map[int64]bool
 
 	// Workflow viewer state
 	workflowContent      string
@@ -122,18 +185,65 @@ type Model struct {
 	artifacts             []gh.Artifact
 	selectedArtifactIndex int
 
+	// Confirmation prompt state
+	confirmMessage  string  // Prompt shown in StateConfirm
+	confirmAction   tea.Cmd // Command to run when the user confirms
+	rejectAction    tea.Cmd // Command to run on x, for prompts that can also reject
+	confirmFromLogs bool    // The prompt was raised in the log viewer, so declining returns there
+
+	// Annotations panel state
+	annotations         []gh.Annotation
+	annotationScrollOff int
+
+	// Failure summary state. Job details (for their steps) are fetched
+	// lazily when the summary opens and cached per job ID
+	failureJobs     map[int64]*gh.Job
+	failureCursor   int
+	logFromFailures bool // The log viewer was opened from the failure summary
+
+	// Environments the current run is waiting on for approval
+	pendingDeployments []gh.PendingDeployment
+
+	// Billable timing of the current run, fetched once it completes
+	runTiming   *gh.RunTiming
+	runTimingID int64
+
+	// Signature verification of run head commits by SHA, fetched once per
+	// commit. A nil entry is still loading or couldn't be fetched.
+	commitVerifications map[string]*gh.CommitVerification
+
+	// An earlier attempt of a re-run run whose jobs are shown (see shownAttempt)
+	attempt      int
+	attemptRunID int64
+	attemptRun   *gh.WorkflowRun // The run as of that attempt
+
+	// Screen help was opened from; closing help returns there and the help
+	// lists that screen's keys
+	helpReturnState State
+
+	// Transient status message (rerun/cancel results)
+	statusMessage     string
+	statusMessageErr  bool
+	statusMessageTime time.Time
+
 	// UI state
 	cursor           int
 	watching         bool
-	notificationSent bool // v0.7: Prevent duplicate notifications on completion
+	notificationSent bool      // v0.7: Prevent duplicate notifications on completion
+	watchNewestID    int64     // --watch-new: newest run ID seen so far; only a newer one is followed
+	bellFlashUntil   time.Time // --bell: the header flashes until this time
 	lastFetch        time.Time
+	fetching         bool // A refresh is in flight; further refreshes wait for it
 
 	// Error
-	err error
+	err        error
+	refreshErr error // Last watch-mode refresh that failed transiently; cleared by the next good one
+	jobsErr    error // Last job fetch failed; the run summary shows a retry hint instead of jobs
 
 	// Styles and keys
-	styles *Styles
-	keys   KeyMap
+	styles       *Styles
+	keys         KeyMap
+	colorEnabled bool // Sparklines need color to be readable
 
 	// Spinner for loading state
 	spinner spinner.Model
@@ -142,11 +252,20 @@ type Model struct {
 	width  int
 	height int
 
+	// Compact layout (--compact, toggled with z): one line per job, no spacing
+	compact bool
+
 	// Loading state
 	loadingMessage string
 
 	// Exit code to return (set when quitting)
 	exitCode int
+
+	// Name of the job that stopped a --fail-fast watch
+	failFastJob string
+
+	// The watch ran out of --timeout before the run completed
+	timedOut bool
 }
 
 // Messages
@@ -158,7 +277,15 @@ type RunLoadedMsg struct {
 
 // JobsLoadedMsg is sent when jobs are loaded
 type JobsLoadedMsg struct {
-	Jobs []gh.Job
+	Jobs    []gh.Job
+	Attempt *gh.WorkflowRun // The earlier attempt the jobs belong to; nil for the latest
+}
+
+// JobsFailedMsg is sent when a run's jobs could not be loaded. Unlike
+// ErrMsg it leaves the run on screen, since the run data is still good.
+type JobsFailedMsg struct {
+	RunID int64 // The run the jobs were fetched for
+	Err   error
 }
 
 // JobDetailsLoadedMsg is sent when job details are loaded
@@ -169,11 +296,27 @@ type JobDetailsLoadedMsg struct {
 // LogLoadedMsg is sent when job logs are loaded
 type LogLoadedMsg struct {
 	Content string
+	Size    int64 // Raw bytes downloaded, used as the next streaming offset
+}
+
+// LogFailedMsg is sent when a job's log can't be opened at all, like an
+// archive over --max-log-bytes, so the viewer closes instead of erroring out
+type LogFailedMsg struct {
+	Err error
 }
 
 // LogUpdatedMsg is sent when logs are updated during streaming
 type LogUpdatedMsg struct {
-	Content string
+	Content  string // New log text if Appended, otherwise the full log
+	Size     int64
+	Appended bool
+}
+
+// LogJobUpdatedMsg carries the streamed job's latest details, for the step
+// it is running
+type LogJobUpdatedMsg struct {
+	JobID int64
+	Job   *gh.Job
 }
 
 // RunsLoadedMsg is sent when multiple workflow runs are loaded
@@ -202,17 +345,38 @@ type ArtifactDownloadedMsg struct {
 	Filename string
 }
 
+// DownloadProgressMsg reports progress of an artifact or log download
+type DownloadProgressMsg struct {
+	Name  string
+	Done  int64
+	Total int64 // -1 if the size is unknown
+
+	next <-chan tea.Msg // remaining messages of the download
+}
+
 // LogExportedMsg is sent when logs are exported to file (v0.6)
 type LogExportedMsg struct {
 	Filename string
 	Error    error
 }
 
+// GistCreatedMsg is sent when the log being viewed has been shared as a gist
+type GistCreatedMsg struct {
+	URL string
+	Err error
+}
+
 // ParsedLogsLoadedMsg is sent when structured logs are loaded (v0.6)
 type ParsedLogsLoadedMsg struct {
 	Logs *gh.ParsedLogs
 }
 
+// StepLogsLoadedMsg is sent when structured logs are loaded to show a single step
+type StepLogsLoadedMsg struct {
+	Logs *gh.ParsedLogs
+	Step gh.JobStep
+}
+
 // MultiJobLogsLoadedMsg is sent when logs for multiple jobs are loaded (v0.6)
 type MultiJobLogsLoadedMsg struct {
 	Contents map[int64]string
@@ -227,6 +391,46 @@ type CompareLogsLoadedMsg struct {
 // MultiRepoRunsLoadedMsg is sent when runs from multiple repos are loaded (v0.8)
 type MultiRepoRunsLoadedMsg struct {
 	SourcedRuns []gh.SourcedRun
+	Failures    []gh.RepoError // Repos that failed to load, in config order
+}
+
+// AnnotationsLoadedMsg is sent when a run's check-run annotations are loaded
+type AnnotationsLoadedMsg struct {
+	Annotations []gh.Annotation
+}
+
+// FailureDetailsLoadedMsg is sent when the details of failed jobs are loaded
+// for the failure summary
+type FailureDetailsLoadedMsg struct {
+	Jobs   []*gh.Job
+	Failed int   // Jobs whose details couldn't be fetched; left out of Jobs
+	Err    error // Why the first of them failed
+}
+
+// PendingDeploymentsLoadedMsg is sent when a waiting run's pending deployments are loaded
+type PendingDeploymentsLoadedMsg struct {
+	RunID       int64
+	Deployments []gh.PendingDeployment
+}
+
+// RunTimingLoadedMsg is sent when a completed run's billable timing is loaded
+type RunTimingLoadedMsg struct {
+	RunID  int64
+	Timing *gh.RunTiming
+}
+
+// CommitVerificationLoadedMsg is sent when a head commit's signature
+// verification is loaded
+type CommitVerificationLoadedMsg struct {
+	SHA          string
+	Verification *gh.CommitVerification
+	Err          error
+}
+
+// ActionResultMsg is sent when a rerun or cancel request completes
+type ActionResultMsg struct {
+	Message string
+	Err     error
 }
 
 // ErrMsg is sent when an error occurs
@@ -234,18 +438,36 @@ type ErrMsg struct {
 	Err error
 }
 
+// RefreshFailedMsg is sent when loading the runs fails, on the first load
+// or a refresh. Other fetches report failures with ErrMsg.
+type RefreshFailedMsg struct {
+	Err error
+}
+
 // TickMsg is sent for watch mode polling
 type TickMsg struct {
 	Time time.Time
 }
 
+// BellFlashEndMsg is sent when the --bell header flash is over
+type BellFlashEndMsg struct{}
+
+// WatchTimeoutMsg is sent when --timeout runs out in watch mode
+type WatchTimeoutMsg struct{}
+
+// QueuedPollMsg is sent to re-check a queued run whose jobs haven't been
+// created yet, outside watch mode
+type QueuedPollMsg struct {
+	RunID int64
+}
+
 // NewModel creates a new TUI model
-func NewModel(cfg *config.Config, client *gh.Client) Model {
+func NewModel(cfg *config.Config, client gh.Provider) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
-	// Colors are enabled unless NO_COLOR is set or --no-color flag is used
-	colorEnabled := os.Getenv("NO_COLOR") == "" && !cfg.NoColor
+	// Colors follow --no-color, NO_COLOR, FORCE_COLOR and TTY detection
+	colorEnabled := cfg.ColorEnabled()
 
 	// v0.8: Determine loading message based on mode
 	loadingMsg := "Loading workflow runs..."
@@ -253,20 +475,28 @@ func NewModel(cfg *config.Config, client *gh.Client) Model {
 		loadingMsg = "Loading runs from multiple repositories..."
 	}
 
+	styles := DefaultStyles(colorEnabled)
+	styles.Symbols = cfg.Symbols
+
 	return Model{
 		config:              cfg,
 		client:              client,
 		state:               StateLoading,
 		multiRepoMode:       cfg.IsMultiRepo(), // v0.8
 		selectedRunIndex:    0,                 // Start with the first (latest) run
-		currentStatusFilter: "",                // Start with no filter (all runs)
-		statusFilterOptions: []string{"", "success", "failure", "in_progress", "completed", "queued"},
+		currentStatusFilter: cfg.StatusFilter,  // Remembered filter, or "" for all runs
+		statusFilterOptions: append([]string{""}, config.RunStatusFilters...),
+		eventFilter:         cfg.Event,
 		loadingMessage:      loadingMsg,
-		styles:              DefaultStyles(colorEnabled),
+		styles:              styles,
+		colorEnabled:        colorEnabled,
 		keys:                DefaultKeyMap(),
 		spinner:             s,
 		watching:            cfg.Watch,
+		compact:             cfg.Compact,
 		logSyntaxEnabled:    true, // v0.6: syntax highlighting on by default
+		repoRunStatus:       make(map[string]map[int64]string),
+		repoNotified:        make(map[string]map[int64]bool),
 	}
 }
 
@@ -277,25 +507,75 @@ func (m Model) Init() tea.Cmd {
 		return tea.Batch(
 			m.spinner.Tick,
 			m.fetchMultiRepoRuns(),
+			m.watchTimeout(),
+		)
+	}
+	if m.config.RunID != 0 {
+		return tea.Batch(
+			m.spinner.Tick,
+			m.fetchSelectedRun(),
+			m.watchTimeout(),
 		)
 	}
 	return tea.Batch(
 		m.spinner.Tick,
 		m.fetchWorkflowRuns(),
+		m.watchTimeout(),
 	)
 }
 
+// watchTimeout ends a --watch session after --timeout
+func (m Model) watchTimeout() tea.Cmd {
+	if !m.watching || m.config.Timeout <= 0 {
+		return nil
+	}
+	return tea.Tick(m.config.Timeout, func(time.Time) tea.Msg {
+		return WatchTimeoutMsg{}
+	})
+}
+
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
 
+	case tea.FocusMsg:
+		// --refresh-on-focus: catch up on what changed while the terminal was
+		// in the background, unless the data on screen is only seconds old
+		if m.config.RefreshOnFocus && m.showsRefreshedRuns() && m.state != StateLoading &&
+			time.Since(m.lastFetch) >= config.MinPollInterval {
+			return m, m.startRefresh()
+		}
+		return m, nil
+
+	case BellFlashEndMsg:
+		// Nothing to update; the redraw drops the flash
+		return m, nil
+
+	case WatchTimeoutMsg:
+		// A watch that already ended (run completed, or w pressed) stays open
+		if !m.watching {
+			return m, nil
+		}
+		// --watch-new never ends by itself; a finished run isn't a timeout
+		if m.config.WatchNew && m.run != nil && m.run.IsCompleted() {
+			m.watching = false
+			return m, tea.Quit
+		}
+		m.watching = false
+		m.timedOut = true
+		m.exitCode = config.ExitTimeout
+		return m, tea.Quit
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -309,10 +589,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedRunIndex = 0
 			}
 			m.run = &m.runs[m.selectedRunIndex] // Select the current run
+			if m.watching && m.config.WatchNew {
+				m.followNewestRun()
+			}
 			m.lastFetch = time.Now()
+			m.refreshErr = nil
 			return m, m.fetchJobs()
 		}
 		// No runs found - still go to ready state but show message
+		m.fetching = false
 		m.run = nil
 		m.state = StateReady
 		return m, nil
@@ -320,7 +605,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MultiRepoRunsLoadedMsg:
 		// v0.8: Handle multi-repo runs loading
 		m.sourcedRuns = msg.SourcedRuns
+		m.repoFailures = msg.Failures
 		m.lastFetch = time.Now()
+		m.refreshErr = nil
+		bell := m.checkMultiRepoCompletions()
+		if !m.multiRepoListView {
+			// Dashboard is the default multi-repo view; no jobs needed
+			m.fetching = false
+			if m.showsRefreshedRuns() {
+				m.state = StateDashboard
+			}
+			return m, tea.Batch(m.scheduleNextPoll(), bell)
+		}
 		if len(m.sourcedRuns) > 0 {
 			// Ensure selectedSourcedRun is valid
 			if m.selectedSourcedRun >= len(m.sourcedRuns) {
@@ -331,89 +627,258 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.run = sr.Run
 			m.config.Owner = sr.Owner
 			m.config.Repo = sr.Repo
-			return m, m.fetchJobs()
+			return m, tea.Batch(m.fetchJobs(), bell)
 		}
 		// No runs found
+		m.fetching = false
 		m.run = nil
 		m.state = StateReady
-		return m, nil
+		return m, bell
 
 	case BranchesLoadedMsg:
 		m.branches = msg.Branches
+		m.branchFilter = ""
+		m.selectedBranchIndex = 0
 		m.state = StateBranchSelection
 		return m, nil
 
 	case RunLoadedMsg:
 		m.run = msg.Run
 		m.lastFetch = time.Now()
+		m.refreshErr = nil
 		if m.run != nil {
 			return m, m.fetchJobs()
 		}
+		m.fetching = false
 		m.state = StateReady
 		return m, nil
 
 	case JobsLoadedMsg:
+		m.fetching = false
+		m.jobsErr = nil
+		selectedID := m.selectedJobID()
 		m.jobs = msg.Jobs
+		m.selectJobByID(selectedID)
+		// The attempt shown is the one these jobs came from
+		m.attemptRun = msg.Attempt
+		m.attempt, m.attemptRunID = 0, 0
+		if msg.Attempt != nil {
+			m.attempt, m.attemptRunID = msg.Attempt.RunAttempt, msg.Attempt.ID
+		}
 		// Even if job fetching fails, we can still show the runs
-		// Jobs are optional - runs provide the main value
-		if m.watching {
-			m.state = StateWatching
-		} else {
-			m.state = StateReady
+		// Jobs are optional - runs provide the main value.
+		// A background poll leaves other screens (help, job details, ...) open.
+		if m.showsRefreshedRuns() {
+			if m.watching {
+				m.state = StateWatching
+			} else {
+				m.state = StateReady
+			}
 		}
-		// If watching and run is complete, stop watching and trigger notifications
-		if m.watching && m.run != nil && m.run.IsCompleted() {
-			m.watching = false
-			m.state = StateReady
+		// --fail-fast: quit as soon as any job fails instead of waiting for the rest
+		if m.watching && m.config.FailFast && !m.multiRepoMode && m.shownAttempt() == 0 {
+			if job := firstFailedJob(m.jobs); job != nil {
+				m.watching = false
+				m.failFastJob = job.Name
+				m.exitCode = 1
+				return m, tea.Quit
+			}
+		}
+		// If watching and run is complete, stop watching and trigger notifications.
+		// Multi-repo watch keeps polling; completions are handled per repo.
+		var bell tea.Cmd
+		if m.watching && !m.multiRepoMode && m.run != nil && m.run.IsCompleted() {
+			// --watch-new keeps polling for the next run
+			if !m.config.WatchNew {
+				m.watching = false
+				if m.state == StateWatching {
+					m.state = StateReady
+				}
+			}
 			// v0.7: Send notification and execute hook (only once per completion)
 			if !m.notificationSent {
 				m.notificationSent = true
 				m.triggerNotifications()
+				bell = m.ringBell()
 			}
 		}
 		// Set exit code based on run status
 		m.updateExitCode()
+		// Billable time is only final once the run completes
+		var timing tea.Cmd
+		if m.run != nil && m.run.IsCompleted() && !m.multiRepoMode && m.runTimingID != m.run.ID {
+			m.runTimingID = m.run.ID
+			m.runTiming = nil
+			timing = m.fetchRunTiming()
+		}
+		// A commit's signature doesn't change, so each head commit is checked once
+		var verification tea.Cmd
+		if m.run != nil && m.run.HeadSHA != "" && !m.multiRepoMode {
+			if _, ok := m.commitVerifications[m.run.HeadSHA]; !ok {
+				if m.commitVerifications == nil {
+					m.commitVerifications = make(map[string]*gh.CommitVerification)
+				}
+				m.commitVerifications[m.run.HeadSHA] = nil
+				verification = m.fetchCommitVerification()
+			}
+		}
+		// Runs paused on an environment protection rule can be approved from here
+		if m.run != nil && m.run.Status == gh.StatusWaiting && !m.multiRepoMode {
+			return m, tea.Batch(m.scheduleNextPoll(), m.fetchPendingDeployments(), verification)
+		}
+		m.pendingDeployments = nil
+		if !m.watching && !m.multiRepoMode && m.waitingForJobs() {
+			// Keep checking until GitHub creates the queued run's jobs
+			return m, tea.Batch(m.scheduleQueuedPoll(m.run.ID), bell, verification)
+		}
+		return m, tea.Batch(m.scheduleNextPoll(), timing, verification, bell)
+
+	case JobsFailedMsg:
+		// The run itself loaded fine, so stay on it and let r retry the jobs,
+		// unless retrying can't help until the user fixes their token
+		m.fetching = false
+		if needsUserAction(msg.Err) {
+			m.err = msg.Err
+			m.state = StateError
+			m.exitCode = 2
+			return m, nil
+		}
+		m.jobsErr = msg.Err
+		// Jobs from before the failure are still good if they're this run's
+		if m.run == nil || m.run.ID != msg.RunID {
+			m.jobs = nil
+		}
+		if m.showsRefreshedRuns() {
+			if m.watching {
+				m.state = StateWatching
+			} else {
+				m.state = StateReady
+			}
+		}
 		return m, m.scheduleNextPoll()
 
+	case QueuedPollMsg:
+		// Only refresh while the same run is still on screen without jobs
+		if m.state == StateReady && !m.watching && m.waitingForJobs() && m.run.ID == msg.RunID {
+			return m, m.startRefresh()
+		}
+		return m, nil
+
+	case PendingDeploymentsLoadedMsg:
+		// Ignore results for a run the user has since moved away from
+		if m.run != nil && m.run.ID == msg.RunID {
+			m.pendingDeployments = msg.Deployments
+		}
+		return m, nil
+
+	case RunTimingLoadedMsg:
+		if msg.RunID == m.runTimingID {
+			m.runTiming = msg.Timing
+		}
+		return m, nil
+
+	case CommitVerificationLoadedMsg:
+		if _, ok := m.commitVerifications[msg.SHA]; ok {
+			m.commitVerifications[msg.SHA] = msg.Verification
+			// A blip is retried on a later poll. Anything else, like a token
+			// without contents access or a provider without verification,
+			// would fail the same way every poll, so it isn't asked again.
+			if msg.Err != nil && gh.IsRetryable(msg.Err) {
+				delete(m.commitVerifications, msg.SHA)
+			}
+		}
+		return m, nil
+
 	case JobDetailsLoadedMsg:
 		m.selectedJob = msg.Job
 		m.state = StateJobDetails
 		return m, nil
 
 	case LogLoadedMsg:
-		m.logContent = msg.Content
+		if m.logTail > 0 {
+			m.setLogTail(msg.Content, m.logTail)
+			m.scrollLogToEnd()
+		} else {
+			m.setLogContent(msg.Content)
+		}
+		m.logByteOffset = msg.Size
 		m.state = StateLogViewer
 		// Check if we should enable streaming (job might still be running)
-		return m, m.checkStreamingStatus()
+		cmd := m.checkStreamingStatus()
+		return m, cmd
+
+	case LogFailedMsg:
+		// The screen the log was opened from is still good
+		m.closeLogViewer()
+		m.setStatusMessage(msg.Err.Error(), true)
+		return m, nil
 
 	case LogUpdatedMsg:
 		// Only update if content has changed
-		if msg.Content != m.logContent {
-			m.logContent = msg.Content
+		if m.applyLogUpdate(msg) && m.logStreaming {
 			// Auto-scroll to bottom for streaming logs
-			if m.logStreaming {
-				lines := strings.Split(strings.TrimSuffix(m.logContent, "\n"), "\n")
-				maxLines := m.height - 8
-				if len(lines) > maxLines {
-					m.logScrollOffset = len(lines) - maxLines
-				}
-			}
+			m.scrollLogToEnd()
 		}
 		// Continue streaming if job is still running
 		return m, m.scheduleLogUpdate()
 
+	case LogJobUpdatedMsg:
+		// Ignore details for a job whose logs are no longer open
+		if msg.JobID == m.logJobID && msg.Job != nil {
+			m.logRunningStep = msg.Job.RunningStep()
+		}
+		return m, nil
+
 	case WorkflowLoadedMsg:
 		m.workflowContent = msg.Content
 		m.workflowPath = msg.Path
 		m.state = StateWorkflowViewer
 		return m, nil
 
+	case AnnotationsLoadedMsg:
+		m.annotations = msg.Annotations
+		m.annotationScrollOff = 0
+		m.state = StateAnnotations
+		return m, nil
+
+	case FailureDetailsLoadedMsg:
+		if m.failureJobs == nil {
+			m.failureJobs = make(map[int64]*gh.Job)
+		}
+		for _, job := range msg.Jobs {
+			m.failureJobs[job.ID] = job
+		}
+		if msg.Failed > 0 {
+			m.setStatusMessage(fmt.Sprintf("Couldn't load the steps of %d failed jobs: %v", msg.Failed, msg.Err), true)
+		}
+		// Leave the screen alone if the user moved on while this loaded
+		if m.state != StateLoading {
+			return m, nil
+		}
+		m.failureCursor = 0
+		if len(m.failedSteps()) == 0 && msg.Failed > 0 {
+			// Nothing to list; stay on the run with the error
+			m.state = StateReady
+			if m.watching {
+				m.state = StateWatching
+			}
+			return m, nil
+		}
+		m.state = StateFailureSummary
+		return m, nil
+
 	case ArtifactsLoadedMsg:
 		m.artifacts = msg.Artifacts
 		m.selectedArtifactIndex = 0
 		m.state = StateArtifactSelection
 		return m, nil
 
+	case DownloadProgressMsg:
+		if m.state == StateLoading {
+			m.loadingMessage = downloadMessage(msg.Name, msg.Done, msg.Total)
+		}
+		return m, waitForDownload(msg.next)
+
 	case ArtifactDownloadedMsg:
 		// Show success message and return to previous state
 		m.state = StateReady
@@ -429,22 +894,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.logExportTime = time.Now()
 		return m, nil
 
+	case GistCreatedMsg:
+		m.state = StateLogViewer
+		switch {
+		case msg.Err != nil:
+			m.logExportMessage = fmt.Sprintf("Gist failed: %v", msg.Err)
+		case copyToClipboard(msg.URL) != nil:
+			m.logExportMessage = "Gist created: " + msg.URL
+		default:
+			m.logExportMessage = "Gist URL copied: " + msg.URL
+		}
+		m.logExportTime = time.Now()
+		return m, nil
+
 	case ParsedLogsLoadedMsg:
 		// v0.6: Handle structured log loading for filtering
 		m.parsedLogs = msg.Logs
 		if m.parsedLogs != nil {
-			m.logContent = m.parsedLogs.Combined
+			m.setLogContent(m.parsedLogs.Combined)
 		}
 		m.state = StateLogFilter
 		return m, nil
 
+	case StepLogsLoadedMsg:
+		m.parsedLogs = msg.Logs
+		if number, ok := stepLogNumber(msg.Logs, msg.Step); ok {
+			m.logFilterStepNumbers = []int{number}
+			m.applyLogFilter()
+		} else {
+			m.logFilterStepNumbers = nil
+			m.applyLogFilter()
+			m.setStatusMessage(fmt.Sprintf("No log found for step %q, showing the whole job", msg.Step.Name), true)
+		}
+		m.state = StateLogViewer
+		return m, nil
+
 	case MultiJobLogsLoadedMsg:
 		// v0.6: Handle multi-job log loading
 		m.multiJobContents = msg.Contents
 		m.multiJobMode = true
 		m.state = StateLogViewer
 		// Build combined content from all selected jobs
-		m.logContent = m.buildMultiJobContent()
+		m.setLogContent(m.buildMultiJobContent())
 		return m, nil
 
 	case CompareLogsLoadedMsg:
@@ -456,20 +947,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = StateCompareView
 		return m, nil
 
+	case ActionResultMsg:
+		if msg.Err != nil {
+			m.setStatusMessage(msg.Err.Error(), true)
+		} else {
+			m.setStatusMessage(msg.Message, false)
+		}
+		// Refresh so the new run status shows up
+		m.loadingMessage = "Refreshing..."
+		m.state = StateLoading
+		return m, m.restartRefresh()
+
 	case TickMsg:
 		{
 			if m.state == StateLogViewer && m.logStreaming {
-				return m, m.updateLogs(m.logJobID)
+				return m, tea.Batch(m.updateLogs(m.logJobID), m.updateLogJob(m.logJobID))
 			} else if m.watching {
-				m.loadingMessage = "Watching for updates..."
-				m.state = StateLoading
-				return m, m.fetchWorkflowRuns()
+				// Poll in the background, keeping the current view on screen
+				return m, m.startRefresh()
+			}
+		}
+		return m, nil
+
+	case RefreshFailedMsg:
+		m.fetching = false
+		// A blip while watching keeps the last good data on screen and
+		// polling going; only errors that need the user end the session
+		if m.watching && (m.run != nil || len(m.sourcedRuns) > 0) && isTransientError(msg.Err) {
+			m.refreshErr = msg.Err
+			if m.state == StateLoading {
+				m.state = m.loadedState()
 			}
+			// The failed refresh ended this poll, so schedule the next one
+			return m, m.scheduleNextPoll()
 		}
+		m.err = msg.Err
+		m.state = StateError
+		m.exitCode = 2
 		return m, nil
 
 	case ErrMsg:
 		{
+			// Logs, details and actions that hit a blip leave the run on
+			// screen with the error in the status line. They aren't part of
+			// the poll cycle, so no poll is scheduled here.
+			if (m.run != nil || len(m.sourcedRuns) > 0) && isTransientError(msg.Err) {
+				if m.state == StateLoading {
+					m.state = m.loadedState()
+				}
+				m.setStatusMessage(msg.Err.Error(), true)
+				return m, nil
+			}
 			m.err = msg.Err
 			m.state = StateError
 			m.exitCode = 2
@@ -483,6 +1011,206 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// loadedState is the screen a load that kept the data on screen returns to:
+// the multi-repo dashboard, or the run and its jobs
+func (m Model) loadedState() State {
+	if m.multiRepoMode && !m.drilledIntoRepo && !m.multiRepoListView {
+		return StateDashboard
+	}
+	if m.watching {
+		return StateWatching
+	}
+	return StateReady
+}
+
+// followNewestRun moves a --watch-new session onto the newest loaded run
+// when it started after every run seen so far, so a fresh push is picked up
+// without restarting cimon. Browsing to an older run doesn't count as seeing
+// a new one, so the session stays put until another run actually starts.
+func (m *Model) followNewestRun() {
+	newest := &m.runs[0]
+	seen := m.watchNewestID
+	if newest.ID > seen {
+		m.watchNewestID = newest.ID
+	}
+	if seen == 0 || newest.ID <= seen {
+		return
+	}
+	m.selectedRunIndex = 0
+	m.run = newest
+	m.cursor = 0               // Reset job cursor
+	m.notificationSent = false // The new run gets its own completion notification
+	m.setStatusMessage(fmt.Sprintf("Following newer run #%d", newest.RunNumber), false)
+}
+
+// isTransientError reports whether err is likely to clear up by itself, like
+// a 502 or a timeout, rather than needing the user to act, like bad
+// credentials, a missing repo or SSO authorization
+func isTransientError(err error) bool {
+	var notFoundErr *gh.NotFoundError
+	if needsUserAction(err) || errors.As(err, &notFoundErr) {
+		return false
+	}
+	return gh.IsRetryable(err)
+}
+
+// needsUserAction reports whether err is about the token itself: bad
+// credentials, a missing fine-grained permission or SSO authorization
+func needsUserAction(err error) bool {
+	var authErr *gh.AuthError
+	var permErr *gh.PermissionError
+	var samlErr *gh.SAMLError
+	return errors.As(err, &authErr) || errors.As(err, &permErr) || errors.As(err, &samlErr)
+}
+
+// handleMouse maps the scroll wheel onto the up/down keys, so every view
+// scrolls the way it does from the keyboard, and a left click on a job row
+// selects that job (--mouse)
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// Prompts, help and search input treat any key as an answer; don't let the wheel answer them
+	if msg.Action != tea.MouseActionPress || m.state == StateConfirm || m.state == StateHelp || m.searchInputMode {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.handleKey(tea.KeyMsg{Type: tea.KeyUp})
+	case tea.MouseButtonWheelDown:
+		return m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	case tea.MouseButtonLeft:
+		if m.state == StateReady && !m.multiRepoMode && !m.showingJobDetails {
+			row := msg.Y - m.jobListTop()
+			if row >= 0 && row < len(m.visibleJobs()) {
+				m.cursor = row
+			}
+		}
+	}
+	return m, nil
+}
+
+// handleBranchFilterKey edits the branch filter: typed characters narrow the
+// list, backspace widens it, and esc clears it or, when it's already empty,
+// leaves branch selection. It reports whether msg was handled; arrows and
+// enter fall through to the normal navigation.
+func (m *Model) handleBranchFilterKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		m.branchFilter += string(msg.Runes)
+	case tea.KeyBackspace:
+		if r := []rune(m.branchFilter); len(r) > 0 {
+			m.branchFilter = string(r[:len(r)-1])
+		}
+	case tea.KeyEsc:
+		if m.branchFilter == "" {
+			m.state = StateReady
+			return true
+		}
+		m.branchFilter = ""
+	default:
+		return false
+	}
+	// Keep the cursor on the narrowed list
+	if n := len(m.filteredBranches()); m.selectedBranchIndex >= n {
+		m.selectedBranchIndex = max(n-1, 0)
+	}
+	return true
+}
+
+// openQuickJump opens the fuzzy finder over the run's jobs, or over the
+// loaded runs when runs is set
+func (m *Model) openQuickJump(runs bool) {
+	m.quickJumpQuery = ""
+	m.quickJumpCursor = 0
+	m.quickJumpRuns = runs
+	m.state = StateQuickJump
+}
+
+// handleQuickJumpKey edits the quick-jump query and moves through its
+// matches; it reports whether it handled the key
+func (m *Model) handleQuickJumpKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		m.quickJumpQuery += string(msg.Runes)
+		m.quickJumpCursor = 0 // The best match leads after every edit
+	case tea.KeyBackspace:
+		if r := []rune(m.quickJumpQuery); len(r) > 0 {
+			m.quickJumpQuery = string(r[:len(r)-1])
+			m.quickJumpCursor = 0
+		}
+	case tea.KeyUp:
+		m.quickJumpCursor = max(m.quickJumpCursor-1, 0)
+	case tea.KeyDown:
+		m.quickJumpCursor = max(min(m.quickJumpCursor+1, len(m.quickJumpMatches())-1), 0)
+	case tea.KeyEnter:
+		if matches := m.quickJumpMatches(); m.quickJumpCursor < len(matches) {
+			if m.quickJumpRuns {
+				m.runListCursor = matches[m.quickJumpCursor]
+			} else {
+				m.cursor = matches[m.quickJumpCursor]
+			}
+		}
+		m.closeQuickJump()
+	case tea.KeyEsc:
+		if m.quickJumpQuery == "" {
+			m.closeQuickJump()
+			return true
+		}
+		m.quickJumpQuery = ""
+		m.quickJumpCursor = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// closeQuickJump returns to the list the finder was opened from
+func (m *Model) closeQuickJump() {
+	if m.quickJumpRuns {
+		m.state = StateRunList
+	} else {
+		m.state = StateReady
+	}
+}
+
+// quickJumpItems returns the labels the quick-jump finder searches: the
+// visible jobs' names, or the loaded runs' numbers, names and branches
+func (m Model) quickJumpItems() []string {
+	var items []string
+	if m.quickJumpRuns {
+		for i := range m.runs {
+			run := &m.runs[i]
+			items = append(items, fmt.Sprintf("#%d %s %s", run.RunNumber, run.DisplayName(), run.HeadBranch))
+		}
+		return items
+	}
+	for _, job := range m.visibleJobs() {
+		items = append(items, job.Name)
+	}
+	return items
+}
+
+// quickJumpMatches returns the indexes of the quick-jump items matching the
+// query, best match first
+func (m Model) quickJumpMatches() []int {
+	return fuzzyFilter(m.quickJumpQuery, m.quickJumpItems())
+}
+
+// filteredBranches returns the branches whose names contain the branch
+// filter, ignoring case
+func (m Model) filteredBranches() []gh.Branch {
+	if m.branchFilter == "" {
+		return m.branches
+	}
+	filter := strings.ToLower(m.branchFilter)
+	var filtered []gh.Branch
+	for _, branch := range m.branches {
+		if strings.Contains(strings.ToLower(branch.Name), filter) {
+			filtered = append(filtered, branch)
+		}
+	}
+	return filtered
+}
+
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle search input mode first
 	if m.searchInputMode {
@@ -493,7 +1221,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchInputMode = false
 			m.findSearchMatches()
 			if len(m.logSearchMatches) > 0 {
-				m.scrollToLine(m.logSearchMatches[0])
+				m.scrollToLine(m.logSearchMatches[0].line)
 			}
 			return m, nil
 		case tea.KeyEsc:
@@ -516,30 +1244,91 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// Handle help state - any key exits (except q which quits)
-	if m.state == StateHelp && !key.Matches(msg, m.keys.Quit) {
+	// Handle confirmation prompt - y confirms, x rejects (if offered), any other key cancels
+	if m.state == StateConfirm {
+		action, reject, fromLogs := m.confirmAction, m.rejectAction, m.confirmFromLogs
+		m.confirmAction = nil
+		m.rejectAction = nil
+		m.confirmMessage = ""
+		m.confirmFromLogs = false
+		if msg.String() == "y" || msg.String() == "Y" {
+			m.loadingMessage = "Sending request..."
+			m.state = StateLoading
+			return m, action
+		}
+		if reject != nil && msg.String() == "x" {
+			m.loadingMessage = "Sending request..."
+			m.state = StateLoading
+			return m, reject
+		}
+		if fromLogs {
+			m.state = StateLogViewer
+			return m, nil
+		}
 		m.state = StateReady
 		return m, nil
 	}
 
-	switch {
-	case key.Matches(msg, m.keys.Quit):
-		return m, tea.Quit
+	// Handle help state - any key returns to the screen help was opened
+	// from (except q which quits)
+	if m.state == StateHelp && !key.Matches(msg, m.keys.Quit) {
+		m.state = m.helpReturnState
+		// The refresh help was opened over may have finished while it was
+		// open, or the watched run may have completed
+		if (m.state == StateLoading && !m.fetching) || (m.state == StateWatching && !m.watching) {
+			m.state = StateReady
+			if m.watching {
+				m.state = StateWatching
+			}
+		}
+		return m, nil
+	}
 
-	case key.Matches(msg, m.keys.Refresh):
-		if m.err != nil {
-			// If we have an error, retry the last operation
-			m.err = nil
+	// Branch selection filters as you type, so letters don't trigger shortcuts
+	if m.state == StateBranchSelection && m.handleBranchFilterKey(msg) {
+		return m, nil
+	}
+	if m.state == StateQuickJump && m.handleQuickJumpKey(msg) {
+		return m, nil
+	}
+
+	// ? opens help from any other screen; the filters above take it as text
+	if key.Matches(msg, m.keys.Help) {
+		m.helpReturnState = m.state
+		m.state = StateHelp
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Refresh):
+		if m.fetching {
+			// A refresh is already on its way; don't start another
+			return m, nil
+		}
+		if m.err != nil {
+			// If we have an error, retry the last operation
+			m.err = nil
 			m.state = StateLoading
-			return m, m.fetchWorkflowRuns()
+			return m, m.startRefresh()
 		} else {
 			// Normal refresh
 			m.state = StateLoading
-			return m, m.fetchWorkflowRuns()
+			return m, m.startRefresh()
 		}
 
 	case key.Matches(msg, m.keys.Watch):
 		m.watching = !m.watching
+		if m.state == StateDashboard {
+			// Stay on the dashboard; polling refreshes it in place
+			if m.watching {
+				m.notificationSent = false
+				return m, m.scheduleNextPoll()
+			}
+			return m, nil
+		}
 		if m.watching {
 			m.notificationSent = false // v0.7: Reset for new watch session
 			m.state = StateWatching
@@ -551,6 +1340,21 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Open):
 		return m, m.openInBrowser()
 
+	case key.Matches(msg, m.keys.OpenChange):
+		return m, m.openChange()
+
+	case key.Matches(msg, m.keys.CopyCommand):
+		return m, m.copyRunCommand()
+
+	case key.Matches(msg, m.keys.Compact):
+		m.compact = !m.compact
+		return m, nil
+
+	case key.Matches(msg, m.keys.TimeZone):
+		m.config.UTC = !m.config.UTC
+		m.setStatusMessage("Showing times in "+m.config.TimeZoneLabel(), false)
+		return m, nil
+
 	case key.Matches(msg, m.keys.Up):
 		if m.state == StateLogViewer {
 			// Scroll up in log viewer
@@ -592,6 +1396,27 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.compareScrollOff > 0 {
 				m.compareScrollOff--
 			}
+		} else if m.state == StateAnnotations {
+			if m.annotationScrollOff > 0 {
+				m.annotationScrollOff--
+			}
+		} else if m.state == StateWorkflowViewer {
+			if m.workflowScrollOffset > 0 {
+				m.workflowScrollOffset--
+			}
+		} else if m.state == StateDashboard {
+			// Navigate dashboard repos up
+			if m.dashboardCursor > 0 {
+				m.dashboardCursor--
+			}
+		} else if m.state == StateRunList {
+			if m.runListCursor > 0 {
+				m.runListCursor--
+			}
+		} else if m.state == StateFailureSummary {
+			if m.failureCursor > 0 {
+				m.failureCursor--
+			}
 		} else if m.multiRepoMode && m.state == StateReady {
 			// v0.8: Navigate multi-repo runs up
 			if m.selectedSourcedRun > 0 {
@@ -612,14 +1437,13 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Down):
 		if m.state == StateLogViewer {
 			// Scroll down in log viewer
-			lines := strings.Split(strings.TrimSuffix(m.logContent, "\n"), "\n")
-			maxScroll := len(lines) - (m.height - 8) // Approximate visible lines
+			maxScroll := len(m.logLines) - (m.height - 8) // Approximate visible lines
 			if maxScroll > 0 && m.logScrollOffset < maxScroll {
 				m.logScrollOffset++
 			}
 		} else if m.state == StateBranchSelection {
 			// Navigate branches down
-			if m.selectedBranchIndex < len(m.branches)-1 {
+			if m.selectedBranchIndex < len(m.filteredBranches())-1 {
 				m.selectedBranchIndex++
 			}
 		} else if m.state == StateStatusFilter {
@@ -653,6 +1477,29 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if maxScroll > 0 && m.compareScrollOff < maxScroll {
 				m.compareScrollOff++
 			}
+		} else if m.state == StateAnnotations {
+			maxScroll := len(annotationLines(m.annotations)) - (m.height - 10)
+			if maxScroll > 0 && m.annotationScrollOff < maxScroll {
+				m.annotationScrollOff++
+			}
+		} else if m.state == StateWorkflowViewer {
+			maxScroll := strings.Count(strings.TrimSuffix(m.workflowContent, "\n"), "\n") + 1 - (m.height - 10)
+			if maxScroll > 0 && m.workflowScrollOffset < maxScroll {
+				m.workflowScrollOffset++
+			}
+		} else if m.state == StateDashboard {
+			// Navigate dashboard repos down
+			if m.dashboardCursor < len(m.config.Repositories)-1 {
+				m.dashboardCursor++
+			}
+		} else if m.state == StateRunList {
+			if m.runListCursor < len(m.runs)-1 {
+				m.runListCursor++
+			}
+		} else if m.state == StateFailureSummary {
+			if m.failureCursor < len(m.failedSteps())-1 {
+				m.failureCursor++
+			}
 		} else if m.multiRepoMode && m.state == StateReady {
 			// v0.8: Navigate multi-repo runs down
 			if m.selectedSourcedRun < len(m.sourcedRuns)-1 {
@@ -663,7 +1510,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.jobDetailsCursor++
 			}
 		} else {
-			if m.cursor < len(m.jobs)-1 {
+			if m.cursor < len(m.visibleJobs())-1 {
 				m.cursor++
 			}
 		}
@@ -703,6 +1550,12 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+		} else if m.state == StateRunList {
+			return m, m.selectRunFromList()
+		} else if m.state == StateFailureSummary {
+			return m, m.openFailedStep()
+		} else if m.state == StateDashboard && m.dashboardCursor < len(m.config.Repositories) {
+			return m, m.drillIntoRepo(m.dashboardCursor)
 		} else if m.multiRepoMode && m.state == StateReady && len(m.sourcedRuns) > 0 {
 			// v0.8: Select multi-repo run and load its jobs
 			sr := m.sourcedRuns[m.selectedSourcedRun]
@@ -713,28 +1566,29 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.loadingMessage = fmt.Sprintf("Loading jobs for %s...", sr.RepoSlug())
 			m.state = StateLoading
 			return m, m.fetchJobs()
-		} else if m.state == StateReady && len(m.jobs) > 0 && m.cursor >= 0 && m.cursor < len(m.jobs) {
+		} else if jobs := m.visibleJobs(); m.state == StateReady && len(jobs) > 0 && m.cursor >= 0 && m.cursor < len(jobs) {
 			// Enter job details mode
 			m.showingJobDetails = true
 			m.jobDetailsCursor = 0
-			job := m.jobs[m.cursor]
+			job := jobs[m.cursor]
 			return m, m.fetchJobDetails(job.ID)
+		} else if step, ok := m.selectedStep(); ok {
+			// Open the selected step's log
+			return m, m.openStepLogs(step)
 		} else if m.state == StateJobDetails {
-			// Exit job details mode
-			m.showingJobDetails = false
-			m.selectedJob = nil
-			m.jobDetailsCursor = 0
-			m.state = StateReady
+			m.exitJobDetails()
 			return m, nil
 		} else if m.state == StateBranchSelection {
 			// Select the current branch and reload runs
-			if len(m.branches) > 0 && m.selectedBranchIndex >= 0 && m.selectedBranchIndex < len(m.branches) {
-				selectedBranch := m.branches[m.selectedBranchIndex]
+			branches := m.filteredBranches()
+			if len(branches) > 0 && m.selectedBranchIndex >= 0 && m.selectedBranchIndex < len(branches) {
+				selectedBranch := branches[m.selectedBranchIndex]
 				m.config.Branch = selectedBranch.Name
+				m.config.BranchPattern = "" // A picked branch replaces --branch-pattern
 				m.loadingMessage = fmt.Sprintf("Switching to branch '%s'...", selectedBranch.Name)
 				m.state = StateLoading
 				m.selectedRunIndex = 0
-				return m, m.fetchWorkflowRuns()
+				return m, tea.Batch(m.restartRefresh(), m.saveState())
 			}
 		} else if m.state == StateStatusFilter {
 			// Apply selected filter and reload runs
@@ -743,7 +1597,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.loadingMessage = fmt.Sprintf("Applying '%s' filter...", m.statusFilterOptions[m.selectedFilterIndex])
 				m.state = StateLoading
 				m.selectedRunIndex = 0
-				return m, m.fetchWorkflowRuns()
+				return m, tea.Batch(m.restartRefresh(), m.saveState())
 			}
 		} else if m.state == StateArtifactSelection {
 			// Download selected artifact
@@ -758,40 +1612,26 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.TailLogs):
+		// Tail-only logs: the last --tail lines (or defaultTailLines), scrolled to the end
+		tail := m.config.Tail
+		if tail == 0 {
+			tail = defaultTailLines
+		}
+		if jobID, ok := m.logTargetJob(); ok {
+			return m, m.openLogs(jobID, tail)
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Logs):
-		if m.state == StateReady && len(m.jobs) > 0 && m.cursor >= 0 && m.cursor < len(m.jobs) {
-			// View logs for selected job
-			job := m.jobs[m.cursor]
-			m.showingLogs = true
-			m.logScrollOffset = 0
-			m.logSearchTerm = ""
-			m.logSearchIndex = 0
-			m.logJobID = job.ID
-			m.logLastFetch = time.Now()
-			return m, m.fetchLogs(job.ID)
-		} else if m.state == StateJobDetails && m.selectedJob != nil {
-			// View logs for selected job in details view
-			m.showingLogs = true
-			m.logScrollOffset = 0
-			m.logSearchTerm = ""
-			m.logSearchIndex = 0
-			m.logJobID = m.selectedJob.ID
-			m.logLastFetch = time.Now()
-			return m, m.fetchLogs(m.selectedJob.ID)
+		if step, ok := m.selectedStep(); ok {
+			// In job details, l opens the selected step's log
+			return m, m.openStepLogs(step)
+		} else if jobID, ok := m.logTargetJob(); ok {
+			// View logs for the selected job, tailed if --tail is set
+			return m, m.openLogs(jobID, m.config.Tail)
 		} else if m.state == StateLogViewer {
-			// Exit log viewer
-			m.showingLogs = false
-			m.logContent = ""
-			m.logScrollOffset = 0
-			m.logSearchTerm = ""
-			m.logSearchIndex = 0
-			m.logJobID = 0
-			m.logStreaming = false
-			if m.selectedJob != nil {
-				m.state = StateJobDetails
-			} else {
-				m.state = StateReady
-			}
+			m.closeLogViewer()
 		}
 		return m, nil
 
@@ -840,6 +1680,35 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.NextRepo):
+		return m, m.cycleRepo(1)
+
+	case key.Matches(msg, m.keys.PrevRepo):
+		return m, m.cycleRepo(-1)
+
+	case key.Matches(msg, m.keys.QuickJump):
+		if m.state == StateRunList && len(m.runs) > 0 {
+			m.openQuickJump(true)
+		} else if m.state == StateReady && !m.multiRepoMode && !m.showingJobDetails && len(m.visibleJobs()) > 0 {
+			m.openQuickJump(false)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Attempt):
+		if m.state == StateReady && !m.multiRepoMode && !m.showingJobDetails && m.run != nil && m.run.RunAttempt > 1 {
+			return m, m.cycleAttempt()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.RunList):
+		if m.state == StateRunList {
+			m.state = StateReady
+		} else if m.state == StateReady && !m.multiRepoMode && !m.showingJobDetails && len(m.runs) > 0 {
+			m.runListCursor = m.selectedRunIndex
+			m.state = StateRunList
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Filter):
 		if m.state == StateReady && !m.showingJobDetails && !m.showingLogs {
 			// Enter status filter mode
@@ -853,17 +1722,95 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.loadingMessage = fmt.Sprintf("Applying '%s' filter...", m.statusFilterOptions[m.selectedFilterIndex])
 				m.state = StateLoading
 				m.selectedRunIndex = 0
-				return m, m.fetchWorkflowRuns()
+				return m, tea.Batch(m.restartRefresh(), m.saveState())
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.EventFilter):
+		// Cycle the event filter; GitHub filters by event, so reload runs
+		if m.state == StateReady && !m.showingJobDetails && !m.showingLogs {
+			m.eventFilter = nextEventFilter(m.eventFilter)
+			m.loadingMessage = fmt.Sprintf("Showing %s runs...", eventFilterLabel(m.eventFilter))
+			m.state = StateLoading
+			m.selectedRunIndex = 0
+			m.selectedSourcedRun = 0
+			return m, m.restartRefresh()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.JobFilter):
+		// Cycle the job-level status filter (client-side, no refetch)
+		if m.state == StateReady && !m.showingJobDetails && len(m.jobs) > 0 {
+			selectedID := m.selectedJobID()
+			m.jobStatusFilter = nextJobStatusFilter(m.jobStatusFilter)
+			m.selectJobByID(selectedID)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.JobSort):
+		// Cycle the job sort order, keeping the cursor on the same job
+		if m.state == StateReady && !m.showingJobDetails && len(m.jobs) > 0 {
+			selectedID := m.selectedJobID()
+			m.jobSort = nextJobSort(m.jobSort)
+			m.selectJobByID(selectedID)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Rerun):
+		if m.state == StateReady && m.run != nil {
+			m.confirmMessage = fmt.Sprintf("Rerun workflow #%d (%s) on %s?", m.run.RunNumber, m.run.DisplayName(), m.config.RepoSlug())
+			m.confirmAction = m.rerunWorkflow()
+			m.state = StateConfirm
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.RerunJob):
+		if jobs := m.visibleJobs(); m.state == StateReady && m.run != nil && m.cursor >= 0 && m.cursor < len(jobs) {
+			job := jobs[m.cursor]
+			if !job.IsFailure() {
+				m.setStatusMessage(fmt.Sprintf("Job %s didn't fail; press %s to rerun the whole workflow", job.Name, m.keys.Rerun.Help().Key), true)
+				return m, nil
+			}
+			m.confirmMessage = fmt.Sprintf("Rerun job %s of workflow #%d (%s) on %s?", job.Name, m.run.RunNumber, m.run.DisplayName(), m.config.RepoSlug())
+			m.confirmAction = m.rerunJob(job)
+			m.state = StateConfirm
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.CancelRun):
+		if m.state == StateReady && m.run != nil {
+			// Mirror the CLI guard: only running or queued workflows can be cancelled
+			if m.run.Status != gh.StatusInProgress && m.run.Status != gh.StatusQueued {
+				m.setStatusMessage(fmt.Sprintf("Workflow #%d is not running (status: %s)", m.run.RunNumber, m.run.Status), true)
+				return m, nil
 			}
+			m.confirmMessage = fmt.Sprintf("Cancel workflow #%d (%s) on %s?", m.run.RunNumber, m.run.DisplayName(), m.config.RepoSlug())
+			m.confirmAction = m.cancelWorkflow()
+			m.state = StateConfirm
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.Help):
-		if m.state != StateHelp {
-			// Enter help mode
-			m.state = StateHelp
+	case key.Matches(msg, m.keys.Deployments):
+		if m.state == StateReady && m.run != nil {
+			if m.run.IsActionRequired() {
+				// GitHub has no API to approve these; the run's page does
+				return m, m.openActionRequired()
+			}
+			if len(m.pendingDeployments) == 0 {
+				m.setStatusMessage(fmt.Sprintf("Workflow #%d is not waiting for approval", m.run.RunNumber), true)
+				return m, nil
+			}
+			ids := gh.EnvironmentIDs(m.pendingDeployments)
+			if len(ids) == 0 {
+				m.setStatusMessage("You are not a required reviewer for these environments", true)
+				return m, nil
+			}
+			m.confirmMessage = fmt.Sprintf("Deploy workflow #%d to %s?", m.run.RunNumber, pendingEnvironmentNames(m.pendingDeployments))
+			m.confirmAction = m.reviewDeployment(ids, true)
+			m.rejectAction = m.reviewDeployment(ids, false)
+			m.state = StateConfirm
 		}
-		// Note: exiting help with any key is handled at the top of handleKey
 		return m, nil
 
 	case key.Matches(msg, m.keys.Workflow):
@@ -877,6 +1824,24 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Annotations):
+		if m.state == StateAnnotations {
+			m.state = StateReady
+		} else if m.state == StateReady && m.run != nil {
+			m.loadingMessage = "Loading annotations..."
+			m.state = StateLoading
+			return m, m.fetchAnnotations()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Failures):
+		if m.state == StateFailureSummary {
+			m.state = StateReady
+		} else if m.state == StateReady && !m.multiRepoMode && !m.showingJobDetails && m.run != nil {
+			return m, m.openFailureSummary()
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Artifacts):
 		if m.run != nil {
 			// Enter artifact selection mode
@@ -893,6 +1858,42 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.LineNumbers):
+		if m.state == StateLogViewer {
+			m.logLineNumbers = !m.logLineNumbers
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Timestamps):
+		if m.state == StateLogViewer {
+			m.logHideTimestamps = !m.logHideTimestamps
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.ScrollLeft):
+		if m.state == StateLogViewer {
+			m.logHScrollOffset = max(m.logHScrollOffset-logHScrollStep, 0)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.ScrollRight):
+		if m.state == StateLogViewer {
+			m.logHScrollOffset = min(m.logHScrollOffset+logHScrollStep, m.maxLogHScroll(m.logTextWidth()))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.PrevStep):
+		if m.state == StateLogViewer {
+			m.jumpToStep(false)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextStep):
+		if m.state == StateLogViewer {
+			m.jumpToStep(true)
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.LogSave):
 		// v0.6: Export logs to file
 		if m.state == StateLogViewer && m.logContent != "" {
@@ -900,6 +1901,16 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.LogGist):
+		// A gist is remote state that outlives the session, so ask first
+		if m.state == StateLogViewer && m.logContent != "" {
+			m.confirmMessage = "Share this log as a secret gist? Anyone with the link can read it."
+			m.confirmAction = m.createLogGist()
+			m.confirmFromLogs = true
+			m.state = StateConfirm
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.LogFilter):
 		// v0.6: Enter log filter selection mode
 		if m.state == StateLogViewer && m.logJobID != 0 {
@@ -915,7 +1926,38 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Dashboard):
+		if m.drilledIntoRepo && m.state == StateReady {
+			m.returnToDashboard()
+		} else if m.state == StateDashboard {
+			// Switch to the time-sorted list of runs across repos
+			m.multiRepoListView = true
+			if len(m.sourcedRuns) > 0 {
+				if m.selectedSourcedRun >= len(m.sourcedRuns) {
+					m.selectedSourcedRun = 0
+				}
+				sr := m.sourcedRuns[m.selectedSourcedRun]
+				m.run = sr.Run
+				m.config.Owner = sr.Owner
+				m.config.Repo = sr.Repo
+			}
+			m.state = StateReady
+		} else if m.multiRepoMode && m.state == StateReady {
+			m.multiRepoListView = false
+			m.state = StateDashboard
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Escape):
+		// Return from a drilled-in repo to the dashboard
+		if m.drilledIntoRepo && m.state == StateReady {
+			m.returnToDashboard()
+			return m, nil
+		}
+		if m.state == StateJobDetails {
+			m.exitJobDetails()
+			return m, nil
+		}
 		// Exit from filter mode without applying
 		if m.state == StateLogFilter {
 			m.state = StateLogViewer
@@ -926,8 +1968,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.state = StateReady
 			return m, nil
 		}
-		// v0.6: Exit from compare selection or view
-		if m.state == StateCompareSelect || m.state == StateCompareView {
+		// Exit from compare selection/view, the annotations panel or run history
+		if m.state == StateCompareSelect || m.state == StateCompareView || m.state == StateAnnotations || m.state == StateRunList || m.state == StateFailureSummary {
 			m.state = StateReady
 			return m, nil
 		}
@@ -971,7 +2013,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// v0.6: Toggle between split and combined view in multi-job mode
 		if m.state == StateLogViewer && m.multiJobMode {
 			m.multiJobViewSplit = !m.multiJobViewSplit
-			m.logContent = m.buildMultiJobContent()
+			m.setLogContent(m.buildMultiJobContent())
 			return m, nil
 		}
 		return m, nil
@@ -1019,204 +2061,1410 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // Commands
 
 func (m Model) fetchWorkflowRuns() tea.Cmd {
+	if m.config.BranchPattern != "" {
+		return m.fetchBranchPatternRuns()
+	}
 	return func() tea.Msg {
-		runs, err := m.client.FetchWorkflowRuns(m.config.Owner, m.config.Repo, m.config.Branch, m.currentStatusFilter, 1, 10) // Fetch 10 most recent runs with current filter
+		runs, err := m.client.FetchWorkflowRuns(m.config.Owner, m.config.Repo, m.config.Branch, m.currentStatusFilter, m.eventFilter, m.config.CreatedFilter(), 1, 10) // Fetch 10 most recent runs with current filter
 		if err != nil {
-			return ErrMsg{Err: err}
+			return RefreshFailedMsg{Err: err}
 		}
 
 		if len(runs) == 0 {
-			return ErrMsg{Err: fmt.Errorf("no workflow runs found")}
+			if m.currentStatusFilter != "" || m.eventFilter != "" || m.config.CreatedFilter() != "" {
+				return RefreshFailedMsg{Err: fmt.Errorf("no workflow runs found matching the filters")}
+			}
+			// Say whether the repo has no workflows or they just haven't run here
+			return RefreshFailedMsg{Err: gh.ExplainNoRuns(m.client, m.config.Owner, m.config.Repo, m.config.BranchLabel())}
 		}
 
 		return RunsLoadedMsg{Runs: runs}
 	}
 }
 
-// fetchMultiRepoRuns fetches runs from all configured repositories (v0.8)
-func (m Model) fetchMultiRepoRuns() tea.Cmd {
-	return func() tea.Msg {
-		var allRuns []gh.SourcedRun
+// branchPatternRunsPerBranch is how many recent runs --branch-pattern
+// fetches from each matching branch
+const branchPatternRunsPerBranch = 5
 
-		for _, repo := range m.config.Repositories {
-			runs, err := m.client.FetchWorkflowRuns(
-				repo.Owner, repo.Repo, repo.Branch,
-				m.currentStatusFilter, 1, 5, // Fetch 5 recent runs per repo
-			)
-			if err != nil {
-				// Log error but continue with other repos
-				continue
-			}
+// fetchBranchPatternRuns fetches recent runs from every branch matching
+// --branch-pattern and merges them, newest first
+func (m Model) fetchBranchPatternRuns() tea.Cmd {
+	owner, repo, pattern := m.config.Owner, m.config.Repo, m.config.BranchPattern
+	status, event, created := m.currentStatusFilter, m.eventFilter, m.config.CreatedFilter()
+	return func() tea.Msg {
+		branches, err := m.client.FetchBranches(owner, repo)
+		if err != nil {
+			return RefreshFailedMsg{Err: err}
+		}
+		names, err := gh.MatchBranches(branches, pattern)
+		if err != nil {
+			return RefreshFailedMsg{Err: err}
+		}
+		if len(names) == 0 {
+			return RefreshFailedMsg{Err: fmt.Errorf("no branches match %q", pattern)}
+		}
 
-			for i := range runs {
-				allRuns = append(allRuns, gh.SourcedRun{
-					Owner: repo.Owner,
-					Repo:  repo.Repo,
-					Run:   &runs[i],
-				})
-			}
+		// Each branch is fetched like a repo in multi-repo mode
+		specs := make([]config.RepoSpec, len(names))
+		for i, name := range names {
+			specs[i] = config.RepoSpec{Owner: owner, Repo: repo, Branch: name}
+		}
+		sourced, failures := fetchRepoRuns(specs, multiRepoWorkers, multiRepoContext,
+			func(ctx context.Context, spec config.RepoSpec) ([]gh.WorkflowRun, error) {
+				return m.client.FetchWorkflowRunsContext(ctx, owner, repo, spec.Branch, status, event, created, 1, branchPatternRunsPerBranch)
+			})
+		if len(failures) > 0 {
+			return RefreshFailedMsg{Err: fmt.Errorf("%d of %d branches failed to load: %w", len(failures), len(names), failures[0].Err)}
 		}
 
-		// Sort by UpdatedAt descending (most recent first)
-		sort.Slice(allRuns, func(i, j int) bool {
-			return allRuns[i].Run.UpdatedAt.After(allRuns[j].Run.UpdatedAt)
+		runs := make([]gh.WorkflowRun, 0, len(sourced))
+		for _, sr := range sourced {
+			runs = append(runs, *sr.Run)
+		}
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].CreatedAt.After(runs[j].CreatedAt)
 		})
-
-		if len(allRuns) == 0 {
-			return ErrMsg{Err: fmt.Errorf("no workflow runs found across repositories")}
+		if len(runs) == 0 {
+			return RefreshFailedMsg{Err: fmt.Errorf("no workflow runs found on branches matching %q", pattern)}
 		}
-
-		return MultiRepoRunsLoadedMsg{SourcedRuns: allRuns}
+		return RunsLoadedMsg{Runs: runs}
 	}
 }
 
-func (m Model) fetchJobs() tea.Cmd {
+// fetchSelectedRun fetches the run picked with --run/--run-id
+func (m Model) fetchSelectedRun() tea.Cmd {
 	return func() tea.Msg {
-		if m.run == nil {
-			return JobsLoadedMsg{Jobs: nil}
-		}
-		jobs, err := m.client.FetchJobs(m.config.Owner, m.config.Repo, m.run.ID)
+		run, err := m.client.FetchRun(m.config.Owner, m.config.Repo, m.config.RunID)
 		if err != nil {
-			return ErrMsg{Err: err}
+			return RefreshFailedMsg{Err: err}
 		}
-		return JobsLoadedMsg{Jobs: jobs}
+		return RunLoadedMsg{Run: run}
 	}
 }
 
-func (m Model) fetchJobDetails(jobID int64) tea.Cmd {
+// saveState remembers the branch and status filter for this repo so the next
+// session starts with them (disabled with --no-state). A repo drilled into
+// from the dashboard takes its branch and filter from cimon.yml, so it isn't saved.
+func (m Model) saveState() tea.Cmd {
+	if m.config.StatePath == "" || m.multiRepoMode || m.drilledIntoRepo || m.config.BranchPattern != "" {
+		return nil
+	}
+	path, slug := m.config.StatePath, m.config.RepoSlug()
+	repoState := config.RepoState{Branch: m.config.Branch, StatusFilter: m.currentStatusFilter}
 	return func() tea.Msg {
-		job, err := m.client.FetchJobDetails(m.config.Owner, m.config.Repo, jobID)
-		if err != nil {
-			return ErrMsg{Err: err}
-		}
-		return JobDetailsLoadedMsg{Job: job}
+		// Best effort: failing to save shouldn't interrupt the session
+		_ = config.SaveRepoState(path, slug, repoState)
+		return nil
 	}
 }
 
-func (m Model) fetchLogs(jobID int64) tea.Cmd {
-	return func() tea.Msg {
-		logs, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobID)
-		if err != nil {
-			return ErrMsg{Err: err}
-		}
-		return LogLoadedMsg{Content: logs}
+// startRefresh reloads runs like refreshRuns, unless a refresh is already in
+// flight, so a poll and a keypress landing together fetch only once
+func (m *Model) startRefresh() tea.Cmd {
+	if m.fetching {
+		return nil
 	}
+	m.fetching = true
+	return m.refreshRuns()
 }
 
-func (m Model) updateLogs(jobID int64) tea.Cmd {
-	return func() tea.Msg {
-		logs, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobID)
-		if err != nil {
-			// Don't return error for streaming updates, just ignore
-			return LogUpdatedMsg{Content: m.logContent}
-		}
-		return LogUpdatedMsg{Content: logs}
+// restartRefresh reloads runs after the filters changed. Unlike startRefresh
+// it doesn't wait for a refresh in flight, which is for the old filters.
+func (m *Model) restartRefresh() tea.Cmd {
+	m.fetching = true
+	return m.refreshRuns()
+}
+
+// selectRunFromList opens the run highlighted in the run history table,
+// loading its jobs if it isn't the current run
+func (m *Model) selectRunFromList() tea.Cmd {
+	m.state = StateReady
+	if m.runListCursor < 0 || m.runListCursor >= len(m.runs) || m.runListCursor == m.selectedRunIndex {
+		return nil
 	}
+	m.selectedRunIndex = m.runListCursor
+	m.run = &m.runs[m.selectedRunIndex]
+	m.cursor = 0 // Reset job cursor
+	return m.fetchJobs()
 }
 
-func (m Model) fetchWorkflowContent() tea.Cmd {
-	return func() tea.Msg {
-		content, err := m.client.FetchWorkflowContent(m.config.Owner, m.config.Repo, m.workflowPath)
-		if err != nil {
-			return ErrMsg{Err: err}
-		}
-		return WorkflowLoadedMsg{Content: content, Path: m.workflowPath}
+// showsRefreshedRuns reports whether newly loaded runs should take over the
+// screen: after a loading screen, or on the run list or dashboard they update
+func (m Model) showsRefreshedRuns() bool {
+	switch m.state {
+	case StateLoading, StateReady, StateWatching, StateDashboard:
+		return true
+	default:
+		return false
 	}
 }
 
-func (m Model) fetchArtifacts() tea.Cmd {
-	return func() tea.Msg {
-		if m.run == nil {
-			return ArtifactsLoadedMsg{Artifacts: nil}
-		}
-		artifacts, err := m.client.FetchWorkflowArtifacts(m.config.Owner, m.config.Repo, m.run.ID)
-		if err != nil {
-			return ErrMsg{Err: err}
-		}
-		return ArtifactsLoadedMsg{Artifacts: artifacts}
+// refreshRuns reloads runs for the current mode (single or multi-repo)
+func (m Model) refreshRuns() tea.Cmd {
+	if m.multiRepoMode {
+		return m.fetchMultiRepoRuns()
 	}
+	// Keep polling the selected run until the user browses the run list
+	if m.config.RunID != 0 && len(m.runs) == 0 {
+		return m.fetchSelectedRun()
+	}
+	return m.fetchWorkflowRuns()
 }
 
-func (m Model) downloadArtifact(artifact gh.Artifact) tea.Cmd {
-	return func() tea.Msg {
-		filename := fmt.Sprintf("%s.zip", artifact.Name)
-		err := m.client.DownloadArtifact(m.config.Owner, m.config.Repo, artifact.ID, filename)
-		if err != nil {
-			return ErrMsg{Err: err}
-		}
-		return ArtifactDownloadedMsg{Filename: filename}
+// drillIntoRepo shows the runs of the i'th configured repo in the
+// single-repo view, keeping the dashboard cursor on it for the way back
+func (m *Model) drillIntoRepo(i int) tea.Cmd {
+	spec := m.config.Repositories[i]
+	if !m.drilledIntoRepo {
+		m.dashboardStatus = m.currentStatusFilter
+		m.dashboardConfig = m.config
 	}
+	m.dashboardCursor = i
+	// Point the model at a copy, so the dashboard's config (shared with the
+	// caller) keeps its own repo and branch
+	repoConfig := *m.dashboardConfig
+	repoConfig.Owner = spec.Owner
+	repoConfig.Repo = spec.Repo
+	repoConfig.Branch = spec.Branch
+	repoConfig.UTC = m.config.UTC
+	m.config = &repoConfig
+	m.currentStatusFilter = repoStatusFilter(spec, m.dashboardStatus)
+	m.multiRepoMode = false
+	m.drilledIntoRepo = true
+	m.runs = nil
+	m.run = nil
+	m.jobs = nil
+	m.jobsErr = nil
+	m.selectedRunIndex = 0
+	m.cursor = 0
+	m.loadingMessage = fmt.Sprintf("Loading runs for %s...", spec.Slug())
+	m.state = StateLoading
+	m.fetching = true
+	return m.fetchWorkflowRuns()
 }
 
-// exportCurrentLogs exports the current log content to a file (v0.6)
-func (m Model) exportCurrentLogs() tea.Cmd {
-	return func() tea.Msg {
-		// Generate filename: cimon-logs-REPO-RUNID-TIMESTAMP.txt
-		timestamp := time.Now().Format("20060102-150405")
-		filename := fmt.Sprintf("cimon-logs-%s-%d-%s.txt",
-			m.config.Repo, m.run.ID, timestamp)
-
-		// Build content with metadata header
-		var content strings.Builder
-		content.WriteString("# Cimon Log Export\n")
-		content.WriteString(fmt.Sprintf("# Repository: %s/%s\n", m.config.Owner, m.config.Repo))
-		content.WriteString(fmt.Sprintf("# Branch: %s\n", m.config.Branch))
-		if m.run != nil {
-			content.WriteString(fmt.Sprintf("# Run: #%d (ID: %d)\n", m.run.RunNumber, m.run.ID))
-		}
-		content.WriteString(fmt.Sprintf("# Job ID: %d\n", m.logJobID))
-		content.WriteString(fmt.Sprintf("# Exported: %s\n", time.Now().Format(time.RFC3339)))
-		content.WriteString("#\n\n")
-		content.WriteString(m.logContent)
+// cycleRepo moves a drilled-in view to the next (or previous) configured
+// repo, wrapping around like browser tabs
+func (m *Model) cycleRepo(step int) tea.Cmd {
+	n := len(m.config.Repositories)
+	if !m.drilledIntoRepo || n < 2 || (m.state != StateReady && m.state != StateWatching) {
+		return nil
+	}
+	return m.drillIntoRepo(((m.dashboardCursor+step)%n + n) % n)
+}
 
-		err := os.WriteFile(filename, []byte(content.String()), 0644)
-		return LogExportedMsg{Filename: filename, Error: err}
+// returnToDashboard leaves a drilled-in repo and shows the multi-repo dashboard
+func (m *Model) returnToDashboard() {
+	if m.dashboardConfig != nil {
+		m.dashboardConfig.UTC = m.config.UTC // Keep a U pressed while drilled in
+		m.config = m.dashboardConfig
+		m.dashboardConfig = nil
 	}
+	m.multiRepoMode = true
+	m.drilledIntoRepo = false
+	m.currentStatusFilter = m.dashboardStatus
+	m.multiRepoListView = false
+	m.runs = nil
+	m.run = nil
+	m.jobs = nil
+	m.cursor = 0
+	m.state = StateDashboard
 }
 
-// fetchLogsStructured fetches logs with step-level structure for filtering (v0.6)
-func (m Model) fetchLogsStructured(jobID int64) tea.Cmd {
-	return func() tea.Msg {
-		logs, err := m.client.FetchJobLogsStructured(m.config.Owner, m.config.Repo, jobID)
-		if err != nil {
-			return ErrMsg{Err: err}
+// latestRunForRepo returns the most recent run for a repo from sourcedRuns, or nil
+func (m Model) latestRunForRepo(spec config.RepoSpec) *gh.SourcedRun {
+	var latest *gh.SourcedRun
+	for i := range m.sourcedRuns {
+		sr := &m.sourcedRuns[i]
+		if sr.Owner != spec.Owner || sr.Repo != spec.Repo {
+			continue
+		}
+		if latest == nil || sr.Run.CreatedAt.After(latest.Run.CreatedAt) {
+			latest = sr
 		}
-		return ParsedLogsLoadedMsg{Logs: logs}
 	}
+	return latest
 }
 
-// toggleStepFilter toggles a step number in the filter selection (v0.6)
-func (m *Model) toggleStepFilter(stepNum int) {
-	// Check if step is already selected
-	for i, n := range m.logFilterStepNumbers {
-		if n == stepNum {
-			// Remove it
-			m.logFilterStepNumbers = append(m.logFilterStepNumbers[:i], m.logFilterStepNumbers[i+1:]...)
-			return
+// repoRuns returns the loaded runs for spec, newest first
+func (m Model) repoRuns(spec config.RepoSpec) []gh.WorkflowRun {
+	var runs []gh.WorkflowRun
+	for i := range m.sourcedRuns {
+		if m.sourcedRuns[i].Owner == spec.Owner && m.sourcedRuns[i].Repo == spec.Repo {
+			runs = append(runs, *m.sourcedRuns[i].Run)
 		}
 	}
-	// Add it
-	m.logFilterStepNumbers = append(m.logFilterStepNumbers, stepNum)
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.After(runs[j].CreatedAt)
+	})
+	return runs
 }
 
-// applyLogFilter applies the current filter selection to log content (v0.6)
-func (m *Model) applyLogFilter() {
-	if m.parsedLogs == nil {
-		return
-	}
+// Multi-repo fetches run concurrently, a few repos at a time, and a slow repo
+// is given up on rather than holding up the whole dashboard
+const (
+	multiRepoWorkers = 4
+	multiRepoTimeout = 15 * time.Second
+)
 
-	if len(m.logFilterStepNumbers) == 0 {
-		// No filter - show all
-		m.logContent = m.parsedLogs.Combined
-	} else {
-		// Apply filter
-		m.logContent = m.parsedLogs.FilteredContent(m.logFilterStepNumbers)
-	}
-	m.logScrollOffset = 0 // Reset scroll position
+// multiRepoContext bounds one repo's fetch in a multi-repo refresh
+func multiRepoContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), multiRepoTimeout)
 }
 
-// isStepSelected returns true if a step number is in the filter selection (v0.6)
-func (m Model) isStepSelected(stepNum int) bool {
+// fetchMultiRepoRuns fetches runs from all configured repositories (v0.8)
+func (m Model) fetchMultiRepoRuns() tea.Cmd {
+	repos := m.config.Repositories
+	status, event, created := m.currentStatusFilter, m.eventFilter, m.config.CreatedFilter()
+	return func() tea.Msg {
+		allRuns, failures := fetchRepoRuns(repos, multiRepoWorkers, multiRepoContext,
+			func(ctx context.Context, repo config.RepoSpec) ([]gh.WorkflowRun, error) {
+				// Fetch 5 recent runs per repo
+				return m.client.FetchWorkflowRunsContext(ctx, repo.Owner, repo.Repo, repo.Branch, repoStatusFilter(repo, status), event, created, 1, 5)
+			})
+
+		// Sort by UpdatedAt descending (most recent first)
+		sort.Slice(allRuns, func(i, j int) bool {
+			return allRuns[i].Run.UpdatedAt.After(allRuns[j].Run.UpdatedAt)
+		})
+
+		if len(allRuns) == 0 {
+			if len(failures) > 0 {
+				return RefreshFailedMsg{Err: fmt.Errorf("%d of %d repos failed to load: %w", len(failures), len(repos), &failures[0])}
+			}
+			return RefreshFailedMsg{Err: fmt.Errorf("no workflow runs found across repositories")}
+		}
+
+		return MultiRepoRunsLoadedMsg{SourcedRuns: allRuns, Failures: failures}
+	}
+}
+
+// repoStatusFilter returns the run status filter for a repo in multi-repo
+// mode: the filter picked in the TUI, else the repo's own from cimon.yml
+func repoStatusFilter(repo config.RepoSpec, selected string) string {
+	if selected != "" {
+		return selected
+	}
+	return repo.Status
+}
+
+// fetchRepoRuns calls fetch for each repo using up to workers goroutines,
+// each call with its own context from newContext, which bounds it. Runs come
+// back tagged with their repo and failures in repo order.
+func fetchRepoRuns(repos []config.RepoSpec, workers int, newContext func() (context.Context, context.CancelFunc),
+	fetch func(ctx context.Context, repo config.RepoSpec) ([]gh.WorkflowRun, error)) ([]gh.SourcedRun, []gh.RepoError) {
+	runs := make([][]gh.WorkflowRun, len(repos))
+	errs := make([]error, len(repos))
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, repo config.RepoSpec) {
+			defer func() { <-sem; wg.Done() }()
+			ctx, cancel := newContext()
+			defer cancel()
+			runs[i], errs[i] = fetch(ctx, repo)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	var allRuns []gh.SourcedRun
+	var failures []gh.RepoError
+	for i, repo := range repos {
+		if errs[i] != nil {
+			failures = append(failures, gh.RepoError{Owner: repo.Owner, Repo: repo.Repo, Err: errs[i]})
+			continue
+		}
+		for j := range runs[i] {
+			allRuns = append(allRuns, gh.SourcedRun{Owner: repo.Owner, Repo: repo.Repo, Run: &runs[i][j]})
+		}
+	}
+	return allRuns, failures
+}
+
+func (m Model) fetchJobs() tea.Cmd {
+	return m.fetchAttemptJobs(m.shownAttempt())
+}
+
+// fetchAttemptJobs loads the jobs of an earlier attempt of the current run,
+// or of its latest attempt if attempt is 0
+func (m Model) fetchAttemptJobs(attempt int) tea.Cmd {
+	return func() tea.Msg {
+		if m.run == nil || m.config.NoJobs {
+			return JobsLoadedMsg{Jobs: nil}
+		}
+		if attempt > 0 {
+			run, err := m.client.FetchRunAttempt(m.config.Owner, m.config.Repo, m.run.ID, attempt)
+			if err != nil {
+				return JobsFailedMsg{RunID: m.run.ID, Err: err}
+			}
+			jobs, err := m.client.FetchAttemptJobs(m.config.Owner, m.config.Repo, m.run.ID, attempt)
+			if err != nil {
+				return JobsFailedMsg{RunID: m.run.ID, Err: err}
+			}
+			return JobsLoadedMsg{Jobs: jobs, Attempt: run}
+		}
+		jobs, err := m.client.FetchJobs(m.config.Owner, m.config.Repo, m.run.ID)
+		if err != nil {
+			return JobsFailedMsg{RunID: m.run.ID, Err: err}
+		}
+		return JobsLoadedMsg{Jobs: jobs}
+	}
+}
+
+// shownAttempt returns the earlier attempt of the current run whose jobs
+// are on screen, or 0 for the latest attempt. Switching runs goes back to
+// the latest attempt, since the choice belongs to the run it was made on.
+func (m Model) shownAttempt() int {
+	if m.run == nil || m.run.ID != m.attemptRunID || m.attempt >= m.run.RunAttempt {
+		return 0
+	}
+	return m.attempt
+}
+
+// cycleAttempt steps back through a re-run run's attempts, from the latest
+// to the first and round to the latest again, and loads that attempt's jobs
+func (m *Model) cycleAttempt() tea.Cmd {
+	attempt := m.shownAttempt()
+	if attempt == 0 {
+		attempt = m.run.RunAttempt
+	}
+	if attempt--; attempt < 1 {
+		attempt = m.run.RunAttempt
+	}
+	m.cursor = 0
+	m.loadingMessage = fmt.Sprintf("Loading attempt %d of run #%d...", attempt, m.run.RunNumber)
+	m.state = StateLoading
+	// The shown attempt changes once its jobs are in, so a failed load
+	// leaves the summary matching the jobs still on screen
+	if attempt == m.run.RunAttempt {
+		return m.fetchAttemptJobs(0)
+	}
+	return m.fetchAttemptJobs(attempt)
+}
+
+func (m Model) fetchJobDetails(jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		job, err := m.client.FetchJobDetails(m.config.Owner, m.config.Repo, jobID)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return JobDetailsLoadedMsg{Job: job}
+	}
+}
+
+// logTargetJob returns the job whose logs l/L open: the selected job in the
+// job list or the job shown in the details view
+func (m Model) logTargetJob() (int64, bool) {
+	if jobs := m.visibleJobs(); m.state == StateReady && len(jobs) > 0 && m.cursor >= 0 && m.cursor < len(jobs) {
+		return jobs[m.cursor].ID, true
+	}
+	if m.state == StateJobDetails && m.selectedJob != nil {
+		return m.selectedJob.ID, true
+	}
+	return 0, false
+}
+
+// selectedStep returns the step under the cursor in the job details view
+func (m Model) selectedStep() (gh.JobStep, bool) {
+	if m.state != StateJobDetails || m.selectedJob == nil {
+		return gh.JobStep{}, false
+	}
+	steps := m.selectedJob.Steps
+	if m.jobDetailsCursor < 0 || m.jobDetailsCursor >= len(steps) {
+		return gh.JobStep{}, false
+	}
+	return steps[m.jobDetailsCursor], true
+}
+
+// exitJobDetails leaves the job details view for the job list
+func (m *Model) exitJobDetails() {
+	m.showingJobDetails = false
+	m.selectedJob = nil
+	m.jobDetailsCursor = 0
+	m.state = StateReady
+}
+
+// openStepLogs opens the log viewer filtered to one step of the selected
+// job. Step logs come from the completed job's log archive, so a job that is
+// still running opens its whole (streaming) log instead
+func (m *Model) openStepLogs(step gh.JobStep) tea.Cmd {
+	jobID := m.selectedJob.ID
+	m.logFromFailures = false
+	if !m.selectedJob.IsCompleted() {
+		return m.openLogs(jobID, 0)
+	}
+	m.showingLogs = true
+	m.logScrollOffset = 0
+	m.logHScrollOffset = 0
+	m.clearLogSearch()
+	m.logJobID = jobID
+	m.logByteOffset = 0
+	m.logStreaming = false
+	m.logRunningStep = nil
+	m.logTail = 0
+	m.loadingMessage = fmt.Sprintf("Loading logs for step %q...", step.Name)
+	m.state = StateLoading
+	return m.fetchStepLogs(jobID, step)
+}
+
+// stepLogNumber finds the parsed log for a job step: by step number when the
+// log archive has one, otherwise by name
+func stepLogNumber(logs *gh.ParsedLogs, step gh.JobStep) (int, bool) {
+	if logs == nil {
+		return 0, false
+	}
+	if logs.GetStep(step.Number) != "" {
+		return step.Number, true
+	}
+	for _, s := range logs.Steps {
+		if s.Name == step.Name {
+			return s.Number, true
+		}
+	}
+	return 0, false
+}
+
+// failedStep is a row of the failure summary: a failed step of a failed job,
+// or a failed job with no failed step (Step.Number is 0)
+type failedStep struct {
+	Job  *gh.Job
+	Step gh.JobStep
+}
+
+// failedSteps lists the failed steps of the current run's failed jobs, in
+// job order, from the cached job details
+func (m Model) failedSteps() []failedStep {
+	var rows []failedStep
+	for i := range m.jobs {
+		if !m.jobs[i].IsFailure() {
+			continue
+		}
+		job, ok := m.failureJobs[m.jobs[i].ID]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, step := range job.Steps {
+			if step.IsFailure() {
+				rows = append(rows, failedStep{Job: job, Step: step})
+				found = true
+			}
+		}
+		if !found {
+			rows = append(rows, failedStep{Job: job})
+		}
+	}
+	return rows
+}
+
+// openFailureSummary shows the failure summary, first fetching the details
+// of failed jobs that aren't cached yet
+func (m *Model) openFailureSummary() tea.Cmd {
+	var missing []int64
+	failed := 0
+	for i := range m.jobs {
+		if !m.jobs[i].IsFailure() {
+			continue
+		}
+		failed++
+		if _, ok := m.failureJobs[m.jobs[i].ID]; !ok {
+			missing = append(missing, m.jobs[i].ID)
+		}
+	}
+	if failed == 0 {
+		m.setStatusMessage(fmt.Sprintf("Workflow #%d has no failed jobs", m.run.RunNumber), false)
+		return nil
+	}
+	m.failureCursor = 0
+	if len(missing) == 0 {
+		m.state = StateFailureSummary
+		return nil
+	}
+	m.loadingMessage = fmt.Sprintf("Loading steps of %d failed jobs...", len(missing))
+	m.state = StateLoading
+	return m.fetchFailureDetails(missing)
+}
+
+// fetchFailureDetails fetches the details of the given jobs for the failure summary
+func (m Model) fetchFailureDetails(jobIDs []int64) tea.Cmd {
+	return func() tea.Msg {
+		msg := FailureDetailsLoadedMsg{Jobs: make([]*gh.Job, 0, len(jobIDs))}
+		for _, id := range jobIDs {
+			job, err := m.client.FetchJobDetails(m.config.Owner, m.config.Repo, id)
+			if err != nil {
+				// One missing job (deleted, say) shouldn't hide the others
+				if msg.Failed == 0 {
+					msg.Err = err
+				}
+				msg.Failed++
+				continue
+			}
+			msg.Jobs = append(msg.Jobs, job)
+		}
+		return msg
+	}
+}
+
+// openFailedStep opens the log of the failure summary row under the cursor:
+// the failed step's log, or the whole job log if no step failed
+func (m *Model) openFailedStep() tea.Cmd {
+	rows := m.failedSteps()
+	if m.failureCursor < 0 || m.failureCursor >= len(rows) {
+		return nil
+	}
+	row := rows[m.failureCursor]
+	m.selectedJob = row.Job
+	var cmd tea.Cmd
+	if row.Step.Number == 0 {
+		cmd = m.openLogs(row.Job.ID, m.config.Tail)
+	} else {
+		cmd = m.openStepLogs(row.Step)
+	}
+	m.logFromFailures = true
+	return cmd
+}
+
+// closeLogViewer leaves the log viewer for the screen it was opened from
+func (m *Model) closeLogViewer() {
+	m.showingLogs = false
+	m.setLogContent("")
+	m.logScrollOffset = 0
+	m.logHScrollOffset = 0
+	m.clearLogSearch()
+	m.logJobID = 0
+	m.logByteOffset = 0
+	m.logStreaming = false
+	m.logRunningStep = nil
+	m.logTail = 0
+	m.parsedLogs = nil
+	m.logFilterStepNumbers = nil
+	if m.logFromFailures {
+		// Back to the failure summary the log was opened from
+		m.logFromFailures = false
+		m.selectedJob = nil
+		m.state = StateFailureSummary
+	} else if m.selectedJob != nil {
+		m.state = StateJobDetails
+	} else {
+		m.state = StateReady
+	}
+}
+
+// openLogs resets the log viewer and starts loading a job's logs, keeping
+// only the last tail lines if tail > 0
+func (m *Model) openLogs(jobID int64, tail int) tea.Cmd {
+	m.logFromFailures = false
+	m.parsedLogs = nil
+	m.logFilterStepNumbers = nil
+	m.showingLogs = true
+	m.logScrollOffset = 0
+	m.logHScrollOffset = 0
+	m.clearLogSearch()
+	m.logJobID = jobID
+	m.logByteOffset = 0
+	m.logTail = tail
+	m.logLastFetch = time.Now()
+	m.loadingMessage = "Loading logs..."
+	m.state = StateLoading
+	return m.fetchLogs(jobID)
+}
+
+func (m Model) fetchLogs(jobID int64) tea.Cmd {
+	// Completed logs won't stream, so they can come from the cache; they're a
+	// single ZIP download, so show its progress
+	if m.isJobCompleted(jobID) {
+		owner, repo := m.config.Owner, m.config.Repo
+		return startDownload("job logs", func(progress gh.ProgressFunc) tea.Msg {
+			logs, err := m.client.FetchJobLogsWithProgress(owner, repo, jobID, true, progress)
+			if errors.Is(err, gh.ErrLogTooLarge) {
+				return LogFailedMsg{Err: err}
+			} else if err != nil {
+				return ErrMsg{Err: err}
+			}
+			return LogLoadedMsg{Content: logs}
+		})
+	}
+	return func() tea.Msg {
+		chunk, err := m.client.FetchJobLogsSince(m.config.Owner, m.config.Repo, jobID, 0)
+		if errors.Is(err, gh.ErrLogTooLarge) {
+			return LogFailedMsg{Err: err}
+		} else if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return LogLoadedMsg{Content: chunk.Content, Size: chunk.Size}
+	}
+}
+
+// isJobCompleted reports whether a loaded job has finished, so its logs
+// are immutable and safe to cache
+func (m Model) isJobCompleted(jobID int64) bool {
+	if m.selectedJob != nil && m.selectedJob.ID == jobID {
+		return m.selectedJob.IsCompleted()
+	}
+	for i := range m.jobs {
+		if m.jobs[i].ID == jobID {
+			return m.jobs[i].IsCompleted()
+		}
+	}
+	return false
+}
+
+// updateLogs fetches only the log bytes added since the last fetch
+func (m Model) updateLogs(jobID int64) tea.Cmd {
+	offset := m.logByteOffset
+	return func() tea.Msg {
+		chunk, err := m.client.FetchJobLogsSince(m.config.Owner, m.config.Repo, jobID, offset)
+		if err != nil {
+			// Don't return error for streaming updates, just ignore
+			return LogUpdatedMsg{Size: offset, Appended: true}
+		}
+		return LogUpdatedMsg{Content: chunk.Content, Size: chunk.Size, Appended: chunk.Appended}
+	}
+}
+
+// updateLogJob refetches the streamed job's details alongside each log
+// update, to show which step is running
+func (m Model) updateLogJob(jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		job, err := m.client.FetchJobDetails(m.config.Owner, m.config.Repo, jobID)
+		if err != nil {
+			// Like log updates, keep streaming and try again next tick
+			return LogJobUpdatedMsg{JobID: jobID}
+		}
+		return LogJobUpdatedMsg{JobID: jobID, Job: job}
+	}
+}
+
+// applyLogUpdate merges a streaming log update into the log content,
+// appending new bytes rather than replacing everything. Returns true if
+// the content changed.
+func (m *Model) applyLogUpdate(msg LogUpdatedMsg) bool {
+	m.logByteOffset = msg.Size
+	if msg.Appended {
+		if msg.Content == "" {
+			return false
+		}
+		m.appendLogContent(msg.Content)
+		return true
+	}
+	if msg.Content == m.logContent {
+		return false
+	}
+	m.setLogContent(msg.Content)
+	return true
+}
+
+// setLogContent replaces the log content and splits it into lines once, so
+// rendering and search don't re-split the whole log on every frame
+func (m *Model) setLogContent(content string) {
+	m.logContent = content
+	m.logLines = nil
+	m.logTruncated = 0
+	m.logStepLines = nil
+	if content != "" {
+		m.logLines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+	m.indexLogSteps(0)
+	m.capLogLines()
+	m.refreshSearchMatches()
+}
+
+// setLogTail loads only the last n lines of content, counting the rest as
+// truncated so the banner and line numbers reflect the whole log
+func (m *Model) setLogTail(content string, n int) {
+	tail := gh.TailLines(content, n)
+	m.setLogContent(tail)
+	if dropped := strings.Count(content[:len(content)-len(tail)], "\n"); dropped > 0 {
+		m.logTruncated += dropped
+	}
+}
+
+// scrollLogToEnd scrolls the log viewer so the last line is visible
+func (m *Model) scrollLogToEnd() {
+	if maxScroll := len(m.logLines) - (m.height - 8); maxScroll > 0 {
+		m.logScrollOffset = maxScroll
+	}
+}
+
+// appendLogContent adds streamed log text, splitting only the new chunk.
+// A chunk that continues an unterminated last line is joined onto it.
+func (m *Model) appendLogContent(chunk string) {
+	continues := m.logContent != "" && !strings.HasSuffix(m.logContent, "\n")
+	m.logContent += chunk
+
+	lines := strings.Split(strings.TrimSuffix(chunk, "\n"), "\n")
+	if continues && len(m.logLines) > 0 {
+		m.logLines[len(m.logLines)-1] += lines[0]
+		lines = lines[1:]
+	}
+	first := len(m.logLines)
+	m.logLines = append(m.logLines, lines...)
+	m.indexLogSteps(first)
+	m.capLogLines()
+	m.refreshSearchMatches()
+}
+
+// indexLogSteps records the step headers in logLines from index first on
+func (m *Model) indexLogSteps(first int) {
+	for i := first; i < len(m.logLines); i++ {
+		if isStepHeader(m.logLines[i]) {
+			m.logStepLines = append(m.logStepLines, i)
+		}
+	}
+}
+
+// jumpToStep scrolls the log viewer to the next (or previous) step header,
+// putting it at the top of the screen
+func (m *Model) jumpToStep(next bool) {
+	target := -1
+	if next {
+		i := sort.SearchInts(m.logStepLines, m.logScrollOffset+1)
+		if i < len(m.logStepLines) {
+			target = m.logStepLines[i]
+		}
+	} else {
+		if i := sort.SearchInts(m.logStepLines, m.logScrollOffset) - 1; i >= 0 {
+			target = m.logStepLines[i]
+		}
+	}
+	if target < 0 {
+		return
+	}
+	// The view stops scrolling once the last line is on screen
+	maxScroll := max(len(m.logLines)-(m.height-10), 0)
+	m.logScrollOffset = min(target, maxScroll)
+}
+
+// capLogLines drops the oldest lines of enormous logs, keeping the last
+// maxLogLines. The full content is kept for export.
+func (m *Model) capLogLines() {
+	if excess := len(m.logLines) - maxLogLines; excess > 0 {
+		m.logLines = m.logLines[excess:]
+		m.logTruncated += excess
+		steps := m.logStepLines[:0]
+		for _, line := range m.logStepLines {
+			if line >= excess {
+				steps = append(steps, line-excess)
+			}
+		}
+		m.logStepLines = steps
+	}
+}
+
+func (m Model) fetchWorkflowContent() tea.Cmd {
+	return func() tea.Msg {
+		content, err := m.client.FetchWorkflowContent(m.config.Owner, m.config.Repo, m.workflowPath)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return WorkflowLoadedMsg{Content: content, Path: m.workflowPath}
+	}
+}
+
+// fetchAnnotations loads the check-run annotations of the current run
+func (m Model) fetchAnnotations() tea.Cmd {
+	owner, repo, runID := m.config.Owner, m.config.Repo, m.run.ID
+	return func() tea.Msg {
+		annotations, err := m.client.FetchAnnotations(owner, repo, runID)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return AnnotationsLoadedMsg{Annotations: annotations}
+	}
+}
+
+func (m Model) fetchArtifacts() tea.Cmd {
+	return func() tea.Msg {
+		if m.run == nil {
+			return ArtifactsLoadedMsg{Artifacts: nil}
+		}
+		artifacts, err := m.client.FetchWorkflowArtifacts(m.config.Owner, m.config.Repo, m.run.ID)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ArtifactsLoadedMsg{Artifacts: artifacts}
+	}
+}
+
+func (m Model) downloadArtifact(artifact gh.Artifact) tea.Cmd {
+	owner, repo := m.config.Owner, m.config.Repo
+	filename := fmt.Sprintf("%s.zip", artifact.Name)
+	return startDownload(filename, func(progress gh.ProgressFunc) tea.Msg {
+		err := m.client.DownloadArtifactWithProgress(owner, repo, artifact.ID, filename, progress)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ArtifactDownloadedMsg{Filename: filename}
+	})
+}
+
+// downloadProgressInterval throttles progress messages for fast downloads
+const downloadProgressInterval = 100 * time.Millisecond
+
+// startDownload runs download in the background, streaming its progress as
+// DownloadProgressMsg and then delivering the message it returns
+func startDownload(name string, download func(progress gh.ProgressFunc) tea.Msg) tea.Cmd {
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		var last time.Time
+		result := download(func(done, total int64) {
+			if time.Since(last) < downloadProgressInterval && done != total {
+				return
+			}
+			last = time.Now()
+			select {
+			case ch <- DownloadProgressMsg{Name: name, Done: done, Total: total}:
+			default: // The UI hasn't caught up with the last update; skip this one
+			}
+		})
+		ch <- result
+		close(ch)
+	}()
+	return waitForDownload(ch)
+}
+
+// waitForDownload delivers the next message of a download started by startDownload
+func waitForDownload(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		if progress, isProgress := msg.(DownloadProgressMsg); isProgress {
+			progress.next = ch
+			return progress
+		}
+		return msg
+	}
+}
+
+// rerunWorkflow reruns the currently selected workflow run
+func (m Model) rerunWorkflow() tea.Cmd {
+	owner, repo, run := m.config.Owner, m.config.Repo, m.run
+	return func() tea.Msg {
+		if err := m.client.RerunWorkflow(owner, repo, run.ID); err != nil {
+			return ActionResultMsg{Err: fmt.Errorf("rerun of workflow #%d failed: %w", run.RunNumber, err)}
+		}
+		return ActionResultMsg{Message: fmt.Sprintf("Triggered rerun of workflow #%d", run.RunNumber)}
+	}
+}
+
+// rerunJob reruns one failed job of the current run. Where single-job
+// reruns aren't available, it falls back to rerunning all the run's failed
+// jobs and says so.
+func (m Model) rerunJob(job gh.Job) tea.Cmd {
+	owner, repo, run := m.config.Owner, m.config.Repo, m.run
+	return func() tea.Msg {
+		err := m.client.RerunJob(owner, repo, job.ID)
+		var notFound *gh.NotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, gh.ErrUnsupported) {
+			if err := m.client.RerunFailedJobs(owner, repo, run.ID); err != nil {
+				return ActionResultMsg{Err: fmt.Errorf("rerun of failed jobs in workflow #%d failed: %w", run.RunNumber, err)}
+			}
+			return ActionResultMsg{Message: fmt.Sprintf("Single-job rerun isn't available; triggered rerun of all failed jobs in workflow #%d", run.RunNumber)}
+		}
+		if err != nil {
+			return ActionResultMsg{Err: fmt.Errorf("rerun of job %s failed: %w", job.Name, err)}
+		}
+		return ActionResultMsg{Message: fmt.Sprintf("Triggered rerun of job %s in workflow #%d", job.Name, run.RunNumber)}
+	}
+}
+
+// fetchPendingDeployments loads the environments the current run is waiting on
+func (m Model) fetchPendingDeployments() tea.Cmd {
+	owner, repo, runID := m.config.Owner, m.config.Repo, m.run.ID
+	return func() tea.Msg {
+		deployments, err := m.client.FetchPendingDeployments(owner, repo, runID)
+		if err != nil {
+			// Approval is optional - the run itself is still shown
+			return PendingDeploymentsLoadedMsg{RunID: runID}
+		}
+		return PendingDeploymentsLoadedMsg{RunID: runID, Deployments: deployments}
+	}
+}
+
+// fetchCommitVerification loads the signature verification of the current
+// run's head commit
+func (m Model) fetchCommitVerification() tea.Cmd {
+	owner, repo, sha := m.config.Owner, m.config.Repo, m.run.HeadSHA
+	return func() tea.Msg {
+		verification, err := m.client.FetchCommitVerification(owner, repo, sha)
+		if err != nil {
+			// Verification is optional - the summary just leaves it out
+			return CommitVerificationLoadedMsg{SHA: sha, Err: err}
+		}
+		return CommitVerificationLoadedMsg{SHA: sha, Verification: verification}
+	}
+}
+
+// fetchRunTiming loads the current run's billable time per runner OS
+func (m Model) fetchRunTiming() tea.Cmd {
+	owner, repo, runID := m.config.Owner, m.config.Repo, m.run.ID
+	return func() tea.Msg {
+		timing, err := m.client.FetchRunTiming(owner, repo, runID)
+		if err != nil {
+			// Timing is optional - the summary falls back to job durations
+			return RunTimingLoadedMsg{RunID: runID}
+		}
+		return RunTimingLoadedMsg{RunID: runID, Timing: timing}
+	}
+}
+
+// reviewDeployment approves or rejects the current run's pending deployments
+func (m Model) reviewDeployment(environmentIDs []int64, approve bool) tea.Cmd {
+	owner, repo, run := m.config.Owner, m.config.Repo, m.run
+	return func() tea.Msg {
+		if approve {
+			if err := m.client.ApproveDeployment(owner, repo, run.ID, environmentIDs); err != nil {
+				return ActionResultMsg{Err: fmt.Errorf("approval of workflow #%d failed: %w", run.RunNumber, err)}
+			}
+			return ActionResultMsg{Message: fmt.Sprintf("Approved deployment of workflow #%d", run.RunNumber)}
+		}
+		if err := m.client.RejectDeployment(owner, repo, run.ID, environmentIDs); err != nil {
+			return ActionResultMsg{Err: fmt.Errorf("rejection of workflow #%d failed: %w", run.RunNumber, err)}
+		}
+		return ActionResultMsg{Message: fmt.Sprintf("Rejected deployment of workflow #%d", run.RunNumber)}
+	}
+}
+
+// pendingEnvironmentNames joins the environment names of pending deployments
+func pendingEnvironmentNames(deployments []gh.PendingDeployment) string {
+	names := make([]string, len(deployments))
+	for i, d := range deployments {
+		names[i] = d.Environment.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// cancelWorkflow cancels the currently selected workflow run
+func (m Model) cancelWorkflow() tea.Cmd {
+	owner, repo, run := m.config.Owner, m.config.Repo, m.run
+	return func() tea.Msg {
+		if err := m.client.CancelWorkflow(owner, repo, run.ID); err != nil {
+			return ActionResultMsg{Err: fmt.Errorf("cancel of workflow #%d failed: %w", run.RunNumber, err)}
+		}
+		return ActionResultMsg{Message: fmt.Sprintf("Cancelled workflow #%d", run.RunNumber)}
+	}
+}
+
+// setStatusMessage sets a transient status message shown below the job list
+func (m *Model) setStatusMessage(message string, isErr bool) {
+	m.statusMessage = message
+	m.statusMessageErr = isErr
+	m.statusMessageTime = time.Now()
+}
+
+// exportCurrentLogs exports the current log content to a file in the
+// --export-format format (v0.6)
+func (m Model) exportCurrentLogs() tea.Cmd {
+	return func() tea.Msg {
+		format := m.config.ExportFormat
+		if format == "" {
+			format = config.ExportFormatTxt
+		}
+
+		// Generate filename: cimon-logs-REPO-RUNID-TIMESTAMP.FORMAT
+		timestamp := time.Now().Format("20060102-150405")
+		filename := fmt.Sprintf("cimon-logs-%s-%d-%s.%s",
+			m.config.Repo, m.run.ID, timestamp, format)
+
+		// JSON splits the log into steps, so fetch them if the viewer
+		// hasn't; the export falls back to the whole log without them
+		parsed := m.parsedLogs
+		if format == config.ExportFormatJSON && parsed == nil && !m.multiJobMode && m.logJobID != 0 && m.client != nil {
+			parsed, _ = m.client.FetchJobLogsStructured(m.config.Owner, m.config.Repo, m.logJobID, m.isJobCompleted(m.logJobID))
+		}
+
+		data, err := m.renderLogExport(format, parsed)
+		if err == nil {
+			err = os.WriteFile(filename, data, 0644)
+		}
+		return LogExportedMsg{Filename: filename, Error: err}
+	}
+}
+
+// logExport is the document written by a JSON log export
+type logExport struct {
+	Repository string          `json:"repository"`
+	Branch     string          `json:"branch"`
+	RunNumber  int             `json:"run_number,omitempty"`
+	RunID      int64           `json:"run_id,omitempty"`
+	JobID      int64           `json:"job_id"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Steps      []logExportStep `json:"steps"`
+	Log        string          `json:"log"`
+}
+
+// logExportStep is one step of a JSON log export
+type logExportStep struct {
+	Number  int    `json:"number"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// renderLogExport renders the log being viewed in an export format; parsed
+// supplies the steps for JSON and may be nil
+func (m Model) renderLogExport(format string, parsed *gh.ParsedLogs) ([]byte, error) {
+	switch format {
+	case config.ExportFormatJSON:
+		return json.MarshalIndent(m.logExportDocument(parsed), "", "  ")
+	case config.ExportFormatHTML:
+		return []byte(m.logExportHTML()), nil
+	case config.ExportFormatTxt, "":
+		return []byte(m.logExportContent()), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// logExportDocument builds the JSON export, keeping only the steps the log
+// filter shows when one is active
+func (m Model) logExportDocument(parsed *gh.ParsedLogs) logExport {
+	doc := logExport{
+		Repository: m.config.Owner + "/" + m.config.Repo,
+		Branch:     m.exportBranch(),
+		JobID:      m.logJobID,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Steps:      []logExportStep{},
+		Log:        m.exportLog(m.logContent),
+	}
+	if m.run != nil {
+		doc.RunNumber = m.run.RunNumber
+		doc.RunID = m.run.ID
+	}
+	if parsed != nil {
+		for _, step := range parsed.Steps {
+			if len(m.logFilterStepNumbers) > 0 && !m.isStepSelected(step.Number) {
+				continue
+			}
+			doc.Steps = append(doc.Steps, logExportStep{Number: step.Number, Name: step.Name, Content: m.exportLog(step.Content)})
+		}
+	}
+	return doc
+}
+
+// logExportHTML renders the log being viewed as a standalone HTML page with
+// the log viewer's syntax highlighting baked in as classed spans
+func (m Model) logExportHTML() string {
+	title := fmt.Sprintf("%s/%s", m.config.Owner, m.config.Repo)
+	if m.run != nil {
+		title += fmt.Sprintf(" run #%d", m.run.RunNumber)
+	}
+	if m.logJobID != 0 {
+		title += fmt.Sprintf(" job %d", m.logJobID)
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>\n" +
+		"body { background: #1e1e1e; color: #d4d4d4; font-family: monospace; }\n" +
+		"pre { white-space: pre-wrap; }\n" +
+		".error { color: #f14c4c; }\n" +
+		".warning { color: #cca700; }\n" +
+		".group { color: #3794ff; font-weight: bold; }\n" +
+		".step { color: #29b8db; font-weight: bold; }\n" +
+		".command { color: #23d18b; }\n" +
+		".timestamp { color: #808080; }\n" +
+		"</style>\n</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "<p>Branch: %s &middot; Exported: %s</p>\n",
+		html.EscapeString(m.exportBranch()), time.Now().Format(time.RFC3339))
+	b.WriteString("<pre>")
+	for i, line := range strings.Split(m.exportLog(m.logContent), "\n") {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(logLineHTML(line))
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// logLineHTML escapes a log line, wrapping the part syntax highlighting
+// would style in a span named after its kind
+func logLineHTML(line string) string {
+	kind, styled := classifyLogLine(line)
+	var class string
+	switch kind {
+	case logLineError:
+		class = "error"
+	case logLineWarning:
+		class = "warning"
+	case logLineGroup:
+		class = "group"
+	case logLineStep:
+		class = "step"
+	case logLineCommand:
+		class = "command"
+	case logLineTimestamp:
+		class = "timestamp"
+	}
+	if class == "" || styled == 0 {
+		return html.EscapeString(line)
+	}
+	if styled > len(line) {
+		styled = len(line)
+	}
+	return fmt.Sprintf(`<span class="%s">%s</span>%s`, class,
+		html.EscapeString(line[:styled]), html.EscapeString(line[styled:]))
+}
+
+// exportBranch returns the branch named in exports
+func (m Model) exportBranch() string {
+	if m.config.Branch == "" && m.run != nil {
+		return m.run.HeadBranch
+	}
+	return m.config.Branch
+}
+
+// logExportContent returns the log being viewed behind a metadata header
+// naming the repository, branch, run and job it came from
+func (m Model) logExportContent() string {
+	var content strings.Builder
+	content.WriteString("# Cimon Log Export\n")
+	content.WriteString(fmt.Sprintf("# Repository: %s/%s\n", m.config.Owner, m.config.Repo))
+	content.WriteString(fmt.Sprintf("# Branch: %s\n", m.exportBranch()))
+	if m.run != nil {
+		content.WriteString(fmt.Sprintf("# Run: #%d (ID: %d)\n", m.run.RunNumber, m.run.ID))
+	}
+	content.WriteString(fmt.Sprintf("# Job ID: %d\n", m.logJobID))
+	content.WriteString(fmt.Sprintf("# Exported: %s\n", time.Now().Format(time.RFC3339)))
+	content.WriteString("#\n\n")
+	content.WriteString(m.exportLog(m.logContent))
+	return content.String()
+}
+
+// exportLog masks likely secrets in log text that is saved or shared,
+// unless --no-redact is set
+func (m Model) exportLog(content string) string {
+	if m.config.NoRedact {
+		return content
+	}
+	return redact.Secrets(content)
+}
+
+// createLogGist shares the log being viewed as a secret gist
+func (m Model) createLogGist() tea.Cmd {
+	filename := fmt.Sprintf("cimon-logs-%s-%d-job-%d.txt", m.config.Repo, m.run.ID, m.logJobID)
+	content := m.logExportContent()
+	return func() tea.Msg {
+		url, err := m.client.CreateGist(map[string]string{filename: content}, false)
+		return GistCreatedMsg{URL: url, Err: err}
+	}
+}
+
+// fetchLogsStructured fetches logs with step-level structure for filtering (v0.6)
+func (m Model) fetchLogsStructured(jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		logs, err := m.client.FetchJobLogsStructured(m.config.Owner, m.config.Repo, jobID, m.isJobCompleted(jobID))
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ParsedLogsLoadedMsg{Logs: logs}
+	}
+}
+
+// fetchStepLogs fetches a completed job's structured logs to show one step
+func (m Model) fetchStepLogs(jobID int64, step gh.JobStep) tea.Cmd {
+	return func() tea.Msg {
+		logs, err := m.client.FetchJobLogsStructured(m.config.Owner, m.config.Repo, jobID, true)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return StepLogsLoadedMsg{Logs: logs, Step: step}
+	}
+}
+
+// jobStatusFilterOptions are the job filters cycled with the JobFilter key
+var jobStatusFilterOptions = []string{"", gh.ConclusionFailure, gh.StatusInProgress}
+
+// nextJobStatusFilter returns the filter that follows current in the cycle
+func nextJobStatusFilter(current string) string {
+	for i, f := range jobStatusFilterOptions {
+		if f == current {
+			return jobStatusFilterOptions[(i+1)%len(jobStatusFilterOptions)]
+		}
+	}
+	return ""
+}
+
+// eventFilterOptions are the event filters cycled with the EventFilter key
+var eventFilterOptions = []string{"", "push", "pull_request", "schedule", "workflow_dispatch"}
+
+// nextEventFilter returns the filter that follows current in the cycle; an
+// event from --event that isn't in the cycle goes back to all events
+func nextEventFilter(current string) string {
+	for i, f := range eventFilterOptions {
+		if f == current {
+			return eventFilterOptions[(i+1)%len(eventFilterOptions)]
+		}
+	}
+	return ""
+}
+
+// eventFilterLabel describes an event filter for status text
+func eventFilterLabel(event string) string {
+	if event == "" {
+		return "all"
+	}
+	return event
+}
+
+// jobMatchesFilter returns true if a job should be shown under the given job filter
+func jobMatchesFilter(job gh.Job, filter string) bool {
+	switch filter {
+	case gh.ConclusionFailure:
+		return job.IsFailure()
+	case gh.StatusInProgress:
+		return job.Status == gh.StatusInProgress || job.Status == gh.StatusQueued
+	default:
+		return true
+	}
+}
+
+// Job sort orders cycled with the JobSort key
+const (
+	jobSortDefault  = ""         // API order (roughly creation order)
+	jobSortDuration = "duration" // Longest running first
+	jobSortStatus   = "status"   // Failures first
+)
+
+var jobSortOptions = []string{jobSortDefault, jobSortDuration, jobSortStatus}
+
+// nextJobSort returns the sort order that follows current in the cycle
+func nextJobSort(current string) string {
+	for i, s := range jobSortOptions {
+		if s == current {
+			return jobSortOptions[(i+1)%len(jobSortOptions)]
+		}
+	}
+	return jobSortDefault
+}
+
+// sortJobs returns the jobs ordered by the given sort mode.
+// The input slice is never modified; the default mode returns it unchanged.
+func sortJobs(jobs []gh.Job, mode string) []gh.Job {
+	if mode == jobSortDefault || len(jobs) < 2 {
+		return jobs
+	}
+	sorted := make([]gh.Job, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return jobLess(sorted[i], sorted[j], mode)
+	})
+	return sorted
+}
+
+// jobLess reports whether job a sorts before job b under the given mode
+func jobLess(a, b gh.Job, mode string) bool {
+	switch mode {
+	case jobSortDuration:
+		return a.Duration() > b.Duration()
+	case jobSortStatus:
+		return jobStatusRank(a) < jobStatusRank(b)
+	default:
+		return false
+	}
+}
+
+// jobStatusRank orders jobs for status sorting: failures, running, queued, then the rest
+func jobStatusRank(job gh.Job) int {
+	switch {
+	case job.IsFailure():
+		return 0
+	case job.Status == gh.StatusInProgress:
+		return 1
+	case job.Status == gh.StatusQueued:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// visibleJobs returns the jobs shown in the job list after applying the job filter and sort
+func (m Model) visibleJobs() []gh.Job {
+	jobs := m.jobs
+	if m.jobStatusFilter != "" {
+		jobs = nil
+		for _, job := range m.jobs {
+			if jobMatchesFilter(job, m.jobStatusFilter) {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return sortJobs(jobs, m.jobSort)
+}
+
+// selectedJobID returns the ID of the job under the cursor, or 0 if none
+func (m Model) selectedJobID() int64 {
+	jobs := m.visibleJobs()
+	if m.cursor >= 0 && m.cursor < len(jobs) {
+		return jobs[m.cursor].ID
+	}
+	return 0
+}
+
+// selectJobByID moves the cursor to the job with the given ID if it is visible,
+// otherwise it clamps the cursor to the visible list
+func (m *Model) selectJobByID(jobID int64) {
+	if jobID != 0 {
+		for i, job := range m.visibleJobs() {
+			if job.ID == jobID {
+				m.cursor = i
+				return
+			}
+		}
+	}
+	m.clampJobCursor()
+}
+
+// clampJobCursor keeps the job cursor within the visible (filtered) job list
+func (m *Model) clampJobCursor() {
+	n := len(m.visibleJobs())
+	if m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// toggleStepFilter toggles a step number in the filter selection (v0.6)
+func (m *Model) toggleStepFilter(stepNum int) {
+	// Check if step is already selected
+	for i, n := range m.logFilterStepNumbers {
+		if n == stepNum {
+			// Remove it
+			m.logFilterStepNumbers = append(m.logFilterStepNumbers[:i], m.logFilterStepNumbers[i+1:]...)
+			return
+		}
+	}
+	// Add it
+	m.logFilterStepNumbers = append(m.logFilterStepNumbers, stepNum)
+}
+
+// applyLogFilter applies the current filter selection to log content (v0.6)
+func (m *Model) applyLogFilter() {
+	if m.parsedLogs == nil {
+		return
+	}
+
+	if len(m.logFilterStepNumbers) == 0 {
+		// No filter - show all
+		m.setLogContent(m.parsedLogs.Combined)
+	} else {
+		// Apply filter
+		m.setLogContent(m.parsedLogs.FilteredContent(m.logFilterStepNumbers))
+	}
+	m.logScrollOffset = 0 // Reset scroll position
+}
+
+// isStepSelected returns true if a step number is in the filter selection (v0.6)
+func (m Model) isStepSelected(stepNum int) bool {
 	for _, n := range m.logFilterStepNumbers {
 		if n == stepNum {
 			return true
@@ -1256,7 +3504,7 @@ func (m Model) fetchMultiJobLogs() tea.Cmd {
 	return func() tea.Msg {
 		contents := make(map[int64]string)
 		for _, jobID := range m.multiJobIDs {
-			logs, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobID)
+			logs, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobID, m.isJobCompleted(jobID))
 			if err != nil {
 				contents[jobID] = fmt.Sprintf("Error loading logs: %v", err)
 			} else {
@@ -1325,12 +3573,12 @@ func (m Model) fetchComparisonLogs() tea.Cmd {
 		}
 
 		// Fetch logs for the first job of each run
-		logs1, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobs1[0].ID)
+		logs1, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobs1[0].ID, jobs1[0].IsCompleted())
 		if err != nil {
 			logs1 = fmt.Sprintf("Error loading logs: %v", err)
 		}
 
-		logs2, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobs2[0].ID)
+		logs2, err := m.client.FetchJobLogs(m.config.Owner, m.config.Repo, jobs2[0].ID, jobs2[0].IsCompleted())
 		if err != nil {
 			logs2 = fmt.Sprintf("Error loading logs: %v", err)
 		}
@@ -1388,13 +3636,14 @@ func (m *Model) computeDiff(logs1, logs2 string) ([]string, []int) {
 	return result, colors
 }
 
-func (m Model) checkStreamingStatus() tea.Cmd {
+func (m *Model) checkStreamingStatus() tea.Cmd {
 	// Check if the current job is still running
 	for _, job := range m.jobs {
 		if job.ID == m.logJobID {
 			m.logStreaming = job.Status == gh.StatusInProgress || job.Status == gh.StatusQueued
+			m.logRunningStep = job.RunningStep()
 			if m.logStreaming {
-				return m.scheduleLogUpdate()
+				return tea.Batch(m.scheduleLogUpdate(), m.updateLogJob(job.ID))
 			}
 			break
 		}
@@ -1412,28 +3661,78 @@ func (m Model) scheduleLogUpdate() tea.Cmd {
 	})
 }
 
+// findSearchMatches records every case-insensitive match of the search term
+// across the whole log, so highlighting doesn't depend on what's visible
 func (m *Model) findSearchMatches() {
-	m.logSearchMatches = []int{}
-	if m.logSearchTerm == "" || m.logContent == "" {
+	m.logSearchMatches = []logMatch{}
+	if m.logSearchTerm == "" || len(m.logLines) == 0 {
 		return
 	}
 
-	lines := strings.Split(strings.TrimSuffix(m.logContent, "\n"), "\n")
-	for i, line := range lines {
-		if strings.Contains(strings.ToLower(line), strings.ToLower(m.logSearchTerm)) {
-			m.logSearchMatches = append(m.logSearchMatches, i)
+	for i, line := range m.logLines {
+		for _, span := range matchSpans(line, m.logSearchTerm) {
+			m.logSearchMatches = append(m.logSearchMatches, logMatch{line: i, start: span[0], end: span[1]})
 		}
 	}
 	m.logSearchIndex = 0
 }
 
+// refreshSearchMatches finds the search matches again after the log lines
+// change, staying on the current match while it still exists
+func (m *Model) refreshSearchMatches() {
+	current := m.logSearchIndex
+	m.findSearchMatches()
+	if current < len(m.logSearchMatches) {
+		m.logSearchIndex = current
+	}
+}
+
+// clearLogSearch drops the search term and its matches, which belong to
+// the log they were found in
+func (m *Model) clearLogSearch() {
+	m.logSearchTerm = ""
+	m.logSearchMatches = nil
+	m.logSearchIndex = 0
+}
+
+// matchSpans returns the byte ranges of the non-overlapping case-insensitive
+// matches of term in line
+func matchSpans(line, term string) [][2]int {
+	var spans [][2]int
+	lowerLine, lowerTerm := strings.ToLower(line), strings.ToLower(term)
+
+	// Lowercasing almost always keeps byte offsets, so search the lowered text
+	if len(lowerLine) == len(line) && len(lowerTerm) == len(term) {
+		for from := 0; ; {
+			idx := strings.Index(lowerLine[from:], lowerTerm)
+			if idx < 0 {
+				return spans
+			}
+			start := from + idx
+			spans = append(spans, [2]int{start, start + len(term)})
+			from = start + len(term)
+		}
+	}
+
+	// Otherwise compare case-folded windows rune by rune
+	for i := 0; i+len(term) <= len(line); {
+		if strings.EqualFold(line[i:i+len(term)], term) {
+			spans = append(spans, [2]int{i, i + len(term)})
+			i += len(term)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+	}
+	return spans
+}
+
 func (m *Model) nextSearchMatch() {
 	if len(m.logSearchMatches) == 0 {
 		return
 	}
 	m.logSearchIndex = (m.logSearchIndex + 1) % len(m.logSearchMatches)
-	lineNum := m.logSearchMatches[m.logSearchIndex]
-	m.scrollToLine(lineNum)
+	m.scrollToLine(m.logSearchMatches[m.logSearchIndex].line)
 }
 
 func (m *Model) prevSearchMatch() {
@@ -1444,8 +3743,7 @@ func (m *Model) prevSearchMatch() {
 	if m.logSearchIndex < 0 {
 		m.logSearchIndex = len(m.logSearchMatches) - 1
 	}
-	lineNum := m.logSearchMatches[m.logSearchIndex]
-	m.scrollToLine(lineNum)
+	m.scrollToLine(m.logSearchMatches[m.logSearchIndex].line)
 }
 
 func (m *Model) scrollToLine(lineNum int) {
@@ -1457,6 +3755,19 @@ func (m *Model) scrollToLine(lineNum int) {
 	}
 }
 
+// waitingForJobs reports whether the current run is queued and GitHub hasn't
+// created its jobs yet
+func (m Model) waitingForJobs() bool {
+	return m.run != nil && m.run.Status == gh.StatusQueued && len(m.jobs) == 0 && !m.config.NoJobs
+}
+
+// scheduleQueuedPoll re-checks a queued run after the poll interval
+func (m Model) scheduleQueuedPoll(runID int64) tea.Cmd {
+	return tea.Tick(m.config.Poll, func(time.Time) tea.Msg {
+		return QueuedPollMsg{RunID: runID}
+	})
+}
+
 func (m Model) scheduleNextPoll() tea.Cmd {
 	if !m.watching {
 		return nil
@@ -1477,19 +3788,40 @@ func (m Model) openInBrowser() tea.Cmd {
 	}
 }
 
+// openActionRequired opens an action_required run in the browser, where it
+// can be approved
+func (m *Model) openActionRequired() tea.Cmd {
+	url := m.run.HTMLURL
+	m.setStatusMessage(fmt.Sprintf("Opening workflow #%d in the browser to approve it", m.run.RunNumber), false)
+	return func() tea.Msg {
+		openURL(url)
+		return nil
+	}
+}
+
+// openChange opens the pull request or commit that triggered the run
+func (m *Model) openChange() tea.Cmd {
+	if m.run == nil {
+		return nil
+	}
+	label, url := m.run.ChangeLink()
+	if url == "" {
+		m.setStatusMessage("No pull request or commit link for this run", true)
+		return nil
+	}
+	m.setStatusMessage("Opening "+label, false)
+	return func() tea.Msg {
+		openURL(url)
+		return nil
+	}
+}
+
 func (m *Model) updateExitCode() {
 	if m.run == nil {
 		m.exitCode = 2
 		return
 	}
-	if m.run.IsSuccess() {
-		m.exitCode = 0
-	} else if m.run.IsFailure() {
-		m.exitCode = 1
-	} else {
-		// Still running or queued
-		m.exitCode = 0
-	}
+	m.exitCode = m.config.ExitCode(m.run.Outcome(), m.run.IsFailure())
 }
 
 // ExitCode returns the exit code to use when quitting
@@ -1497,24 +3829,110 @@ func (m Model) ExitCode() int {
 	return m.exitCode
 }
 
+// TimedOut reports whether the TUI quit because --timeout ran out while
+// watching
+func (m Model) TimedOut() bool {
+	return m.timedOut
+}
+
+// FailFastJob returns the name of the failed job that ended a --fail-fast
+// watch, or "" if the TUI exited normally
+func (m Model) FailFastJob() string {
+	return m.failFastJob
+}
+
+// firstFailedJob returns the first job with a failing conclusion, or nil
+func firstFailedJob(jobs []gh.Job) *gh.Job {
+	for i := range jobs {
+		if jobs[i].IsFailure() {
+			return &jobs[i]
+		}
+	}
+	return nil
+}
+
+// writeBell writes the BEL character, which terminals (and tmux, across
+// SSH) turn into an audible or visual bell
+var writeBell = func() {
+	fmt.Fprint(os.Stdout, "\a")
+}
+
 // openURL opens a URL in the default browser silently (no stderr output)
 var openURL = func(url string) {
+	_ = browser.Open(url)
+}
+
+// copyToClipboard writes text to the system clipboard with the platform's
+// copy tool, or an OSC 52 escape sequence (which most terminals, including
+// over SSH, turn into a clipboard write) when no tool is available
+var copyToClipboard = func(text string) error {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
 	case "darwin":
-		cmd = exec.Command("open", url)
+		cmd = exec.Command("pbcopy")
 	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
+		cmd = exec.Command("clip")
 	default:
-		cmd = exec.Command("xdg-open", url)
+		switch {
+		case os.Getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy"):
+			cmd = exec.Command("wl-copy")
+		case os.Getenv("DISPLAY") != "" && hasCommand("xclip"):
+			cmd = exec.Command("xclip", "-selection", "clipboard")
+		case os.Getenv("DISPLAY") != "" && hasCommand("xsel"):
+			cmd = exec.Command("xsel", "--clipboard", "--input")
+		}
+	}
+	if cmd == nil {
+		termenv.Copy(text)
+		return nil
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// reproCommand returns a cimon command line that opens the current run
+func (m Model) reproCommand() string {
+	branch := m.run.HeadBranch
+	if branch == "" {
+		branch = m.config.Branch
+	}
+	args := []string{"cimon", "--repo", m.config.RepoSlug()}
+	if branch != "" {
+		args = append(args, "--branch", shellQuote(branch))
+	}
+	args = append(args, "--run", strconv.Itoa(m.run.RunNumber))
+	return strings.Join(args, " ")
+}
+
+// shellQuote single-quotes s if it contains anything a shell would interpret
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./+@:=", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// copyRunCommand copies a command that reopens the current run, and the
+// run's URL, so the run can be handed to someone else
+func (m *Model) copyRunCommand() tea.Cmd {
+	if m.run == nil {
+		m.setStatusMessage("No run to copy", true)
+		return nil
 	}
-	// Suppress all output - we don't want to pollute the TUI
-	cmd.Stdout = nil
-	cmd.Stderr = nil
-	cmd.Stdin = nil
-	// Detach from terminal
-	cmd.Env = os.Environ()
-	_ = cmd.Start()
+	command := m.reproCommand()
+	if err := copyToClipboard(command + "\n" + m.run.HTMLURL + "\n"); err != nil {
+		m.setStatusMessage(fmt.Sprintf("Copy failed: %v", err), true)
+		return nil
+	}
+	m.setStatusMessage("Copied: "+command, false)
+	return nil
 }
 
 // triggerNotifications sends desktop notifications and executes hooks (v0.7)
@@ -1522,16 +3940,72 @@ func (m *Model) triggerNotifications() {
 	if m.run == nil {
 		return
 	}
+	branch := m.config.Branch
+	if branch == "" {
+		branch = m.run.HeadBranch // All-branches mode
+	}
+	m.sendRunNotifications(m.config.RepoSlug(), branch, m.run, m.jobs, false)
+}
+
+// checkMultiRepoCompletions notifies for runs in any repo that were seen running
+// on a previous poll and have since completed (multi-repo watch mode), returning
+// the --bell flash command if any did
+func (m *Model) checkMultiRepoCompletions() tea.Cmd {
+	var bell tea.Cmd
+	for _, sr := range m.sourcedRuns {
+		slug := sr.RepoSlug()
+		seen := m.repoRunStatus[slug]
+		if seen == nil {
+			seen = make(map[int64]string)
+			m.repoRunStatus[slug] = seen
+		}
+
+		prev, ok := seen[sr.Run.ID]
+		if m.watching && ok && prev != gh.StatusCompleted && sr.Run.IsCompleted() && !m.repoNotified[slug][sr.Run.ID] {
+			if m.repoNotified[slug] == nil {
+				m.repoNotified[slug] = make(map[int64]bool)
+			}
+			m.repoNotified[slug][sr.Run.ID] = true
+			m.sendRunNotifications(slug, sr.Run.HeadBranch, sr.Run, nil, true)
+			bell = m.ringBell()
+		}
+		seen[sr.Run.ID] = sr.Run.Status
+	}
+	return bell
+}
+
+// bellFlashDuration is how long --bell flashes the header
+const bellFlashDuration = time.Second
+
+// ringBell rings the terminal bell and starts the header flash for --bell,
+// returning the command that ends the flash (nil when --bell is off)
+func (m *Model) ringBell() tea.Cmd {
+	if !m.config.Bell {
+		return nil
+	}
+	writeBell()
+	m.bellFlashUntil = time.Now().Add(bellFlashDuration)
+	return tea.Tick(bellFlashDuration, func(time.Time) tea.Msg {
+		return BellFlashEndMsg{}
+	})
+}
 
+// bellFlashing reports whether the --bell header flash is showing
+func (m Model) bellFlashing() bool {
+	return time.Now().Before(m.bellFlashUntil)
+}
+
+// sendRunNotifications sends the desktop notification and runs the hook for a completed run
+func (m *Model) sendRunNotifications(repoSlug, branch string, run *gh.WorkflowRun, jobs []gh.Job, repoInTitle bool) {
 	conclusion := ""
-	if m.run.Conclusion != nil {
-		conclusion = *m.run.Conclusion
+	if run.Conclusion != nil {
+		conclusion = *run.Conclusion
 	}
 
 	// Count job successes and failures
 	successCount := 0
 	failureCount := 0
-	for _, job := range m.jobs {
+	for _, job := range jobs {
 		if job.Conclusion != nil {
 			switch *job.Conclusion {
 			case gh.ConclusionSuccess:
@@ -1544,27 +4018,31 @@ func (m *Model) triggerNotifications() {
 
 	// Build notification data
 	notifyData := notify.NotificationData{
-		WorkflowName: m.run.Name,
-		RunNumber:    m.run.RunNumber,
+		WorkflowName: run.DisplayName(),
+		RunNumber:    run.RunNumber,
 		Conclusion:   conclusion,
-		Repo:         m.config.RepoSlug(),
-		Branch:       m.config.Branch,
-		HTMLURL:      m.run.HTMLURL,
+		Repo:         repoSlug,
+		Branch:       branch,
+		HTMLURL:      run.HTMLURL,
+		RepoInTitle:  repoInTitle,
+
+		TitleTemplate: m.config.NotifyTitleTemplate,
+		BodyTemplate:  m.config.NotifyBodyTemplate,
 	}
 
 	// Build hook data
 	hookData := notify.HookData{
-		WorkflowName: m.run.Name,
-		RunNumber:    m.run.RunNumber,
-		RunID:        m.run.ID,
-		Status:       m.run.Status,
+		WorkflowName: run.DisplayName(),
+		RunNumber:    run.RunNumber,
+		RunID:        run.ID,
+		Status:       run.Status,
 		Conclusion:   conclusion,
-		Repo:         m.config.RepoSlug(),
-		Branch:       m.config.Branch,
-		Event:        m.run.Event,
-		Actor:        m.run.ActorLogin(),
-		HTMLURL:      m.run.HTMLURL,
-		JobCount:     len(m.jobs),
+		Repo:         repoSlug,
+		Branch:       branch,
+		Event:        run.Event,
+		Actor:        run.ActorLogin(),
+		HTMLURL:      run.HTMLURL,
+		JobCount:     len(jobs),
 		SuccessCount: successCount,
 		FailureCount: failureCount,
 	}
@@ -0,0 +1,2098 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lance0/cimon/internal/config"
+	"github.com/lance0/cimon/internal/gh"
+	"github.com/lance0/cimon/internal/redact"
+)
+
+func TestSortJobs(t *testing.T) {
+	failure := gh.ConclusionFailure
+	success := gh.ConclusionSuccess
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end1 := start.Add(1 * time.Minute)
+	end5 := start.Add(5 * time.Minute)
+
+	jobs := []gh.Job{
+		{ID: 1, Name: "lint", Status: gh.StatusCompleted, Conclusion: &success, StartedAt: &start, CompletedAt: &end1},
+		{ID: 2, Name: "build", Status: gh.StatusInProgress},
+		{ID: 3, Name: "test", Status: gh.StatusCompleted, Conclusion: &failure, StartedAt: &start, CompletedAt: &end5},
+		{ID: 4, Name: "deploy", Status: gh.StatusQueued},
+	}
+
+	tests := []struct {
+		name string
+		mode string
+		want []int64
+	}{
+		{"default keeps API order", jobSortDefault, []int64{1, 2, 3, 4}},
+		{"duration longest first", jobSortDuration, []int64{3, 1, 2, 4}},
+		{"status failures first", jobSortStatus, []int64{3, 2, 4, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortJobs(jobs, tt.mode)
+			if len(got) != len(tt.want) {
+				t.Fatalf("sortJobs() returned %d jobs, want %d", len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("sortJobs()[%d].ID = %d, want %d", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+
+	// The input slice must not be reordered
+	if jobs[0].ID != 1 || jobs[2].ID != 3 {
+		t.Error("sortJobs() modified the input slice")
+	}
+}
+
+func TestSelectJobByIDAcrossSort(t *testing.T) {
+	failure := gh.ConclusionFailure
+	success := gh.ConclusionSuccess
+	m := Model{
+		jobs: []gh.Job{
+			{ID: 10, Status: gh.StatusCompleted, Conclusion: &success},
+			{ID: 20, Status: gh.StatusCompleted, Conclusion: &failure},
+		},
+		cursor: 1,
+	}
+
+	selected := m.selectedJobID()
+	m.jobSort = jobSortStatus
+	m.selectJobByID(selected)
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after status sort, want 0", m.cursor)
+	}
+	if got := m.selectedJobID(); got != 20 {
+		t.Errorf("selectedJobID() = %d, want 20", got)
+	}
+}
+
+func TestCheckMultiRepoCompletions(t *testing.T) {
+	success := gh.ConclusionSuccess
+	m := NewModel(&config.Config{}, nil)
+	m.watching = true
+
+	running := gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &running}}
+	m.checkMultiRepoCompletions()
+	if _, notified := m.repoNotified["org/api"]; notified {
+		t.Fatal("notified for a run that is still in progress")
+	}
+
+	done := gh.WorkflowRun{ID: 1, Status: gh.StatusCompleted, Conclusion: &success}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &done}}
+	m.checkMultiRepoCompletions()
+	if !m.repoNotified["org/api"][1] {
+		t.Errorf("repoNotified[org/api] = %v, want run 1", m.repoNotified["org/api"])
+	}
+
+	// Two runs of one repo completing in turn each notify once, even if the
+	// first flickers back to running in between
+	rings := 0
+	orig := writeBell
+	writeBell = func() { rings++ }
+	defer func() { writeBell = orig }()
+	m.config.Bell = true
+	second := gh.WorkflowRun{ID: 3, Status: gh.StatusInProgress}
+	flicker := gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &second}, {Owner: "org", Repo: "api", Run: &flicker}}
+	m.checkMultiRepoCompletions()
+	secondDone := gh.WorkflowRun{ID: 3, Status: gh.StatusCompleted, Conclusion: &success}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &secondDone}, {Owner: "org", Repo: "api", Run: &done}}
+	m.checkMultiRepoCompletions()
+	if got := m.repoNotified["org/api"]; len(got) != 2 || !got[1] || !got[3] || rings != 1 {
+		t.Errorf("repoNotified[org/api] = %v after %d new notifications, want runs 1 and 3 after 1", got, rings)
+	}
+
+	// A run first seen already completed must not notify
+	other := gh.WorkflowRun{ID: 2, Status: gh.StatusCompleted, Conclusion: &success}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "web", Run: &other}}
+	m.checkMultiRepoCompletions()
+	if _, notified := m.repoNotified["org/web"]; notified {
+		t.Error("notified for a run never seen running")
+	}
+}
+
+func TestBellOnCompletion(t *testing.T) {
+	rings := 0
+	orig := writeBell
+	writeBell = func() { rings++ }
+	defer func() { writeBell = orig }()
+
+	success := gh.ConclusionSuccess
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Bell: true}, nil)
+	m.run = &gh.WorkflowRun{ID: 1, Status: gh.StatusCompleted, Conclusion: &success}
+	m.watching = true
+	m.state = StateWatching
+
+	updated, cmd := m.Update(JobsLoadedMsg{Jobs: []gh.Job{{ID: 1, Name: "build", Status: gh.StatusCompleted, Conclusion: &success}}})
+	m = updated.(Model)
+	if rings != 1 || cmd == nil {
+		t.Fatalf("rings = %d, cmd = %v; want one bell and a command", rings, cmd != nil)
+	}
+	if !m.bellFlashing() || !strings.Contains(m.viewHeader(), "o/r") {
+		t.Error("expected the header to flash after completion")
+	}
+
+	// Only once per completion
+	m.Update(JobsLoadedMsg{Jobs: m.jobs})
+	if rings != 1 {
+		t.Errorf("rings = %d after a second poll, want 1", rings)
+	}
+
+	// Without --bell nothing rings
+	m = NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.watching = true
+	running := gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &running}}
+	m.checkMultiRepoCompletions()
+	done := gh.WorkflowRun{ID: 1, Status: gh.StatusCompleted, Conclusion: &success}
+	m.sourcedRuns = []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &done}}
+	if cmd := m.checkMultiRepoCompletions(); cmd != nil || rings != 1 {
+		t.Errorf("rang without --bell: rings = %d, cmd = %v", rings, cmd != nil)
+	}
+}
+
+func TestApplyLogUpdate(t *testing.T) {
+	m := Model{logContent: "line 1\n", logByteOffset: 7}
+
+	// Appended chunk extends the log
+	if !m.applyLogUpdate(LogUpdatedMsg{Content: "line 2\n", Size: 14, Appended: true}) {
+		t.Error("applyLogUpdate() with new bytes = false, want true")
+	}
+	if m.logContent != "line 1\nline 2\n" || m.logByteOffset != 14 {
+		t.Errorf("after append: content = %q, offset = %d", m.logContent, m.logByteOffset)
+	}
+
+	// Empty append is a no-op
+	if m.applyLogUpdate(LogUpdatedMsg{Size: 14, Appended: true}) {
+		t.Error("applyLogUpdate() with no new bytes = true, want false")
+	}
+
+	// Full content replaces the log
+	if !m.applyLogUpdate(LogUpdatedMsg{Content: "rewritten\n", Size: 10}) {
+		t.Error("applyLogUpdate() with full content = false, want true")
+	}
+	if m.logContent != "rewritten\n" || m.logByteOffset != 10 {
+		t.Errorf("after replace: content = %q, offset = %d", m.logContent, m.logByteOffset)
+	}
+	if m.applyLogUpdate(LogUpdatedMsg{Content: "rewritten\n", Size: 10}) {
+		t.Error("applyLogUpdate() with unchanged full content = true, want false")
+	}
+}
+
+func TestLogLinesCache(t *testing.T) {
+	m := Model{}
+	m.setLogContent("a\nb\npart")
+	if !m.applyLogUpdate(LogUpdatedMsg{Content: "ial\nc\n", Appended: true}) {
+		t.Fatal("applyLogUpdate() with new bytes = false, want true")
+	}
+	want := []string{"a", "b", "partial", "c"}
+	if strings.Join(m.logLines, ",") != strings.Join(want, ",") {
+		t.Errorf("logLines = %q, want %q", m.logLines, want)
+	}
+
+	m.setLogContent(strings.Repeat("x\n", maxLogLines+5))
+	if len(m.logLines) != maxLogLines || m.logTruncated != 5 {
+		t.Errorf("capped log: %d lines, %d truncated, want %d/5", len(m.logLines), m.logTruncated, maxLogLines)
+	}
+	m.appendLogContent("y\n")
+	if len(m.logLines) != maxLogLines || m.logTruncated != 6 || m.logLines[len(m.logLines)-1] != "y" {
+		t.Errorf("after append: %d lines, %d truncated, last %q", len(m.logLines), m.logTruncated, m.logLines[len(m.logLines)-1])
+	}
+
+	m.setLogContent("")
+	if m.logLines != nil || m.logTruncated != 0 {
+		t.Errorf("cleared log: lines = %q, truncated = %d", m.logLines, m.logTruncated)
+	}
+}
+
+func TestTailLogs(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.state = StateReady
+	m.height = 30
+	m.jobs = []gh.Job{{ID: 7, Name: "build", Status: gh.StatusInProgress}}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading || m.logJobID != 7 || m.logTail != defaultTailLines {
+		t.Fatalf("L key: state = %v, job = %d, tail = %d", m.state, m.logJobID, m.logTail)
+	}
+
+	var log strings.Builder
+	for i := 1; i <= 3000; i++ {
+		fmt.Fprintf(&log, "line %d\n", i)
+	}
+	updated, _ = m.Update(LogLoadedMsg{Content: log.String()})
+	m = updated.(Model)
+	if len(m.logLines) != defaultTailLines || m.logTruncated != 3000-defaultTailLines {
+		t.Errorf("tail log: %d lines, %d truncated", len(m.logLines), m.logTruncated)
+	}
+	if m.logLines[0] != "line 2001" || m.logScrollOffset != defaultTailLines-(m.height-8) {
+		t.Errorf("tail log starts at %q, scrolled to %d", m.logLines[0], m.logScrollOffset)
+	}
+}
+
+func TestStepLogs(t *testing.T) {
+	failure := gh.ConclusionFailure
+	m := NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.state = StateJobDetails
+	m.showingJobDetails = true
+	m.selectedJob = &gh.Job{ID: 7, Name: "build", Status: gh.StatusCompleted, Steps: []gh.JobStep{
+		{Number: 1, Name: "Set up job"},
+		{Number: 2, Name: "Run tests", Conclusion: &failure},
+	}}
+	m.jobDetailsCursor = 1
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading || m.logJobID != 7 {
+		t.Fatalf("enter on step: state = %v, job = %d", m.state, m.logJobID)
+	}
+
+	logs := &gh.ParsedLogs{
+		Steps: []gh.StepLog{
+			{Number: 1, Name: "Set up job", Content: "runner ready"},
+			{Number: 2, Name: "Run tests", Content: "FAIL TestFoo"},
+		},
+		Combined: "runner ready\nFAIL TestFoo",
+	}
+	updated, _ = m.Update(StepLogsLoadedMsg{Logs: logs, Step: m.selectedJob.Steps[1]})
+	m = updated.(Model)
+	if m.state != StateLogViewer || !strings.Contains(m.logContent, "FAIL TestFoo") || strings.Contains(m.logContent, "runner ready") {
+		t.Errorf("step log: state = %v, content = %q", m.state, m.logContent)
+	}
+
+	// l leaves the log viewer back to the job details, clearing the step filter
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = *updated.(*Model)
+	if m.state != StateJobDetails || m.logFilterStepNumbers != nil {
+		t.Errorf("after l: state = %v, filter = %v", m.state, m.logFilterStepNumbers)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateReady || m.selectedJob != nil {
+		t.Errorf("after esc: state = %v", m.state)
+	}
+}
+
+func TestStepLogNumber(t *testing.T) {
+	logs := &gh.ParsedLogs{Steps: []gh.StepLog{
+		{Number: 1, Name: "Set up job", Content: "a"},
+		{Number: 3, Name: "Build", Content: "b"},
+	}}
+
+	tests := []struct {
+		name   string
+		step   gh.JobStep
+		want   int
+		wantOK bool
+	}{
+		{"by number", gh.JobStep{Number: 1, Name: "Set up job"}, 1, true},
+		{"numbers misaligned", gh.JobStep{Number: 2, Name: "Build"}, 3, true},
+		{"no match", gh.JobStep{Number: 9, Name: "Deploy"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := stepLogNumber(logs, tt.step)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("stepLogNumber() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetchRepoRuns(t *testing.T) {
+	repos := []config.RepoSpec{
+		{Owner: "org", Repo: "a"}, {Owner: "org", Repo: "broken"}, {Owner: "org", Repo: "b"},
+		{Owner: "org", Repo: "slow"}, {Owner: "org", Repo: "c"}, {Owner: "org", Repo: "d"},
+	}
+
+	// Every fetch's time is already up, which only the slow repo waits for
+	expired := func() (context.Context, context.CancelFunc) {
+		return context.WithDeadline(context.Background(), time.Time{})
+	}
+	var active, peak int32
+	runs, failures := fetchRepoRuns(repos, 2, expired, func(ctx context.Context, repo config.RepoSpec) ([]gh.WorkflowRun, error) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		switch repo.Repo {
+		case "broken":
+			return nil, errors.New("HTTP 500")
+		case "slow":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		time.Sleep(5 * time.Millisecond)
+		return []gh.WorkflowRun{{ID: 1, Name: repo.Repo}}, nil
+	})
+
+	if peak > 2 {
+		t.Errorf("%d fetches ran at once, want at most 2", peak)
+	}
+	if len(runs) != 4 {
+		t.Errorf("got %d runs, want 4", len(runs))
+	}
+	for _, sr := range runs {
+		if sr.Repo != sr.Run.Name {
+			t.Errorf("run %q tagged with repo %q", sr.Run.Name, sr.Repo)
+		}
+	}
+	if len(failures) != 2 || failures[0].Repo != "broken" || failures[1].Repo != "slow" {
+		t.Fatalf("failures = %v, want broken and slow", failures)
+	}
+	if !errors.Is(&failures[1], context.DeadlineExceeded) {
+		t.Errorf("slow repo error = %v, want deadline exceeded", failures[1].Err)
+	}
+}
+
+func TestRepoFailureBanner(t *testing.T) {
+	m := NewModel(&config.Config{
+		NoColor:      true,
+		Repositories: []config.RepoSpec{{Owner: "org", Repo: "api"}, {Owner: "org", Repo: "web"}},
+	}, nil)
+	m.width = 100
+	m.height = 30
+
+	updated, _ := m.Update(MultiRepoRunsLoadedMsg{
+		SourcedRuns: []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &gh.WorkflowRun{ID: 1, Status: gh.StatusQueued}}},
+		Failures:    []gh.RepoError{{Owner: "org", Repo: "web", Err: errors.New("timeout")}},
+	})
+	view := updated.(Model).viewDashboard()
+	if !strings.Contains(view, "1 repo failed to load: org/web") || !strings.Contains(view, "failed to load") {
+		t.Errorf("dashboard missing failure banner:\n%s", view)
+	}
+
+	// A later fetch where every repo loads clears the banner
+	updated, _ = updated.Update(MultiRepoRunsLoadedMsg{
+		SourcedRuns: []gh.SourcedRun{{Owner: "org", Repo: "api", Run: &gh.WorkflowRun{ID: 1, Status: gh.StatusQueued}}},
+	})
+	if view := updated.(Model).viewDashboard(); strings.Contains(view, "failed to load") {
+		t.Errorf("banner still shown after a clean fetch:\n%s", view)
+	}
+}
+
+func TestFailFastWatch(t *testing.T) {
+	failure := gh.ConclusionFailure
+	run := gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+	jobs := []gh.Job{
+		{Name: "lint", Status: gh.StatusInProgress},
+		{Name: "test (linux)", Status: gh.StatusCompleted, Conclusion: &failure},
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Watch: true, FailFast: true}, nil)
+	m.run = &run
+	updated, cmd := m.Update(JobsLoadedMsg{Jobs: jobs})
+	got := updated.(Model)
+	if cmd == nil || got.FailFastJob() != "test (linux)" || got.ExitCode() != 1 {
+		t.Errorf("fail-fast watch: job = %q, exit = %d, quit = %v", got.FailFastJob(), got.ExitCode(), cmd != nil)
+	}
+
+	// Without watch mode a failed job never ends the session
+	m = NewModel(&config.Config{Owner: "o", Repo: "r", FailFast: true}, nil)
+	m.run = &run
+	updated, _ = m.Update(JobsLoadedMsg{Jobs: jobs})
+	if job := updated.(Model).FailFastJob(); job != "" {
+		t.Errorf("one-shot view stopped on job %q", job)
+	}
+}
+
+func TestDeploymentReviewPrompt(t *testing.T) {
+	run := gh.WorkflowRun{ID: 42, RunNumber: 7, Status: gh.StatusWaiting}
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", NoColor: true}, nil)
+	m.run = &run
+	m.state = StateReady
+
+	updated, _ := m.Update(PendingDeploymentsLoadedMsg{RunID: 42, Deployments: []gh.PendingDeployment{
+		{Environment: gh.DeploymentEnvironment{ID: 1, Name: "production"}, CurrentUserCanApprove: true},
+	}})
+	m = updated.(Model)
+	if !strings.Contains(m.viewRunSummary(), "Waiting for approval: production") {
+		t.Errorf("run summary missing approval indicator:\n%s", m.viewRunSummary())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = *updated.(*Model) // key handling returns a pointer model
+	if m.state != StateConfirm || m.confirmAction == nil || m.rejectAction == nil {
+		t.Fatalf("A key: state = %v, want confirm prompt with approve and reject", m.state)
+	}
+	if !strings.Contains(m.viewConfirm(), "x reject") {
+		t.Errorf("confirm prompt missing reject option:\n%s", m.viewConfirm())
+	}
+
+	// Results for another run are dropped
+	m.state = StateReady
+	updated, _ = m.Update(PendingDeploymentsLoadedMsg{RunID: 99})
+	if len(updated.(Model).pendingDeployments) != 1 {
+		t.Error("pending deployments replaced by a stale result")
+	}
+}
+
+func TestHandleMouse(t *testing.T) {
+	run := gh.WorkflowRun{ID: 1, RunNumber: 3, Status: gh.StatusCompleted}
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateReady
+	m.width = 80
+	m.height = 30
+	m.run = &run
+	m.jobs = []gh.Job{{ID: 1, Name: "build"}, {ID: 2, Name: "test"}, {ID: 3, Name: "deploy"}}
+
+	// Click the row that renders the "test" job
+	row := -1
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.HasSuffix(line, " test") {
+			row = i
+		}
+	}
+	if row < 0 {
+		t.Fatal("job row not rendered")
+	}
+	updated, _ := m.Update(tea.MouseMsg{X: 5, Y: row, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = *updated.(*Model)
+	if m.cursor != 1 {
+		t.Errorf("click on row %d: cursor = %d, want 1", row, m.cursor)
+	}
+
+	// The wheel moves like the arrow keys
+	updated, _ = m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	m = *updated.(*Model)
+	if m.cursor != 2 {
+		t.Errorf("wheel down: cursor = %d, want 2", m.cursor)
+	}
+
+	// Clicks still land on the job under a "couldn't load jobs" warning
+	m.jobsErr = errors.New("HTTP 502: Bad Gateway")
+	for _, compact := range []bool{false, true} {
+		m.compact = compact
+		m.cursor = 0
+		row = -1
+		for i, line := range strings.Split(m.View(), "\n") {
+			if strings.HasSuffix(line, " test") {
+				row = i
+			}
+		}
+		updated, _ = m.Update(tea.MouseMsg{X: 5, Y: row, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+		m = *updated.(*Model)
+		if m.cursor != 1 {
+			t.Errorf("compact %v: click on row %d under the warning: cursor = %d, want 1", compact, row, m.cursor)
+		}
+	}
+
+	// ...but never answers a confirmation prompt
+	m.state = StateConfirm
+	updated, _ = m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	if updated.(*Model).state != StateConfirm {
+		t.Error("wheel dismissed the confirmation prompt")
+	}
+}
+
+func TestStartDownload(t *testing.T) {
+	cmd := startDownload("build.zip", func(progress gh.ProgressFunc) tea.Msg {
+		progress(100, 100)
+		return ArtifactDownloadedMsg{Filename: "build.zip"}
+	})
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.state = StateLoading
+	var final tea.Msg
+	for cmd != nil {
+		msg := cmd()
+		progress, ok := msg.(DownloadProgressMsg)
+		if !ok {
+			final = msg
+			break
+		}
+		updated, next := m.Update(progress)
+		m = updated.(Model)
+		cmd = next
+	}
+
+	if m.loadingMessage != "Downloading build.zip 100%" {
+		t.Errorf("loadingMessage = %q, want final progress", m.loadingMessage)
+	}
+	if done, ok := final.(ArtifactDownloadedMsg); !ok || done.Filename != "build.zip" {
+		t.Errorf("final message = %#v, want ArtifactDownloadedMsg", final)
+	}
+}
+
+func TestCopyRunCommand(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = orig }()
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 9, RunNumber: 123, HeadBranch: "main", HTMLURL: "https://github.com/o/r/actions/runs/9"}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")})
+	m = *updated.(*Model)
+	want := "cimon --repo o/r --branch main --run 123\nhttps://github.com/o/r/actions/runs/9\n"
+	if copied != want {
+		t.Errorf("copied %q, want %q", copied, want)
+	}
+	if m.statusMessageErr || !strings.Contains(m.statusMessage, "--run 123") {
+		t.Errorf("status = %q (err %v), want copy confirmation", m.statusMessage, m.statusMessageErr)
+	}
+
+	// All-branches mode names the run's own branch, quoted for the shell
+	m.config.Branch = ""
+	m.run.HeadBranch = "fix/it's"
+	if got := m.reproCommand(); got != `cimon --repo o/r --branch 'fix/it'\''s' --run 123` {
+		t.Errorf("reproCommand() = %s", got)
+	}
+
+	copyToClipboard = func(string) error { return errors.New("no clipboard") }
+	m.copyRunCommand()
+	if !m.statusMessageErr || !strings.Contains(m.statusMessage, "no clipboard") {
+		t.Errorf("status = %q, want copy failure", m.statusMessage)
+	}
+}
+
+func TestOpenChange(t *testing.T) {
+	var opened string
+	orig := openURL
+	openURL = func(url string) { opened = url }
+	defer func() { openURL = orig }()
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 9, Event: "pull_request", HTMLURL: "https://github.com/o/r/actions/runs/9",
+		PullRequests: []gh.PullRequest{{Number: 42}}}
+
+	if view := m.viewRunSummary(); !strings.Contains(view, "PR #42") {
+		t.Errorf("run summary doesn't show the PR:\n%s", view)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = *updated.(*Model)
+	if cmd == nil {
+		t.Fatal("p: no command to open the PR")
+	}
+	cmd()
+	if opened != "https://github.com/o/r/pull/42" {
+		t.Errorf("opened %q, want the PR page", opened)
+	}
+
+	// Nothing to open for a run without a PR or commit
+	m.run.PullRequests = nil
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = *updated.(*Model)
+	if cmd != nil || !m.statusMessageErr {
+		t.Errorf("p without a link: cmd = %v, status %q (err %v)", cmd != nil, m.statusMessage, m.statusMessageErr)
+	}
+}
+
+func TestNoJobs(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoJobs: true}, nil)
+	m.run = &gh.WorkflowRun{ID: 5, RunNumber: 12, Status: gh.StatusQueued}
+
+	// No client call is made: fetchJobs answers with no jobs straight away
+	msg := m.fetchJobs()()
+	if jobs, ok := msg.(JobsLoadedMsg); !ok || len(jobs.Jobs) != 0 {
+		t.Fatalf("fetchJobs() = %#v, want empty JobsLoadedMsg", msg)
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if m.waitingForJobs() {
+		t.Error("a queued run with --no-jobs shouldn't wait for jobs")
+	}
+	if view := m.View(); !strings.Contains(view, "Jobs not loaded (--no-jobs)") {
+		t.Errorf("view doesn't explain the missing jobs:\n%s", view)
+	}
+}
+
+func TestQueuedRunWithoutJobs(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Poll: time.Second}, nil)
+	m.run = &gh.WorkflowRun{ID: 5, RunNumber: 12, Status: gh.StatusQueued}
+
+	updated, cmd := m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("queued run without jobs: no re-poll scheduled")
+	}
+	if view := m.View(); !strings.Contains(view, "Waiting for jobs to be scheduled") || strings.Contains(view, "No jobs available") {
+		t.Errorf("queued view:\n%s", view)
+	}
+
+	// The re-poll refreshes only while the same run is still waiting
+	if _, cmd := m.Update(QueuedPollMsg{RunID: 5}); cmd == nil {
+		t.Error("QueuedPollMsg for the waiting run didn't refresh")
+	}
+	if _, cmd := m.Update(QueuedPollMsg{RunID: 4}); cmd != nil {
+		t.Error("QueuedPollMsg for another run refreshed")
+	}
+
+	updated, _ = m.Update(JobsLoadedMsg{Jobs: []gh.Job{{ID: 1, Name: "build", Status: gh.StatusQueued}}})
+	m = updated.(Model)
+	if _, cmd := m.Update(QueuedPollMsg{RunID: 5}); cmd != nil {
+		t.Error("QueuedPollMsg refreshed after jobs appeared")
+	}
+}
+
+func TestRunList(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.runs = []gh.WorkflowRun{
+		{ID: 3, RunNumber: 13, Name: "CI", HeadBranch: "main", Status: gh.StatusInProgress, Actor: &gh.User{Login: "alice"}},
+		{ID: 2, RunNumber: 12, Name: "CI", HeadBranch: "main", Status: gh.StatusCompleted, Actor: &gh.User{Login: "bob"}},
+		{ID: 1, RunNumber: 11, Name: "CI", HeadBranch: "main", Status: gh.StatusCompleted},
+	}
+	m.run = &m.runs[0]
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = *updated.(*Model)
+	if m.state != StateRunList || m.runListCursor != 0 {
+		t.Fatalf("g: state = %v, cursor = %d; want the run list at the current run", m.state, m.runListCursor)
+	}
+	view := m.View()
+	for _, want := range []string{"Run History", "#13", "#12", "#11", "alice", "bob"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("run list view missing %q:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = *updated.(*Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if m.state != StateReady || m.selectedRunIndex != 1 || m.run.RunNumber != 12 || cmd == nil {
+		t.Errorf("enter: state = %v, selected = %d, cmd = %v; want run #12 loading its jobs", m.state, m.selectedRunIndex, cmd != nil)
+	}
+
+	// Esc leaves without changing the selection
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateReady || m.selectedRunIndex != 1 {
+		t.Errorf("esc: state = %v, selected = %d; want run #12 still selected", m.state, m.selectedRunIndex)
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Event: "release", NoColor: true}, nil)
+	if header := m.viewHeader(); !strings.Contains(header, "[event: release]") {
+		t.Errorf("header = %q, want the --event filter", header)
+	}
+
+	m.state = StateReady
+	for _, want := range []string{"", "push", "pull_request"} {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+		m = *updated.(*Model)
+		if cmd == nil || m.state != StateLoading || m.eventFilter != want {
+			t.Fatalf("E key: state = %v, event = %q, want %q", m.state, m.eventFilter, want)
+		}
+		m.state = StateReady
+	}
+	if header := m.viewHeader(); !strings.Contains(header, "[event: pull_request]") {
+		t.Errorf("header = %q, want the event filter", header)
+	}
+}
+
+func TestBackgroundPollCoalesces(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Watch: true}, nil)
+	m.state = StateWatching
+	m.runs = []gh.WorkflowRun{{ID: 1, Status: gh.StatusInProgress}}
+	m.run = &m.runs[0]
+
+	updated, cmd := m.Update(TickMsg{})
+	m = updated.(Model)
+	if cmd == nil || !m.fetching || m.state != StateWatching {
+		t.Fatalf("poll: cmd = %v, fetching = %v, state = %v; want a background fetch", cmd != nil, m.fetching, m.state)
+	}
+
+	// A second poll and a manual refresh wait for the one in flight
+	updated, cmd = m.Update(TickMsg{})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("overlapping poll started another fetch")
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = *updated.(*Model)
+	if cmd != nil || m.state != StateWatching {
+		t.Errorf("refresh during a poll: cmd = %v, state = %v", cmd != nil, m.state)
+	}
+
+	// Results from a background poll don't close the screen the user is on
+	m.state = StateHelp
+	updated, _ = m.Update(JobsLoadedMsg{Jobs: []gh.Job{{ID: 7, Name: "build", Status: gh.StatusInProgress}}})
+	m = updated.(Model)
+	if m.fetching || m.state != StateHelp || len(m.jobs) != 1 {
+		t.Errorf("after poll: fetching = %v, state = %v, %d jobs", m.fetching, m.state, len(m.jobs))
+	}
+}
+
+func TestFilterChangeRefreshes(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Watch: true}, nil)
+	m.state = StateReady
+	m.runs = []gh.WorkflowRun{{ID: 1, Status: gh.StatusInProgress}}
+	m.run = &m.runs[0]
+	event := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")}
+
+	// Polls wait for the fetch of a new filter
+	updated, cmd := m.Update(event)
+	m = *updated.(*Model)
+	if cmd == nil || !m.fetching || m.state != StateLoading {
+		t.Fatalf("event filter: cmd = %v, fetching = %v, state = %v; want a fetch", cmd != nil, m.fetching, m.state)
+	}
+	updated, cmd = m.Update(TickMsg{})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("poll during a filter change started another fetch")
+	}
+
+	// Another filter fetches anyway, since the runs in flight are for the old one
+	m.state = StateReady
+	updated, cmd = m.Update(event)
+	m = *updated.(*Model)
+	if cmd == nil || !m.fetching {
+		t.Errorf("filter change during a fetch: cmd = %v, fetching = %v", cmd != nil, m.fetching)
+	}
+}
+
+func TestFailureSummary(t *testing.T) {
+	failure, success := gh.ConclusionFailure, gh.ConclusionSuccess
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 1, RunNumber: 12, Status: gh.StatusCompleted, Conclusion: &failure}
+	m.jobs = []gh.Job{
+		{ID: 7, Name: "test (linux)", Status: gh.StatusCompleted, Conclusion: &failure},
+		{ID: 8, Name: "lint", Status: gh.StatusCompleted, Conclusion: &success},
+		{ID: 9, Name: "test (windows)", Status: gh.StatusCompleted, Conclusion: &failure},
+	}
+
+	// Details of the failed jobs are fetched when the summary opens
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading {
+		t.Fatalf("!: state = %v, cmd = %v; want failed job details loading", m.state, cmd != nil)
+	}
+	updated, _ = m.Update(FailureDetailsLoadedMsg{Jobs: []*gh.Job{
+		{ID: 7, Name: "test (linux)", Status: gh.StatusCompleted, Conclusion: &failure, Steps: []gh.JobStep{
+			{Number: 1, Name: "Set up job", Conclusion: &success},
+			{Number: 3, Name: "Run tests", Conclusion: &failure},
+		}},
+		{ID: 9, Name: "test (windows)", Status: gh.StatusCompleted, Conclusion: &failure},
+	}})
+	m = updated.(Model)
+	if m.state != StateFailureSummary || len(m.failedSteps()) != 2 {
+		t.Fatalf("loaded: state = %v, %d rows", m.state, len(m.failedSteps()))
+	}
+	view := m.View()
+	for _, want := range []string{"Failed Steps (2)", "test (linux)", "step 3: Run tests", "test (windows)", "no failed step"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("summary view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "lint") || strings.Contains(view, "Set up job") {
+		t.Errorf("summary view lists passing jobs or steps:\n%s", view)
+	}
+
+	// Reopening uses the cache
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = *updated.(*Model)
+	if cmd != nil || m.state != StateFailureSummary {
+		t.Fatalf("reopen: state = %v, cmd = %v; want the cached summary", m.state, cmd != nil)
+	}
+
+	// Enter opens the step's log; leaving the log returns to the summary
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading || m.logJobID != 7 {
+		t.Fatalf("enter: state = %v, job = %d", m.state, m.logJobID)
+	}
+	updated, _ = m.Update(StepLogsLoadedMsg{
+		Logs: &gh.ParsedLogs{Steps: []gh.StepLog{{Number: 3, Name: "Run tests", Content: "FAIL TestFoo"}}},
+		Step: gh.JobStep{Number: 3, Name: "Run tests"},
+	})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = *updated.(*Model)
+	if m.state != StateFailureSummary || m.selectedJob != nil {
+		t.Errorf("after l: state = %v; want the failure summary", m.state)
+	}
+
+	// A job whose details fail to load is left out, and the rest still show
+	m.state = StateReady
+	m.failureJobs = nil
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = *updated.(*Model)
+	updated, _ = m.Update(FailureDetailsLoadedMsg{
+		Jobs:   []*gh.Job{{ID: 9, Name: "test (windows)", Status: gh.StatusCompleted, Conclusion: &failure}},
+		Failed: 1,
+		Err:    &gh.NotFoundError{Resource: "job", Err: errors.New("HTTP 404")},
+	})
+	m = updated.(Model)
+	if m.state != StateFailureSummary || m.ExitCode() != 0 || len(m.failedSteps()) != 1 {
+		t.Fatalf("partial load: state = %v, exit = %d, %d rows", m.state, m.ExitCode(), len(m.failedSteps()))
+	}
+	if !m.statusMessageErr || !strings.Contains(m.statusMessage, "steps of 1 failed jobs") {
+		t.Errorf("partial load: status message = %q", m.statusMessage)
+	}
+
+	// Details that arrive after the user left don't pull them back
+	m.state = StateReady
+	updated, _ = m.Update(FailureDetailsLoadedMsg{Jobs: []*gh.Job{{ID: 7, Name: "test (linux)", Status: gh.StatusCompleted, Conclusion: &failure}}})
+	m = updated.(Model)
+	if m.state != StateReady {
+		t.Errorf("late details: state = %v, want ready", m.state)
+	}
+
+	// A run without failed jobs says so instead of opening an empty summary
+	m.state = StateReady
+	m.jobs = m.jobs[1:2]
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = *updated.(*Model)
+	if cmd != nil || m.state != StateReady || !strings.Contains(m.statusMessage, "no failed jobs") {
+		t.Errorf("no failures: state = %v, message = %q", m.state, m.statusMessage)
+	}
+}
+
+func TestRunSummaryActionRequired(t *testing.T) {
+	actionRequired := gh.ConclusionActionRequired
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 1, RunNumber: 4, Status: gh.StatusCompleted, Conclusion: &actionRequired, HTMLURL: "https://github.com/o/r/actions/runs/1"}
+
+	summary := m.viewRunSummary()
+	if !strings.Contains(summary, "Action Required") || !strings.Contains(summary, "press A to approve") {
+		t.Errorf("summary doesn't flag the run:\n%s", summary)
+	}
+
+	var opened string
+	orig := openURL
+	openURL = func(url string) { opened = url }
+	defer func() { openURL = orig }()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateReady {
+		t.Fatalf("A: state = %v, cmd = %v; want the run opened in the browser", m.state, cmd != nil)
+	}
+	cmd()
+	if opened != m.run.HTMLURL {
+		t.Errorf("opened %q, want %q", opened, m.run.HTMLURL)
+	}
+}
+
+func TestBranchSelectionFilter(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	updated, _ := m.Update(BranchesLoadedMsg{Branches: []gh.Branch{
+		{Name: "main"}, {Name: "feature/login"}, {Name: "feature/Logout"}, {Name: "release/1.0"},
+	}})
+	m = updated.(Model)
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = *updated.(*Model)
+	}
+	typeText := func(s string) {
+		t.Helper()
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+	names := func() []string {
+		var out []string
+		for _, b := range m.filteredBranches() {
+			out = append(out, b.Name)
+		}
+		return out
+	}
+
+	// Move to the last branch, then narrow the list; the cursor is clamped
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	typeText("log")
+	if got := strings.Join(names(), ","); got != "feature/login,feature/Logout" {
+		t.Fatalf("filter %q = %v", m.branchFilter, got)
+	}
+	if m.state != StateBranchSelection || m.selectedBranchIndex != 1 {
+		t.Fatalf("state = %v, cursor = %d; want branch selection, cursor 1", m.state, m.selectedBranchIndex)
+	}
+	if view := m.View(); !strings.Contains(view, "Filter: log_") || strings.Contains(view, "release/1.0") {
+		t.Errorf("filtered view:\n%s", view)
+	}
+
+	// Letters that are shortcuts elsewhere go to the filter
+	typeText("q")
+	if m.branchFilter != "logq" || len(names()) != 0 || !strings.Contains(m.View(), "No branches match") {
+		t.Fatalf("filter = %q, %v", m.branchFilter, names())
+	}
+	press(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText("in")
+
+	// Enter switches to the highlighted branch of the filtered list
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if cmd == nil || m.config.Branch != "feature/login" {
+		t.Fatalf("enter: branch = %q, cmd = %v", m.config.Branch, cmd != nil)
+	}
+
+	// Esc clears the filter first, then leaves branch selection
+	m.state = StateBranchSelection
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.branchFilter != "" || m.state != StateBranchSelection {
+		t.Fatalf("first esc: filter = %q, state = %v", m.branchFilter, m.state)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != StateReady {
+		t.Errorf("second esc: state = %v, want ready", m.state)
+	}
+}
+
+func TestLogGist(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = orig }()
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.run = &gh.WorkflowRun{ID: 9, RunNumber: 12}
+	m.state = StateLogViewer
+	m.showingLogs = true
+	m.logJobID = 7
+	m.setLogContent("##[error]boom\n")
+
+	// G asks first; any other key goes back to the log
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = *updated.(*Model)
+	if m.state != StateConfirm || m.confirmAction == nil || !strings.Contains(m.View(), "secret gist") {
+		t.Fatalf("G: state = %v, want a confirmation prompt", m.state)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = *updated.(*Model)
+	if m.state != StateLogViewer {
+		t.Fatalf("declined: state = %v, want the log viewer", m.state)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = *updated.(*Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading {
+		t.Fatalf("confirmed: state = %v, cmd = %v", m.state, cmd != nil)
+	}
+
+	updated, _ = m.Update(GistCreatedMsg{URL: "https://gist.github.com/abc"})
+	m = updated.(Model)
+	if m.state != StateLogViewer || copied != "https://gist.github.com/abc" {
+		t.Fatalf("created: state = %v, copied %q", m.state, copied)
+	}
+	if !strings.Contains(m.View(), "Gist URL copied: https://gist.github.com/abc") {
+		t.Errorf("log viewer doesn't show the gist URL:\n%s", m.View())
+	}
+
+	updated, _ = m.Update(GistCreatedMsg{Err: errors.New("403 gist scope")})
+	m = updated.(Model)
+	if !strings.Contains(m.logExportMessage, "Gist failed") {
+		t.Errorf("failure message = %q", m.logExportMessage)
+	}
+}
+
+func TestWatchSurvivesTransientErrors(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Poll: time.Second, NoColor: true}, nil)
+	m.watching = true
+	m.state = StateWatching
+	m.runs = []gh.WorkflowRun{{ID: 1, RunNumber: 5, Status: gh.StatusInProgress}}
+	m.run = &m.runs[0]
+
+	// A gateway error keeps the last run on screen and the poll going
+	updated, cmd := m.Update(RefreshFailedMsg{Err: errors.New("HTTP 502: Bad Gateway")})
+	m = updated.(Model)
+	if m.state != StateWatching || cmd == nil || m.refreshErr == nil || m.run == nil {
+		t.Fatalf("502: state = %v, poll = %v, refreshErr = %v", m.state, cmd != nil, m.refreshErr)
+	}
+	if header := m.viewHeader(); !strings.Contains(header, "refresh failed, retrying") {
+		t.Errorf("header missing the refresh warning:\n%s", header)
+	}
+
+	// A manual refresh that hits a timeout goes back to watching too
+	m.state = StateLoading
+	updated, _ = m.Update(RefreshFailedMsg{Err: errors.New("request timeout")})
+	m = updated.(Model)
+	if m.state != StateWatching {
+		t.Errorf("timeout during refresh: state = %v, want watching", m.state)
+	}
+
+	// The next good refresh clears the warning
+	updated, _ = m.Update(RunsLoadedMsg{Runs: m.runs})
+	m = updated.(Model)
+	if m.refreshErr != nil || strings.Contains(m.viewHeader(), "refresh failed") {
+		t.Errorf("warning kept after a good refresh: %v", m.refreshErr)
+	}
+
+	// A log or detail fetch that fails says so without touching the poll
+	m.state = StateLoading
+	updated, cmd = m.Update(ErrMsg{Err: errors.New("HTTP 502: Bad Gateway")})
+	m = updated.(Model)
+	if m.state != StateWatching || cmd != nil || m.refreshErr != nil || !strings.Contains(m.statusMessage, "502") {
+		t.Errorf("log fetch 502: state = %v, poll = %v, refreshErr = %v, status %q", m.state, cmd != nil, m.refreshErr, m.statusMessage)
+	}
+
+	// On the multi-repo dashboard it goes back to the dashboard
+	dashboard := m
+	dashboard.multiRepoMode = true
+	dashboard.run = nil
+	dashboard.sourcedRuns = []gh.SourcedRun{{Owner: "o", Repo: "r", Run: &m.runs[0]}}
+	dashboard.state = StateLoading
+	updated, _ = dashboard.Update(ErrMsg{Err: errors.New("HTTP 502: Bad Gateway")})
+	if state := updated.(Model).state; state != StateDashboard {
+		t.Errorf("dashboard 502: state = %v, want the dashboard", state)
+	}
+
+	// Errors that need the user still stop the session
+	updated, _ = m.Update(RefreshFailedMsg{Err: &gh.AuthError{Err: errors.New("authentication failed: 503")}})
+	m = updated.(Model)
+	if m.state != StateError {
+		t.Errorf("auth error: state = %v, want error", m.state)
+	}
+}
+
+func TestJobsFailureKeepsRun(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateLoading
+	m.runs = []gh.WorkflowRun{{ID: 1, RunNumber: 5, Status: gh.StatusCompleted}}
+	m.run = &m.runs[0]
+	m.jobs = []gh.Job{{ID: 9, Name: "stale"}}
+
+	// A failed job fetch stays on the run instead of the error screen.
+	// Jobs fetched for another run don't belong to this one.
+	updated, _ := m.Update(JobsFailedMsg{RunID: 2, Err: errors.New("HTTP 502: Bad Gateway")})
+	m = updated.(Model)
+	if m.state != StateReady || m.err != nil || m.jobs != nil {
+		t.Fatalf("state = %v, err = %v, jobs = %v", m.state, m.err, m.jobs)
+	}
+	view := m.View()
+	if !strings.Contains(view, "couldn't load jobs: HTTP 502: Bad Gateway") || !strings.Contains(view, "GitHub servers are temporarily unavailable") {
+		t.Errorf("view missing the jobs warning and hint:\n%s", view)
+	}
+
+	// Loading the jobs on retry clears the warning
+	updated, _ = m.Update(JobsLoadedMsg{Jobs: []gh.Job{{ID: 10, Name: "build"}}})
+	m = updated.(Model)
+	if m.jobsErr != nil || strings.Contains(m.View(), "couldn't load jobs") {
+		t.Errorf("warning kept after jobs loaded: %v", m.jobsErr)
+	}
+
+	// A blip while watching the same run keeps its last good jobs listed
+	updated, _ = m.Update(JobsFailedMsg{RunID: 1, Err: errors.New("HTTP 502: Bad Gateway")})
+	m = updated.(Model)
+	if len(m.jobs) != 1 || m.jobs[0].ID != 10 {
+		t.Fatalf("jobs = %v, want the last good jobs", m.jobs)
+	}
+	if view := m.View(); !strings.Contains(view, "couldn't load jobs") || !strings.Contains(view, "build") {
+		t.Errorf("view should show the warning over the kept jobs:\n%s", view)
+	}
+
+	// A token problem won't clear up by retrying, so it gets the error screen
+	updated, _ = m.Update(JobsFailedMsg{RunID: 1, Err: &gh.SAMLError{}})
+	m = updated.(Model)
+	if m.state != StateError || m.err == nil || m.ExitCode() != 2 {
+		t.Errorf("SAML error: state = %v, err = %v, exit = %d", m.state, m.err, m.ExitCode())
+	}
+}
+
+func TestLogTooLargeKeepsRun(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 1, Status: gh.StatusCompleted}
+	m.showingLogs = true
+	m.logJobID = 9
+	m.state = StateLoading
+
+	// An archive over the limit closes the viewer with the error in the status line
+	err := fmt.Errorf("%w: over 10 bytes", gh.ErrLogTooLarge)
+	updated, _ := m.Update(LogFailedMsg{Err: err})
+	m = updated.(Model)
+	if m.state != StateReady || m.showingLogs || m.err != nil || m.ExitCode() != 0 {
+		t.Fatalf("state = %v, showingLogs = %v, err = %v, exit = %d", m.state, m.showingLogs, m.err, m.ExitCode())
+	}
+	if !m.statusMessageErr || !strings.Contains(m.statusMessage, "larger than the log size limit") {
+		t.Errorf("status message = %q", m.statusMessage)
+	}
+}
+
+func TestWatchTimeout(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Watch: true, Timeout: time.Minute}, nil)
+	if m.watchTimeout() == nil {
+		t.Fatal("no timeout scheduled for --watch --timeout")
+	}
+	m.state = StateWatching
+	m.run = &gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+
+	updated, cmd := m.Update(WatchTimeoutMsg{})
+	m = updated.(Model)
+	if cmd == nil || !m.TimedOut() || m.ExitCode() != config.ExitTimeout {
+		t.Errorf("quit = %v, timedOut = %v, exit = %d", cmd != nil, m.TimedOut(), m.ExitCode())
+	}
+
+	// A watch that already finished ignores the timeout
+	m = NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Timeout: time.Minute}, nil)
+	if m.watchTimeout() != nil {
+		t.Error("timeout scheduled without --watch")
+	}
+	updated, cmd = m.Update(WatchTimeoutMsg{})
+	if m = updated.(Model); cmd != nil || m.TimedOut() {
+		t.Errorf("timeout ended a session that wasn't watching")
+	}
+}
+
+func TestWatchNewFollowsNewerRun(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Watch: true, WatchNew: true, Timeout: time.Minute}, nil)
+	completed := "success"
+	runs := []gh.WorkflowRun{{ID: 10, RunNumber: 5, Status: gh.StatusCompleted, Conclusion: &completed}}
+	updated, _ := m.Update(RunsLoadedMsg{Runs: runs})
+	m = updated.(Model)
+	updated, cmd := m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+
+	// A finished run doesn't end a --watch-new session
+	if !m.watching || m.state != StateWatching || cmd == nil {
+		t.Fatalf("after completion: watching = %v, state = %v, next poll = %v", m.watching, m.state, cmd != nil)
+	}
+
+	// A newer run takes over on the next poll, even with an older one selected
+	runs = []gh.WorkflowRun{{ID: 11, RunNumber: 6, Status: gh.StatusInProgress}, runs[0]}
+	updated, _ = m.Update(RunsLoadedMsg{Runs: runs})
+	m = updated.(Model)
+	if m.run == nil || m.run.ID != 11 || m.selectedRunIndex != 0 || m.notificationSent {
+		t.Fatalf("run = %+v, index = %d, notified = %v; want run 11", m.run, m.selectedRunIndex, m.notificationSent)
+	}
+	if m.statusMessage != "Following newer run #6" {
+		t.Errorf("status message = %q", m.statusMessage)
+	}
+
+	// Browsing back to the older run sticks across polls
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = *updated.(*Model)
+	updated, _ = m.Update(RunsLoadedMsg{Runs: runs})
+	m = updated.(Model)
+	if m.run == nil || m.run.ID != 10 || m.selectedRunIndex != 1 {
+		t.Fatalf("after browsing and polling: run = %+v, index = %d; want run 10", m.run, m.selectedRunIndex)
+	}
+
+	// Until another run starts
+	runs = append([]gh.WorkflowRun{{ID: 12, RunNumber: 7, Status: gh.StatusQueued}}, runs...)
+	updated, _ = m.Update(RunsLoadedMsg{Runs: runs})
+	m = updated.(Model)
+	if m.run == nil || m.run.ID != 12 || m.selectedRunIndex != 0 {
+		t.Fatalf("run = %+v, index = %d; want run 12", m.run, m.selectedRunIndex)
+	}
+
+	// Running out of --timeout on a finished run quits without a timeout
+	m.runs[0].Status = gh.StatusCompleted
+	m.runs[0].Conclusion = &completed
+	updated, cmd = m.Update(WatchTimeoutMsg{})
+	if m = updated.(Model); cmd == nil || m.TimedOut() {
+		t.Errorf("quit = %v, timedOut = %v; want a clean quit", cmd != nil, m.TimedOut())
+	}
+}
+
+func TestRefreshOnFocus(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 1}
+
+	// Off unless --refresh-on-focus is given
+	if _, cmd := m.Update(tea.FocusMsg{}); cmd != nil {
+		t.Error("focus refreshed without --refresh-on-focus")
+	}
+
+	m.config.RefreshOnFocus = true
+	updated, cmd := m.Update(tea.FocusMsg{})
+	m = updated.(Model)
+	if cmd == nil || !m.fetching || m.state != StateReady {
+		t.Fatalf("focus: cmd = %v, fetching = %v, state = %v; want a background refresh", cmd != nil, m.fetching, m.state)
+	}
+
+	// A refresh in flight isn't doubled, and fresh data isn't refetched
+	if _, cmd := m.Update(tea.FocusMsg{}); cmd != nil {
+		t.Error("focus started a second refresh while one was in flight")
+	}
+	m.fetching = false
+	m.lastFetch = time.Now()
+	if _, cmd := m.Update(tea.FocusMsg{}); cmd != nil {
+		t.Error("focus refreshed data fetched moments ago")
+	}
+
+	// Screens that don't show runs are left alone
+	m.lastFetch = time.Time{}
+	m.state = StateLogViewer
+	if _, cmd := m.Update(tea.FocusMsg{}); cmd != nil {
+		t.Error("focus refreshed runs under the log viewer")
+	}
+}
+
+func TestLogExportJSON(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.run = &gh.WorkflowRun{ID: 9, RunNumber: 12}
+	m.logJobID = 7
+	m.setLogContent("build ok\n##[error]tests failed\n")
+	parsed := &gh.ParsedLogs{Steps: []gh.StepLog{
+		{Number: 1, Name: "Build", Content: "build ok"},
+		{Number: 2, Name: "Test", Content: "##[error]tests failed"},
+	}}
+
+	data, err := m.renderLogExport(config.ExportFormatJSON, parsed)
+	if err != nil {
+		t.Fatalf("renderLogExport() error = %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, data)
+	}
+	for _, key := range []string{"repository", "branch", "run_number", "run_id", "job_id", "exported_at", "steps", "log"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("export missing %q: %s", key, data)
+		}
+	}
+	if doc["repository"] != "o/r" || doc["branch"] != "main" || doc["run_number"] != float64(12) || doc["job_id"] != float64(7) {
+		t.Errorf("export metadata = %v", doc)
+	}
+	steps, _ := doc["steps"].([]interface{})
+	if len(steps) != 2 {
+		t.Fatalf("steps = %v, want 2", doc["steps"])
+	}
+	step, _ := steps[1].(map[string]interface{})
+	if step["number"] != float64(2) || step["name"] != "Test" || step["content"] != "##[error]tests failed" {
+		t.Errorf("steps[1] = %v", step)
+	}
+
+	// A step filter narrows the exported steps
+	m.logFilterStepNumbers = []int{2}
+	var filtered logExport
+	data, _ = m.renderLogExport(config.ExportFormatJSON, parsed)
+	if err := json.Unmarshal(data, &filtered); err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered.Steps) != 1 || filtered.Steps[0].Name != "Test" {
+		t.Errorf("filtered steps = %+v, want only Test", filtered.Steps)
+	}
+
+	// Without parsed logs the steps are empty rather than null
+	data, _ = m.renderLogExport(config.ExportFormatJSON, nil)
+	if !strings.Contains(string(data), `"steps": []`) {
+		t.Errorf("export without steps = %s", data)
+	}
+}
+
+func TestLogExportHTML(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.setLogContent("##[error]<bad> & worse\nplain")
+
+	data, err := m.renderLogExport(config.ExportFormatHTML, nil)
+	if err != nil {
+		t.Fatalf("renderLogExport() error = %v", err)
+	}
+	out := string(data)
+	if !strings.Contains(out, `<span class="error">##[error]&lt;bad&gt; &amp; worse</span>`) {
+		t.Errorf("error line not highlighted and escaped:\n%s", out)
+	}
+	if !strings.Contains(out, "\nplain</pre>") {
+		t.Errorf("plain line should be left unwrapped:\n%s", out)
+	}
+
+	if _, err := m.renderLogExport("pdf", nil); err == nil {
+		t.Error("renderLogExport(pdf) should fail")
+	}
+}
+
+func TestLogExportRedactsSecrets(t *testing.T) {
+	token := "ghp_" + strings.Repeat("aB3", 12)
+	m := NewModel(&config.Config{Owner: "o", Repo: "r"}, nil)
+	m.setLogContent("login with " + token + "\n")
+
+	for _, format := range []string{config.ExportFormatTxt, config.ExportFormatJSON, config.ExportFormatHTML} {
+		data, err := m.renderLogExport(format, &gh.ParsedLogs{Steps: []gh.StepLog{{Number: 1, Name: "Login", Content: token}}})
+		if err != nil {
+			t.Fatalf("renderLogExport(%s) error = %v", format, err)
+		}
+		if out := string(data); strings.Contains(out, token) || !strings.Contains(out, redact.Placeholder) {
+			t.Errorf("%s export not redacted:\n%s", format, out)
+		}
+	}
+	if content := m.logExportContent(); strings.Contains(content, token) {
+		t.Errorf("gist content not redacted:\n%s", content)
+	}
+
+	m.config.NoRedact = true
+	if content := m.logExportContent(); !strings.Contains(content, token) {
+		t.Errorf("--no-redact still redacted:\n%s", content)
+	}
+}
+
+func TestLogViewerStepNavigation(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateLogViewer
+	m.showingLogs = true
+	m.width = 60
+	m.height = 40
+	var log strings.Builder
+	for step, name := range []string{"1_Set up job", "2_Checkout", "3_Build"} {
+		fmt.Fprintf(&log, "=== %s ===\n", name)
+		for i := 0; i < 15; i++ {
+			fmt.Fprintf(&log, "step %d line %d\n", step+1, i)
+		}
+		log.WriteString("\n")
+	}
+	m.setLogContent(log.String())
+	if want := []int{0, 17, 34}; !slices.Equal(m.logStepLines, want) {
+		t.Fatalf("logStepLines = %v, want %v", m.logStepLines, want)
+	}
+
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")}
+	prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")}
+	updated, _ := m.Update(next)
+	m = *updated.(*Model)
+	if m.logScrollOffset != 17 {
+		t.Fatalf("offset = %d after ], want 17", m.logScrollOffset)
+	}
+	if out := m.View(); !strings.Contains(out, "Step 2/3") {
+		t.Errorf("expected the step in the status line, got:\n%s", out)
+	}
+
+	// The last step can't scroll past the end of the log
+	updated, _ = m.Update(next)
+	m = *updated.(*Model)
+	if want := len(m.logLines) - (m.height - 10); m.logScrollOffset != want {
+		t.Errorf("offset = %d at the last step, want %d", m.logScrollOffset, want)
+	}
+
+	updated, _ = m.Update(prev)
+	m = *updated.(*Model)
+	if m.logScrollOffset != 17 {
+		t.Errorf("offset = %d after [, want 17", m.logScrollOffset)
+	}
+
+	// Streamed chunks add their headers; capping the log shifts them
+	m.appendLogContent("=== 4_Test ===\nok\n")
+	if last := m.logStepLines[len(m.logStepLines)-1]; m.logLines[last] != "=== 4_Test ===" {
+		t.Errorf("streamed header not indexed: %v", m.logStepLines)
+	}
+	if kind, _ := classifyLogLine("=== 2_Checkout ==="); kind != logLineStep {
+		t.Errorf("step header classified as %v", kind)
+	}
+}
+
+func TestLogStreamingRunningStep(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.width = 100
+	m.height = 30
+	m.jobs = []gh.Job{{ID: 7, Name: "build", Status: gh.StatusInProgress, Steps: []gh.JobStep{
+		{Number: 1, Name: "Set up job", Status: gh.StatusCompleted},
+		{Number: 2, Name: "Build", Status: gh.StatusInProgress},
+	}}}
+	m.logJobID = 7
+
+	updated, cmd := m.Update(LogLoadedMsg{Content: "compiling\n"})
+	m = updated.(Model)
+	if !m.logStreaming || cmd == nil {
+		t.Fatalf("streaming = %v, cmd = %v", m.logStreaming, cmd != nil)
+	}
+	if out := m.View(); !strings.Contains(out, "▶ Running step 2: Build") {
+		t.Errorf("log viewer missing the running step:\n%s", out)
+	}
+
+	// Each poll's job details move the header on to the next step
+	job := m.jobs[0]
+	job.Steps = []gh.JobStep{
+		{Number: 2, Name: "Build", Status: gh.StatusCompleted},
+		{Number: 3, Name: "Test", Status: gh.StatusInProgress},
+	}
+	updated, _ = m.Update(LogJobUpdatedMsg{JobID: 7, Job: &job})
+	m = updated.(Model)
+	if out := m.View(); !strings.Contains(out, "▶ Running step 3: Test") {
+		t.Errorf("log viewer missing the next step:\n%s", out)
+	}
+
+	// Details for a job whose logs aren't open are ignored
+	updated, _ = m.Update(LogJobUpdatedMsg{JobID: 8, Job: &gh.Job{ID: 8}})
+	m = updated.(Model)
+	if m.logRunningStep == nil || m.logRunningStep.Number != 3 {
+		t.Errorf("running step = %v after another job's details", m.logRunningStep)
+	}
+}
+
+func TestLogViewerHorizontalScroll(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateLogViewer
+	m.showingLogs = true
+	m.width = 40
+	m.height = 30
+	m.logSyntaxEnabled = false
+	m.setLogContent("short\n" + strings.Repeat("a", 60) + strings.Repeat("b", 60) + "END\n")
+
+	right := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")}
+	for i := 0; i < 8; i++ {
+		updated, _ := m.Update(right)
+		m = *updated.(*Model)
+	}
+	if m.logHScrollOffset != 8*logHScrollStep {
+		t.Fatalf("offset = %d after eight scrolls, want %d", m.logHScrollOffset, 8*logHScrollStep)
+	}
+	out := m.View()
+	if !strings.Contains(out, strings.Repeat("b", 10)) || strings.Contains(out, strings.Repeat("a", 10)) {
+		t.Errorf("expected the view panned past the a's, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Col 65") {
+		t.Errorf("expected the column in the status line, got:\n%s", out)
+	}
+
+	// Panning stops once the longest line's end is in view
+	for i := 0; i < 10; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+		m = *updated.(*Model)
+	}
+	if want := m.maxLogHScroll(m.logTextWidth()); m.logHScrollOffset != want {
+		t.Errorf("offset = %d, want clamped to %d", m.logHScrollOffset, want)
+	}
+	if !strings.Contains(m.View(), "END") {
+		t.Error("expected the end of the longest line when fully panned")
+	}
+
+	// The line number gutter narrows the text, so panning goes further
+	m.logLineNumbers = true
+	for i := 0; i < 10; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+		m = *updated.(*Model)
+	}
+	if !strings.Contains(m.View(), "END") {
+		t.Errorf("expected the end of the longest line beside line numbers, got:\n%s", m.View())
+	}
+	m.logLineNumbers = false
+
+	for i := 0; i < 20; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("<")})
+		m = *updated.(*Model)
+	}
+	if m.logHScrollOffset != 0 || !strings.Contains(m.View(), "short") {
+		t.Errorf("offset = %d, want back at the start", m.logHScrollOffset)
+	}
+}
+
+func TestFetchMultiRepoRunsPerRepoFilters(t *testing.T) {
+	var mu sync.Mutex
+	queries := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries[r.URL.Path] = r.URL.RawQuery
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"total_count": 1, "workflow_runs": [{"id": 1, "name": "CI", "status": "completed"}]}`)
+	}))
+	defer srv.Close()
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{Transport: srv.Client().Transport, BaseURL: srv.URL, AuthToken: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(&config.Config{Repositories: []config.RepoSpec{
+		{Owner: "org", Repo: "api", Branch: "main", Status: "failure"},
+		{Owner: "org", Repo: "web", Branch: "develop"},
+	}}, client)
+
+	if msg, ok := m.fetchMultiRepoRuns()().(MultiRepoRunsLoadedMsg); !ok || len(msg.SourcedRuns) != 2 {
+		t.Fatalf("fetchMultiRepoRuns() = %#v", msg)
+	}
+	api, web := queries["/repos/org/api/actions/runs"], queries["/repos/org/web/actions/runs"]
+	if !strings.Contains(api, "branch=main") || !strings.Contains(api, "status=failure") {
+		t.Errorf("org/api query = %q, want main and failure", api)
+	}
+	if !strings.Contains(web, "branch=develop") || strings.Contains(web, "status=") {
+		t.Errorf("org/web query = %q, want develop and no status", web)
+	}
+
+	// A filter picked in the TUI overrides the per-repo ones
+	m.currentStatusFilter = "success"
+	m.fetchMultiRepoRuns()()
+	if api := queries["/repos/org/api/actions/runs"]; !strings.Contains(api, "status=success") {
+		t.Errorf("org/api query = %q, want the TUI's success filter", api)
+	}
+}
+
+func TestFetchBranchPatternRuns(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/branches") {
+			fmt.Fprint(w, `[{"name": "main"}, {"name": "release/1.0"}, {"name": "release/2.0"}]`)
+			return
+		}
+		branch := r.URL.Query().Get("branch")
+		mu.Lock()
+		fetched = append(fetched, branch)
+		mu.Unlock()
+		// release/2.0 has the newer run
+		created := "2025-01-01T10:00:00Z"
+		id := 1
+		if branch == "release/2.0" {
+			created, id = "2025-01-02T10:00:00Z", 2
+		}
+		fmt.Fprintf(w, `{"total_count": 1, "workflow_runs": [{"id": %d, "status": "completed", "head_branch": %q, "created_at": %q}]}`, id, branch, created)
+	}))
+	defer srv.Close()
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{Transport: srv.Client().Transport, BaseURL: srv.URL, AuthToken: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", BranchPattern: "release/*", NoColor: true}, client)
+	msg, ok := m.fetchWorkflowRuns()().(RunsLoadedMsg)
+	if !ok || len(msg.Runs) != 2 || msg.Runs[0].ID != 2 || msg.Runs[1].ID != 1 {
+		t.Fatalf("fetchWorkflowRuns() = %#v, want runs 2 then 1", msg)
+	}
+	slices.Sort(fetched)
+	if !slices.Equal(fetched, []string{"release/1.0", "release/2.0"}) {
+		t.Errorf("fetched branches %q, want only the release branches", fetched)
+	}
+
+	// Each run row names its branch
+	m.runs = msg.Runs
+	if list := m.viewRunList(); !strings.Contains(list, "release/1.0") || !strings.Contains(list, "release/2.0") {
+		t.Errorf("run list missing branches:\n%s", list)
+	}
+
+	m.config.BranchPattern = "hotfix/*"
+	if msg, ok := m.fetchWorkflowRuns()().(RefreshFailedMsg); !ok || !strings.Contains(msg.Err.Error(), "no branches match") {
+		t.Errorf("no matching branches: %#v", msg)
+	}
+}
+
+func TestCycleRepo(t *testing.T) {
+	m := NewModel(&config.Config{Repositories: []config.RepoSpec{
+		{Owner: "org", Repo: "api", Branch: "main"},
+		{Owner: "org", Repo: "web"},
+		{Owner: "org", Repo: "docs"},
+	}}, nil)
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	shiftTab := tea.KeyMsg{Type: tea.KeyShiftTab}
+
+	// Tab does nothing on the dashboard itself
+	m.state = StateDashboard
+	if _, cmd := m.Update(tab); cmd != nil {
+		t.Error("tab cycled repos on the dashboard")
+	}
+
+	m.drillIntoRepo(0)
+	m.state = StateReady
+	m.cursor = 2
+	updated, cmd := m.Update(tab)
+	m = *updated.(*Model)
+	if cmd == nil || m.config.RepoSlug() != "org/web" || m.config.Branch != "" || m.cursor != 0 || m.state != StateLoading {
+		t.Fatalf("tab: repo = %s, branch = %q, cursor = %d, state = %v", m.config.RepoSlug(), m.config.Branch, m.cursor, m.state)
+	}
+
+	// Shift-tab wraps around from the first repo to the last
+	m.state = StateReady
+	updated, _ = m.Update(shiftTab)
+	m = *updated.(*Model)
+	m.state = StateReady
+	updated, _ = m.Update(shiftTab)
+	m = *updated.(*Model)
+	if m.config.RepoSlug() != "org/docs" || m.dashboardCursor != 2 {
+		t.Errorf("shift-tab: repo = %s, dashboard cursor = %d", m.config.RepoSlug(), m.dashboardCursor)
+	}
+}
+
+func TestDrillIntoRepoStatusFilter(t *testing.T) {
+	m := NewModel(&config.Config{Repositories: []config.RepoSpec{
+		{Owner: "org", Repo: "api", Status: "failure"},
+		{Owner: "org", Repo: "web"},
+	}}, nil)
+	m.state = StateDashboard
+
+	// The repo's own status filter applies inside it, and not to the next repo
+	m.drillIntoRepo(0)
+	if m.currentStatusFilter != "failure" {
+		t.Errorf("drilled into api: filter = %q, want failure", m.currentStatusFilter)
+	}
+	m.drillIntoRepo(1)
+	if m.currentStatusFilter != "" {
+		t.Errorf("drilled into web: filter = %q, want none", m.currentStatusFilter)
+	}
+	m.drillIntoRepo(0)
+	m.returnToDashboard()
+	if m.currentStatusFilter != "" {
+		t.Errorf("back on the dashboard: filter = %q, want none", m.currentStatusFilter)
+	}
+
+	// A filter picked on the dashboard overrides the repo's
+	m.currentStatusFilter = "success"
+	m.drillIntoRepo(0)
+	if m.currentStatusFilter != "success" {
+		t.Errorf("dashboard filter success: filter = %q", m.currentStatusFilter)
+	}
+	m.returnToDashboard()
+	if m.currentStatusFilter != "success" {
+		t.Errorf("back on the dashboard: filter = %q, want success", m.currentStatusFilter)
+	}
+}
+
+func TestDrillIntoRepoKeepsConfig(t *testing.T) {
+	cfg := &config.Config{
+		Owner:     "org",
+		Repo:      "home",
+		Branch:    "dev",
+		StatePath: filepath.Join(t.TempDir(), "state.json"),
+		Repositories: []config.RepoSpec{
+			{Owner: "org", Repo: "api", Branch: "main"},
+			{Owner: "org", Repo: "web"},
+		},
+	}
+	m := NewModel(cfg, nil)
+	m.multiRepoMode = true
+	m.state = StateDashboard
+
+	m.drillIntoRepo(0)
+	m.drillIntoRepo(1)
+	if m.config.RepoSlug() != "org/web" {
+		t.Errorf("drilled into %s, want org/web", m.config.RepoSlug())
+	}
+	if cfg.Owner != "org" || cfg.Repo != "home" || cfg.Branch != "dev" {
+		t.Errorf("drilling in changed the caller's config to %s@%s", cfg.RepoSlug(), cfg.Branch)
+	}
+	if m.saveState() != nil {
+		t.Error("saved state for a drilled-in repo")
+	}
+
+	// A time zone toggled inside the repo survives the way back
+	m.config.UTC = true
+	m.returnToDashboard()
+	if m.config != cfg || !cfg.UTC {
+		t.Errorf("back on the dashboard: config = %+v, want the caller's with UTC", m.config)
+	}
+}
+
+func TestTimeZoneToggle(t *testing.T) {
+	orig := time.Local
+	time.Local = time.FixedZone("EST", -5*60*60)
+	defer func() { time.Local = orig }()
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 9, Event: "push", UpdatedAt: time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)}
+
+	if view := m.viewRunSummary(); !strings.Contains(view, "09:30 EST") {
+		t.Errorf("run summary should show local time by default:\n%s", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	m = *updated.(*Model)
+	if !m.config.UTC || !strings.Contains(m.viewRunSummary(), "14:30 UTC") {
+		t.Errorf("U should switch to UTC:\n%s", m.viewRunSummary())
+	}
+	if m.statusMessage != "Showing times in UTC" {
+		t.Errorf("status message = %q", m.statusMessage)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	m = *updated.(*Model)
+	if m.config.UTC || !strings.Contains(m.viewRunSummary(), "09:30 EST") {
+		t.Errorf("U again should switch back to local time:\n%s", m.viewRunSummary())
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	items := []string{"bootstrap", "build-test", "lint", "Build (linux)", "deploy"}
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{0, 1, 2, 3, 4}},
+		{"bt", []int{1, 0}},
+		{"BL", []int{1, 3}}, // Case-insensitive; ties keep their order
+		{"lnt", []int{2}},
+		{"xyz", []int{}},
+	}
+	for _, tt := range tests {
+		got := fuzzyFilter(tt.query, items)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("fuzzyFilter(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQuickJump(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 1}
+	m.jobs = []gh.Job{{ID: 1, Name: "lint"}, {ID: 2, Name: "unit tests"}, {ID: 3, Name: "integration tests"}}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	m = *updated.(*Model)
+	if m.state != StateQuickJump || m.quickJumpRuns {
+		t.Fatalf("state = %v, want the job finder", m.state)
+	}
+	for _, r := range "int" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = *updated.(*Model)
+	}
+	if view := m.View(); !strings.Contains(view, "(2 of 3)") || !strings.Contains(view, "integration tests") {
+		t.Errorf("finder view:\n%s", view)
+	}
+
+	// "int" ranks integration first; down moves to lint
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if m.state != StateReady || m.cursor != 0 {
+		t.Errorf("after enter: state = %v, cursor = %d, want ready on lint", m.state, m.cursor)
+	}
+
+	// From the run list, ctrl+p searches the runs; esc clears the query, then closes
+	m.runs = []gh.WorkflowRun{{ID: 1, RunNumber: 10, Name: "CI", HeadBranch: "main"}, {ID: 2, RunNumber: 11, Name: "Release", HeadBranch: "v1"}}
+	m.state = StateRunList
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("rel")})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *updated.(*Model)
+	if m.state != StateRunList || m.runListCursor != 1 {
+		t.Errorf("run jump: state = %v, run list cursor = %d, want 1", m.state, m.runListCursor)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateQuickJump || m.quickJumpQuery != "" {
+		t.Errorf("esc should clear the query first: state = %v, query = %q", m.state, m.quickJumpQuery)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateRunList || m.runListCursor != 1 {
+		t.Errorf("esc should return to the run list: state = %v, cursor = %d", m.state, m.runListCursor)
+	}
+}
+
+func TestCycleAttempt(t *testing.T) {
+	success, failure := gh.ConclusionSuccess, gh.ConclusionFailure
+	attemptDown := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/o/r/actions/runs/42/attempts/1/jobs":
+			if attemptDown {
+				http.Error(w, `{"message": "Server Error"}`, http.StatusBadGateway)
+				return
+			}
+			json.NewEncoder(w).Encode(gh.JobsResponse{Jobs: []gh.Job{{ID: 1, Name: "test", Status: gh.StatusCompleted, Conclusion: &failure}}})
+		case "/repos/o/r/actions/runs/42/attempts/1":
+			json.NewEncoder(w).Encode(gh.WorkflowRun{ID: 42, RunAttempt: 1, Status: gh.StatusCompleted, Conclusion: &failure})
+		case "/repos/o/r/actions/runs/42/jobs":
+			json.NewEncoder(w).Encode(gh.JobsResponse{Jobs: []gh.Job{{ID: 2, Name: "test", Status: gh.StatusCompleted, Conclusion: &success}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{Transport: srv.Client().Transport, BaseURL: srv.URL, AuthToken: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, client)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 42, RunNumber: 12, RunAttempt: 2, Status: gh.StatusCompleted, Conclusion: &success}
+	m.jobs = []gh.Job{{ID: 2, Name: "test", Status: gh.StatusCompleted, Conclusion: &success}}
+	if view := m.viewRunSummary(); !strings.Contains(view, "attempt 2/2") {
+		t.Errorf("summary doesn't show the attempt:\n%s", view)
+	}
+	attempt := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")}
+
+	// A failed load stays on the latest attempt, whose jobs are still shown
+	updated, cmd := m.Update(attempt)
+	m = *updated.(*Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.shownAttempt() != 0 || m.jobsErr == nil || len(m.jobs) != 1 || m.jobs[0].ID != 2 {
+		t.Fatalf("failed load: shown = %d, jobsErr = %v, jobs = %+v", m.shownAttempt(), m.jobsErr, m.jobs)
+	}
+	if view := m.viewRunSummary(); !strings.Contains(view, "attempt 2/2") {
+		t.Errorf("summary after a failed load:\n%s", view)
+	}
+	attemptDown = false
+
+	// P steps back to the first attempt, with its own jobs and conclusion
+	updated, cmd = m.Update(attempt)
+	m = *updated.(*Model)
+	if cmd == nil || m.state != StateLoading {
+		t.Fatalf("P: state = %v, want loading attempt 1", m.state)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.shownAttempt() != 1 || len(m.jobs) != 1 || m.jobs[0].ID != 1 || m.attemptRun == nil || !m.attemptRun.IsFailure() {
+		t.Fatalf("attempt 1: shown = %d, jobs = %+v, attempt run = %+v", m.shownAttempt(), m.jobs, m.attemptRun)
+	}
+	if view := m.viewRunSummary(); !strings.Contains(view, "attempt 1/2") {
+		t.Errorf("summary doesn't show attempt 1:\n%s", view)
+	}
+
+	// ...and round to the latest again
+	updated, cmd = m.Update(attempt)
+	m = *updated.(*Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.shownAttempt() != 0 || len(m.jobs) != 1 || m.jobs[0].ID != 2 || m.attemptRun != nil {
+		t.Errorf("latest: shown = %d, jobs = %+v", m.shownAttempt(), m.jobs)
+	}
+
+	// The choice doesn't carry over to another run
+	m.attempt, m.attemptRunID = 1, 42
+	m.run = &gh.WorkflowRun{ID: 43, RunAttempt: 3}
+	if m.shownAttempt() != 0 {
+		t.Errorf("shownAttempt() = %d on another run, want 0", m.shownAttempt())
+	}
+}
+
+func TestRerunFailedJob(t *testing.T) {
+	var posts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts = append(posts, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/jobs/2/rerun") {
+			// e.g. a GitHub Enterprise Server without single-job reruns
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{Transport: srv.Client().Transport, BaseURL: srv.URL, AuthToken: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	success, failure := gh.ConclusionSuccess, gh.ConclusionFailure
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, client)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 42, RunNumber: 12, Status: gh.StatusCompleted, Conclusion: &failure}
+	m.jobs = []gh.Job{
+		{ID: 1, Name: "build", Status: gh.StatusCompleted, Conclusion: &success},
+		{ID: 2, Name: "test", Status: gh.StatusCompleted, Conclusion: &failure},
+	}
+	rerun := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")}
+
+	// A job that passed isn't rerun on its own
+	updated, _ := m.Update(rerun)
+	m = *updated.(*Model)
+	if m.state != StateReady || !strings.Contains(m.statusMessage, "didn't fail") {
+		t.Fatalf("passed job: state = %v, status %q", m.state, m.statusMessage)
+	}
+
+	m.cursor = 1
+	updated, _ = m.Update(rerun)
+	m = *updated.(*Model)
+	if m.state != StateConfirm || !strings.Contains(m.confirmMessage, "Rerun job test") {
+		t.Fatalf("failed job: state = %v, prompt %q", m.state, m.confirmMessage)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = *updated.(*Model)
+	if cmd == nil {
+		t.Fatal("confirming should send the rerun")
+	}
+
+	// The single-job endpoint is missing, so all failed jobs are rerun
+	msg, ok := m.rerunJob(m.jobs[1])().(ActionResultMsg)
+	if !ok || msg.Err != nil || !strings.Contains(msg.Message, "all failed jobs") {
+		t.Fatalf("rerunJob() = %#v (%v)", msg, msg.Err)
+	}
+	if got := posts[len(posts)-1]; got != "/repos/o/r/actions/runs/42/rerun-failed-jobs" {
+		t.Errorf("fallback request = %s", got)
+	}
+}
+
+func TestHelpFromAnyState(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main"}, nil)
+	m.state = StateCompareView
+
+	// ? opens help for the compare view, and any key goes back to it
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = *updated.(*Model)
+	if m.state != StateHelp {
+		t.Fatalf("state = %v, want help", m.state)
+	}
+	help := m.View()
+	if !strings.Contains(help, "Keyboard Shortcuts: Run Comparison") || !strings.Contains(help, "close comparison") {
+		t.Errorf("compare view help:\n%s", help)
+	}
+	if strings.Contains(help, "rerun workflow") {
+		t.Errorf("compare view help lists keys that don't work there:\n%s", help)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = *updated.(*Model)
+	if m.state != StateCompareView {
+		t.Errorf("after closing help: state = %v, want the compare view", m.state)
+	}
+
+	// The run screen gets every shortcut
+	m.state = StateReady
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = *updated.(*Model)
+	if help := m.View(); !strings.Contains(help, "rerun workflow") || strings.Contains(help, "Shortcuts:") {
+		t.Errorf("run screen help:\n%s", help)
+	}
+
+	// Help opened over a refresh that has since finished doesn't go back to loading
+	m.state = StateLoading
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = *updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateReady {
+		t.Errorf("after closing help: state = %v, want ready", m.state)
+	}
+
+	// Help opened while watching closes to the run screen once the run completes
+	m.state = StateWatching
+	m.watching = true
+	m.run = &gh.WorkflowRun{ID: 1, Status: gh.StatusInProgress}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = *updated.(*Model)
+	m.run.Status = gh.StatusCompleted
+	updated, _ = m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if m.watching || m.state != StateHelp {
+		t.Fatalf("run completed under help: watching = %v, state = %v", m.watching, m.state)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *updated.(*Model)
+	if m.state != StateReady {
+		t.Errorf("after closing help: state = %v, want ready", m.state)
+	}
+
+	// The branch filter takes ? as text
+	m.state = StateBranchSelection
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = *updated.(*Model)
+	if m.state != StateBranchSelection || m.branchFilter != "?" {
+		t.Errorf("branch filter: state = %v, filter = %q", m.state, m.branchFilter)
+	}
+}
+
+func TestCommitVerification(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if strings.HasSuffix(r.URL.Path, "/fork99") {
+			http.Error(w, `{"message": "No commit found for SHA: fork99"}`, http.StatusNotFound)
+			return
+		}
+		if requests == 1 {
+			http.Error(w, `{"message": "Server Error"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"verification": {"verified": true, "reason": "valid"}}`)
+	}))
+	defer srv.Close()
+	client, err := gh.NewClientWithOptions(gh.ClientOptions{Transport: srv.Client().Transport, BaseURL: srv.URL, AuthToken: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, client)
+	m.state = StateReady
+	m.run = &gh.WorkflowRun{ID: 42, Status: gh.StatusInProgress, HeadSHA: "abc123"}
+	updated, _ := m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if _, ok := m.commitVerifications["abc123"]; !ok {
+		t.Fatal("head commit verification wasn't requested")
+	}
+	if strings.Contains(m.viewRunSummary(), "verified") {
+		t.Error("summary shows verification before it loaded")
+	}
+
+	// A blip is tried again on the next poll
+	updated, _ = m.Update(m.fetchCommitVerification()())
+	m = updated.(Model)
+	if _, ok := m.commitVerifications["abc123"]; ok {
+		t.Fatal("failed verification fetch kept its entry")
+	}
+	updated, _ = m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if _, ok := m.commitVerifications["abc123"]; !ok {
+		t.Fatal("verification wasn't requested again after a failure")
+	}
+
+	// The loaded result shows in the summary and isn't fetched again
+	updated, _ = m.Update(m.fetchCommitVerification()())
+	m = updated.(Model)
+	if !strings.Contains(m.viewRunSummary(), "🔒 verified") {
+		t.Errorf("summary:\n%s", m.viewRunSummary())
+	}
+	updated, _ = m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if requests != 2 {
+		t.Errorf("%d verification requests, want 2", requests)
+	}
+
+	m.commitVerifications["abc123"] = &gh.CommitVerification{Reason: "unsigned"}
+	if !strings.Contains(m.viewRunSummary(), "⚠ unsigned") {
+		t.Errorf("unsigned summary:\n%s", m.viewRunSummary())
+	}
+	m.styles.Symbols = true
+	if !strings.Contains(m.viewRunSummary(), "[WARN] unsigned") {
+		t.Errorf("--symbols summary:\n%s", m.viewRunSummary())
+	}
+
+	// A commit the API can't find fails the same way every time, so it's
+	// asked about once
+	m.run = &gh.WorkflowRun{ID: 43, Status: gh.StatusInProgress, HeadSHA: "fork99"}
+	updated, _ = m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	updated, _ = m.Update(m.fetchCommitVerification()())
+	m = updated.(Model)
+	updated, _ = m.Update(JobsLoadedMsg{})
+	m = updated.(Model)
+	if _, ok := m.commitVerifications["fork99"]; !ok || requests != 3 {
+		t.Errorf("after a 404: cached = %v, %d requests; want it cached after 3", ok, requests)
+	}
+}
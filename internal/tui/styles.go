@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lance0/cimon/internal/gh"
 )
@@ -12,9 +14,28 @@ const (
 	IconWarning    = "!"
 	IconInProgress = "●"
 	IconQueued     = "…"
-	IconSkipped    = "-"
+	IconSkipped    = "-" // skipped by an if: condition or a failed dependency
+	IconNeutral    = "○" // finished without passing or failing
+	IconNotRun     = "·" // completed without a conclusion: never ran
+)
+
+// Status symbols used instead of the icons with --symbols. They are told
+// apart by text alone, without relying on color or similar glyph shapes.
+const (
+	SymbolSuccess    = "[PASS]"
+	SymbolFailure    = "[FAIL]"
+	SymbolWarning    = "[WARN]"
+	SymbolInProgress = "[RUN]"
+	SymbolQueued     = "[WAIT]"
+	SymbolSkipped    = "[SKIP]"
+	SymbolNeutral    = "[NEUT]"
+	SymbolNotRun     = "[N/A]"
 )
 
+// symbolWidth is the widest status symbol; narrower ones are padded to it
+// so columns stay aligned
+const symbolWidth = 6
+
 // Colors
 var (
 	ColorGreen  = lipgloss.Color("2")  // Green
@@ -71,11 +92,19 @@ type Styles struct {
 	LogWarning   lipgloss.Style
 	LogCommand   lipgloss.Style
 	LogGroup     lipgloss.Style
+	LogStep      lipgloss.Style
 	LogTimestamp lipgloss.Style
 
+	// Log search matches; the current match stands out from the rest
+	SearchMatch   lipgloss.Style
+	SearchCurrent lipgloss.Style
+
 	// Diff styles (v0.6)
 	DiffAdded   lipgloss.Style
 	DiffRemoved lipgloss.Style
+
+	// Symbols shows text symbols and bracketed badges instead of icons (--symbols)
+	Symbols bool
 }
 
 // DefaultStyles returns the default style set
@@ -126,8 +155,13 @@ func DefaultStyles(colorEnabled bool) *Styles {
 			LogWarning:   lipgloss.NewStyle(),
 			LogCommand:   lipgloss.NewStyle(),
 			LogGroup:     lipgloss.NewStyle().Bold(true),
+			LogStep:      lipgloss.NewStyle().Bold(true).Underline(true),
 			LogTimestamp: lipgloss.NewStyle(),
 
+			// Search (no color)
+			SearchMatch:   lipgloss.NewStyle().Bold(true),
+			SearchCurrent: lipgloss.NewStyle().Bold(true).Underline(true),
+
 			// Diff (no color)
 			DiffAdded:   lipgloss.NewStyle(),
 			DiffRemoved: lipgloss.NewStyle(),
@@ -179,8 +213,13 @@ func DefaultStyles(colorEnabled bool) *Styles {
 		LogWarning:   lipgloss.NewStyle().Foreground(ColorYellow),
 		LogCommand:   lipgloss.NewStyle().Foreground(ColorCyan),
 		LogGroup:     lipgloss.NewStyle().Bold(true).Foreground(ColorWhite),
+		LogStep:      lipgloss.NewStyle().Bold(true).Foreground(ColorCyan),
 		LogTimestamp: lipgloss.NewStyle().Foreground(ColorDim),
 
+		// Search
+		SearchMatch:   lipgloss.NewStyle().Bold(true).Foreground(ColorRed),
+		SearchCurrent: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(ColorYellow),
+
 		// Diff styles
 		DiffAdded:   lipgloss.NewStyle().Foreground(ColorGreen),
 		DiffRemoved: lipgloss.NewStyle().Foreground(ColorRed),
@@ -196,83 +235,167 @@ func StatusIcon(status string, conclusion *string) string {
 		return IconInProgress
 	case gh.StatusCompleted:
 		if conclusion == nil {
-			return IconSkipped
+			return IconNotRun
 		}
 		switch *conclusion {
 		case gh.ConclusionSuccess:
 			return IconSuccess
-		case gh.ConclusionFailure:
+		case gh.ConclusionFailure, gh.ConclusionStartupFailure:
 			return IconFailure
-		case gh.ConclusionCancelled, gh.ConclusionTimedOut, gh.ConclusionActionRequired:
+		case gh.ConclusionCancelled, gh.ConclusionTimedOut, gh.ConclusionActionRequired, gh.ConclusionStale:
 			return IconWarning
-		case gh.ConclusionSkipped, gh.ConclusionNeutral:
+		case gh.ConclusionSkipped:
 			return IconSkipped
+		case gh.ConclusionNeutral:
+			return IconNeutral
 		default:
-			return IconSkipped
+			return IconNotRun
 		}
 	default:
 		return IconQueued
 	}
 }
 
-// StatusIconStyled returns a styled icon for a status/conclusion
+// StatusSymbol returns the text symbol for a status/conclusion combination
+func StatusSymbol(status string, conclusion *string) string {
+	switch StatusIcon(status, conclusion) {
+	case IconSuccess:
+		return SymbolSuccess
+	case IconFailure:
+		return SymbolFailure
+	case IconWarning:
+		return SymbolWarning
+	case IconInProgress:
+		return SymbolInProgress
+	case IconSkipped:
+		return SymbolSkipped
+	case IconNeutral:
+		return SymbolNeutral
+	case IconNotRun:
+		return SymbolNotRun
+	default:
+		return SymbolQueued
+	}
+}
+
+// StatusIconStyled returns a styled icon for a status/conclusion, or a
+// styled symbol with --symbols
 func (s *Styles) StatusIconStyled(status string, conclusion *string) string {
-	icon := StatusIcon(status, conclusion)
+	if s.Symbols {
+		return s.iconStyle(status, conclusion).Render(fmt.Sprintf("%-*s", symbolWidth, StatusSymbol(status, conclusion)))
+	}
+	return s.iconStyle(status, conclusion).Render(StatusIcon(status, conclusion))
+}
 
+// StatusGlyphStyled returns a styled icon for a status/conclusion even with
+// --symbols, for places too narrow for symbols such as sparklines
+func (s *Styles) StatusGlyphStyled(status string, conclusion *string) string {
+	return s.iconStyle(status, conclusion).Render(StatusIcon(status, conclusion))
+}
+
+// iconStyle returns the icon style for a status/conclusion
+func (s *Styles) iconStyle(status string, conclusion *string) lipgloss.Style {
 	switch status {
 	case gh.StatusQueued:
-		return s.IconQueued.Render(icon)
+		return s.IconQueued
 	case gh.StatusInProgress:
-		return s.IconInProgress.Render(icon)
+		return s.IconInProgress
 	case gh.StatusCompleted:
 		if conclusion == nil {
-			return s.IconSkipped.Render(icon)
+			return s.IconSkipped
 		}
 		switch *conclusion {
 		case gh.ConclusionSuccess:
-			return s.IconSuccess.Render(icon)
-		case gh.ConclusionFailure:
-			return s.IconFailure.Render(icon)
-		case gh.ConclusionCancelled, gh.ConclusionTimedOut, gh.ConclusionActionRequired:
-			return s.IconFailure.Render(icon)
+			return s.IconSuccess
+		case gh.ConclusionFailure, gh.ConclusionStartupFailure:
+			return s.IconFailure
+		case gh.ConclusionCancelled, gh.ConclusionTimedOut, gh.ConclusionActionRequired, gh.ConclusionStale:
+			return s.IconFailure
 		default:
-			return s.IconSkipped.Render(icon)
+			return s.IconSkipped
 		}
 	default:
-		return s.IconQueued.Render(icon)
+		return s.IconQueued
 	}
 }
 
-// StatusBadge returns a styled status badge text
+// StatusNote names the quiet outcomes that share the dim icon style -
+// "skipped", "neutral" or "not run" - so they can be told apart in job
+// lists, and returns "" for every other status
+func StatusNote(status string, conclusion *string) string {
+	switch StatusIcon(status, conclusion) {
+	case IconSkipped:
+		return "skipped"
+	case IconNeutral:
+		return "neutral"
+	case IconNotRun:
+		return "not run"
+	}
+	return ""
+}
+
+// statusLegend lists each status icon with what it means, for the help view
+var statusLegend = []struct {
+	status     string
+	conclusion string
+	meaning    string
+}{
+	{gh.StatusCompleted, gh.ConclusionSuccess, "passed"},
+	{gh.StatusCompleted, gh.ConclusionFailure, "failed"},
+	{gh.StatusCompleted, gh.ConclusionCancelled, "cancelled, timed out or waiting for approval"},
+	{gh.StatusInProgress, "", "running"},
+	{gh.StatusQueued, "", "queued"},
+	{gh.StatusCompleted, gh.ConclusionSkipped, "skipped: its if: condition was false or a job it needs failed"},
+	{gh.StatusCompleted, gh.ConclusionNeutral, "neutral: finished without passing or failing"},
+	{gh.StatusCompleted, "", "not run: completed without a result"},
+}
+
+// StatusBadge returns a styled status badge text. With --symbols the
+// badge is bracketed like the symbols, so it stands out without color.
 func (s *Styles) StatusBadge(status string, conclusion *string) string {
+	style, text := s.badge(status, conclusion)
+	if s.Symbols {
+		text = "[" + text + "]"
+	}
+	return style.Render(text)
+}
+
+// badge returns the style and text of a status badge
+func (s *Styles) badge(status string, conclusion *string) (lipgloss.Style, string) {
 	switch status {
 	case gh.StatusQueued:
-		return s.StatusQueued.Render("QUEUED")
+		return s.StatusQueued, "QUEUED"
 	case gh.StatusInProgress:
-		return s.StatusInProgress.Render("IN PROGRESS")
+		return s.StatusInProgress, "IN PROGRESS"
+	case gh.StatusWaiting:
+		return s.StatusQueued, "WAITING"
 	case gh.StatusCompleted:
 		if conclusion == nil {
-			return s.Dim.Render("UNKNOWN")
+			return s.Dim, "NOT RUN"
 		}
 		switch *conclusion {
 		case gh.ConclusionSuccess:
-			return s.StatusSuccess.Render("PASSED")
+			return s.StatusSuccess, "PASSED"
 		case gh.ConclusionFailure:
-			return s.StatusFailure.Render("FAILED")
+			return s.StatusFailure, "FAILED"
 		case gh.ConclusionCancelled:
-			return s.StatusFailure.Render("CANCELLED")
+			return s.StatusFailure, "CANCELLED"
 		case gh.ConclusionTimedOut:
-			return s.StatusFailure.Render("TIMED OUT")
+			return s.StatusFailure, "TIMED OUT"
 		case gh.ConclusionActionRequired:
-			return s.StatusFailure.Render("ACTION REQUIRED")
+			return s.StatusFailure, "ACTION REQUIRED"
+		case gh.ConclusionStartupFailure:
+			return s.StatusFailure, "STARTUP FAILED"
+		case gh.ConclusionStale:
+			return s.StatusFailure, "STALE"
 		case gh.ConclusionSkipped:
-			return s.Dim.Render("SKIPPED")
+			return s.Dim, "SKIPPED"
 		case gh.ConclusionNeutral:
-			return s.Dim.Render("NEUTRAL")
+			return s.Dim, "NEUTRAL"
 		default:
-			return s.Dim.Render(*conclusion)
+			return s.Dim, *conclusion
 		}
 	default:
-		return s.Dim.Render(status)
+		return s.Dim, status
 	}
 }
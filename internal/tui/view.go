@@ -1,12 +1,18 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lance0/cimon/internal/config"
+	"github.com/lance0/cimon/internal/gh"
 )
 
 // View implements tea.Model
@@ -38,6 +44,18 @@ func (m Model) View() string {
 		return m.viewCompareSelect()
 	case StateCompareView:
 		return m.viewCompareView()
+	case StateConfirm:
+		return m.viewConfirm()
+	case StateDashboard:
+		return m.viewDashboard()
+	case StateAnnotations:
+		return m.viewAnnotations()
+	case StateRunList:
+		return m.viewRunList()
+	case StateFailureSummary:
+		return m.viewFailureSummary()
+	case StateQuickJump:
+		return m.viewQuickJump()
 	default:
 		return m.viewReady()
 	}
@@ -74,14 +92,60 @@ func (m Model) viewError() string {
 }
 
 func (m Model) getErrorHint() string {
-	if m.err == nil {
+	return m.errorHint(m.err)
+}
+
+// errorHint suggests what the user can do about err.
+func (m Model) errorHint(err error) string {
+	if err == nil {
 		return ""
 	}
 
-	errStr := strings.ToLower(m.err.Error())
+	// Typed errors carry more than the message, so check them first
+	var samlErr *gh.SAMLError
+	if errors.As(err, &samlErr) {
+		return "Your organization requires SSO authorization for this token - run 'gh auth refresh' or authorize it in your GitHub token settings"
+	}
+	var permErr *gh.PermissionError
+	if errors.As(err, &permErr) {
+		return permErr.Hint()
+	}
+	var secondaryErr *gh.SecondaryRateLimitError
+	if errors.As(err, &secondaryErr) {
+		if secondaryErr.RetryAfter > 0 {
+			return fmt.Sprintf("GitHub secondary rate limit hit - wait %s, then retry with a longer --poll interval", secondaryErr.RetryAfter)
+		}
+		return "GitHub secondary rate limit hit - wait a minute, then retry with a longer --poll interval"
+	}
+	gitlab := m.config.Provider == config.ProviderGitLab
+	if errors.Is(err, gh.ErrNoWorkflows) {
+		if gitlab {
+			return "No CI is configured in this project - add a .gitlab-ci.yml to start using GitLab CI/CD"
+		}
+		return "No workflows are configured in this repo - add a workflow file under .github/workflows/ to start using GitHub Actions"
+	}
+	var noRunsErr *gh.NoRunsError
+	if errors.As(err, &noRunsErr) {
+		if gitlab {
+			return "CI is configured but no pipeline has run on this branch - push a commit, press 'b' to pick another branch, or use --branch all"
+		}
+		return "Workflows exist but none have run on this branch - push a commit, press 'b' to pick another branch, or use --branch all"
+	}
+	if errors.Is(err, gh.ErrNoRuns) {
+		return "No runs found on this branch - push a commit, press 'b' to pick another branch, or use --branch all"
+	}
+	var rateLimitErr *gh.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if !rateLimitErr.Reset.IsZero() {
+			return fmt.Sprintf("GitHub API rate limit exceeded - it resets at %s", m.config.FormatTime(rateLimitErr.Reset, "15:04:05 MST"))
+		}
+		return "GitHub API rate limit exceeded - wait a few minutes before retrying"
+	}
+
+	errStr := strings.ToLower(err.Error())
 
 	if strings.Contains(errStr, "authentication") || strings.Contains(errStr, "401") {
-		return "Run 'gh auth login' to authenticate with GitHub, or set GITHUB_TOKEN environment variable"
+		return "Run 'gh auth login' to authenticate with GitHub, or set the GH_TOKEN environment variable"
 	}
 	if strings.Contains(errStr, "403") || strings.Contains(errStr, "forbidden") {
 		return "Check that you have access to this repository and the correct permissions"
@@ -117,7 +181,9 @@ func (m Model) viewReady() string {
 
 	// Header
 	b.WriteString(m.viewHeader())
-	b.WriteString("\n")
+	if !m.compact {
+		b.WriteString("\n")
+	}
 
 	// v0.8: Multi-repo view
 	if m.multiRepoMode {
@@ -126,6 +192,7 @@ func (m Model) viewReady() string {
 		} else {
 			b.WriteString("\n  No workflow runs found across repositories\n")
 		}
+		b.WriteString(m.viewStatusMessage())
 
 		// Footer
 		b.WriteString("\n")
@@ -134,14 +201,27 @@ func (m Model) viewReady() string {
 	}
 
 	// Run summary (single-repo mode)
-	if m.run != nil {
+	if m.run != nil && m.compact {
+		b.WriteString(m.viewCompactRunSummary())
+	} else if m.run != nil {
 		b.WriteString(m.viewRunSummary())
 		b.WriteString("\n")
 	}
 
 	// Jobs table
-	if len(m.jobs) > 0 {
+	if m.jobsErr != nil && m.run != nil {
+		b.WriteString(m.viewJobsWarning())
+		// The last good jobs for this run stay listed under the warning
+		if len(m.jobs) > 0 {
+			b.WriteString(m.viewJobs())
+		}
+	} else if len(m.jobs) > 0 {
 		b.WriteString(m.viewJobs())
+	} else if m.waitingForJobs() {
+		// A freshly queued run has no jobs until a runner picks it up
+		b.WriteString(fmt.Sprintf("\n  %s Waiting for jobs to be scheduled...\n", m.spinner.View()))
+	} else if m.run != nil && m.config.NoJobs {
+		b.WriteString(m.styles.Dim.Render("\n  Jobs not loaded (--no-jobs)\n"))
 	} else if m.run != nil {
 		b.WriteString("\n  No jobs available\n")
 	} else if len(m.runs) > 0 {
@@ -150,21 +230,37 @@ func (m Model) viewReady() string {
 		b.WriteString("\n  No workflow data available\n")
 	}
 
+	// Transient status message (rerun/cancel results) - auto-clear after 5 seconds
+	b.WriteString(m.viewStatusMessage())
+
 	// Footer
-	b.WriteString("\n")
+	if !m.compact {
+		b.WriteString("\n")
+	}
 	b.WriteString(m.viewFooter())
 
 	return b.String()
 }
 
+// headerTitleStyle styles the header title, reversed while --bell flashes it
+func (m Model) headerTitleStyle() lipgloss.Style {
+	if m.bellFlashing() {
+		return m.styles.RepoName.Reverse(true)
+	}
+	return m.styles.RepoName
+}
+
 func (m Model) viewHeader() string {
 	var b strings.Builder
 
-	b.WriteString("\n  ")
+	if !m.compact {
+		b.WriteString("\n")
+	}
+	b.WriteString("  ")
 
 	// v0.8: Multi-repo header
 	if m.multiRepoMode {
-		b.WriteString(m.styles.RepoName.Render("Multi-Repo Dashboard"))
+		b.WriteString(m.headerTitleStyle().Render("Multi-Repo Dashboard"))
 		repoCount := len(m.config.Repositories)
 		b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" (%d repos)", repoCount)))
 
@@ -182,10 +278,14 @@ func (m Model) viewHeader() string {
 				b.WriteString(m.styles.Separator.Render(filterInfo))
 			}
 		}
+		if m.eventFilter != "" {
+			b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [event: %s]", m.eventFilter)))
+		}
 
 		if m.watching {
 			b.WriteString("  ")
 			b.WriteString(m.styles.Watching.Render("◉ Watching"))
+			b.WriteString(m.viewRefreshFailure())
 		}
 
 		b.WriteString("\n")
@@ -193,9 +293,13 @@ func (m Model) viewHeader() string {
 	}
 
 	// Single-repo header (existing)
-	b.WriteString(m.styles.RepoName.Render(m.config.RepoSlug()))
+	b.WriteString(m.headerTitleStyle().Render(m.config.RepoSlug()))
 	b.WriteString(m.styles.Separator.Render(" • "))
-	b.WriteString(m.styles.Branch.Render(m.config.Branch))
+	if m.config.Branch != "" {
+		b.WriteString(m.styles.Branch.Render(m.config.Branch))
+	} else {
+		b.WriteString(m.styles.Branch.Render("all branches"))
+	}
 
 	// Show current filter if active
 	if m.currentStatusFilter != "" {
@@ -211,6 +315,22 @@ func (m Model) viewHeader() string {
 			b.WriteString(m.styles.Separator.Render(filterInfo))
 		}
 	}
+	if m.eventFilter != "" {
+		b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [event: %s]", m.eventFilter)))
+	}
+
+	// Show creation time window if active
+	if window := m.timeWindowLabel(); window != "" {
+		b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [%s]", window)))
+	}
+
+	// Show job filter if active
+	if m.jobStatusFilter != "" {
+		b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [jobs: %s]", jobStatusFilterLabel(m.jobStatusFilter))))
+	}
+	if m.jobSort != jobSortDefault {
+		b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [sort: %s]", m.jobSort)))
+	}
 
 	// Show run navigation info if we have multiple runs
 	if len(m.runs) > 1 {
@@ -218,9 +338,15 @@ func (m Model) viewHeader() string {
 		b.WriteString(m.styles.Separator.Render(runInfo))
 	}
 
+	if spark := m.runSparkline(m.runs); spark != "" {
+		b.WriteString("  ")
+		b.WriteString(spark)
+	}
+
 	if m.watching {
 		b.WriteString("  ")
 		b.WriteString(m.styles.Watching.Render("◉ Watching"))
+		b.WriteString(m.viewRefreshFailure())
 	}
 
 	b.WriteString("\n")
@@ -228,6 +354,35 @@ func (m Model) viewHeader() string {
 	return b.String()
 }
 
+// viewRefreshFailure flags a watch-mode refresh that failed transiently,
+// so the data on screen is known to be stale until the next poll succeeds
+func (m Model) viewRefreshFailure() string {
+	if m.refreshErr == nil {
+		return ""
+	}
+	return "  " + m.styles.LogWarning.Render("⚠ refresh failed, retrying")
+}
+
+// sparklineRuns is how many recent runs a sparkline shows
+const sparklineRuns = 10
+
+// runSparkline renders the outcomes of the most recent runs as a row of
+// status icons, newest first. It is empty without color, where the icons
+// are too similar to tell apart at a glance.
+func (m Model) runSparkline(runs []gh.WorkflowRun) string {
+	if !m.colorEnabled || len(runs) == 0 {
+		return ""
+	}
+	if len(runs) > sparklineRuns {
+		runs = runs[:sparklineRuns]
+	}
+	var b strings.Builder
+	for _, run := range runs {
+		b.WriteString(m.styles.StatusGlyphStyled(run.Status, run.Conclusion))
+	}
+	return b.String()
+}
+
 func (m Model) viewRunSummary() string {
 	var b strings.Builder
 
@@ -236,12 +391,10 @@ func (m Model) viewRunSummary() string {
 	b.WriteString("  ")
 
 	// Workflow name and run number
-	if run.Name != "" {
-		b.WriteString(m.styles.Dim.Render(run.Name))
-		b.WriteString(m.styles.Separator.Render(" #"))
-		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%d", run.RunNumber)))
-		b.WriteString("  ")
-	}
+	b.WriteString(m.styles.Dim.Render(run.DisplayName()))
+	b.WriteString(m.styles.Separator.Render(" #"))
+	b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%d", run.RunNumber)))
+	b.WriteString("  ")
 
 	// Status badge
 	b.WriteString(m.styles.StatusBadge(run.Status, run.Conclusion))
@@ -254,21 +407,194 @@ func (m Model) viewRunSummary() string {
 		b.WriteString(m.styles.Dim.Render(actor))
 	}
 
-	// Time ago
+	// Runs can come from any branch, so say which
+	if m.config.Branch == "" && run.HeadBranch != "" {
+		b.WriteString(m.styles.Dim.Render(" on "))
+		b.WriteString(m.styles.Branch.Render(run.HeadBranch))
+	}
+
+	// Time ago, with the clock time in the chosen zone
 	b.WriteString(m.styles.Separator.Render(" • "))
-	b.WriteString(m.styles.Dim.Render(timeAgo(run.UpdatedAt)))
+	b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%s (%s)", TimeAgo(run.UpdatedAt), m.config.FormatTime(run.UpdatedAt, "15:04 MST"))))
+
+	// How long a running run has gone, against how long its workflow usually takes
+	if run.Status == gh.StatusInProgress {
+		if typical, ok := gh.TypicalDuration(m.runs, run.Name); ok {
+			b.WriteString(m.styles.Separator.Render(" • "))
+			b.WriteString(m.styles.Dim.Render(fmt.Sprintf("running %s (typ. ~%s)",
+				formatDuration(run.WallDuration(time.Now())), formatDuration(typical.Round(time.Minute)))))
+		}
+	}
+
+	// Which attempt of a re-run run the jobs below belong to
+	if run.RunAttempt > 1 {
+		b.WriteString(m.styles.Separator.Render(" • "))
+		if attempt := m.shownAttempt(); attempt > 0 {
+			b.WriteString(m.styles.LogWarning.Render(fmt.Sprintf("attempt %d/%d", attempt, run.RunAttempt)))
+			if m.attemptRun != nil {
+				b.WriteString(" ")
+				b.WriteString(m.styles.StatusBadge(m.attemptRun.Status, m.attemptRun.Conclusion))
+			}
+		} else {
+			b.WriteString(m.styles.Dim.Render(fmt.Sprintf("attempt %d/%d", run.RunAttempt, run.RunAttempt)))
+		}
+		b.WriteString(m.styles.Dim.Render(" ("))
+		b.WriteString(m.styles.HelpKey.Render(m.keys.Attempt.Help().Key))
+		b.WriteString(m.styles.Dim.Render(" to switch)"))
+	}
+
+	// A queued run may be held behind another in its concurrency group
+	if blocking := gh.BlockingRun(m.runs, run); blocking != nil {
+		b.WriteString(m.styles.Separator.Render(" • "))
+		b.WriteString(m.styles.LogWarning.Render(fmt.Sprintf("may be queued behind run #%d", blocking.RunNumber)))
+	}
+
+	// The pull request or commit behind the run
+	if label, _ := run.ChangeLink(); label != "" {
+		b.WriteString(m.styles.Separator.Render(" • "))
+		b.WriteString(m.styles.Branch.Render(label))
+		b.WriteString(m.styles.Dim.Render(" ("))
+		b.WriteString(m.styles.HelpKey.Render(m.keys.OpenChange.Help().Key))
+		b.WriteString(m.styles.Dim.Render(" to open)"))
+	}
+
+	// Whether the head commit's signature checked out, once that's loaded
+	if v := m.commitVerifications[run.HeadSHA]; v != nil {
+		b.WriteString(m.styles.Separator.Render(" • "))
+		lock, warning := "🔒", "⚠"
+		if m.styles.Symbols {
+			lock, warning = SymbolSuccess, SymbolWarning
+		}
+		switch {
+		case v.Verified:
+			b.WriteString(m.styles.StatusSuccess.Render(lock + " verified"))
+		case v.Reason == "unsigned" || v.Reason == "":
+			b.WriteString(m.styles.LogWarning.Render(warning + " unsigned"))
+		default:
+			b.WriteString(m.styles.LogWarning.Render(warning + " unverified (" + strings.ReplaceAll(v.Reason, "_", " ") + ")"))
+		}
+	}
 
 	b.WriteString("\n")
 
+	if timing := m.runTimingSummary(); timing != "" {
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render(timing))
+		b.WriteString("\n")
+	}
+
+	// Runs held for manual approval go nowhere until someone acts
+	if run.IsActionRequired() {
+		b.WriteString("  ")
+		b.WriteString(m.styles.StatusFailure.Render("⚠ Action Required"))
+		b.WriteString(m.styles.Dim.Render(" - this run needs approval before it can run; press "))
+		b.WriteString(m.styles.HelpKey.Render(m.keys.Deployments.Help().Key))
+		b.WriteString(m.styles.Dim.Render(" to approve it in the browser"))
+		b.WriteString("\n")
+	}
+
+	// Environments waiting on approval
+	if len(m.pendingDeployments) > 0 {
+		b.WriteString("  ")
+		b.WriteString(m.styles.LogWarning.Render("Waiting for approval: " + pendingEnvironmentNames(m.pendingDeployments)))
+		if len(gh.EnvironmentIDs(m.pendingDeployments)) > 0 {
+			b.WriteString(m.styles.Dim.Render(" - press "))
+			b.WriteString(m.styles.HelpKey.Render(m.keys.Deployments.Help().Key))
+			b.WriteString(m.styles.Dim.Render(" to review"))
+		}
+		b.WriteString("\n")
+	}
+
 	return b.String()
 }
 
+// runTimingSummary compares the time spent in the run's jobs with its
+// wall-clock duration, e.g. "CPU: 24m across 8 jobs, wall: 6m", adding
+// the billable minutes once they're loaded
+func (m Model) runTimingSummary() string {
+	total, count := gh.JobTime(m.jobs)
+	if count == 0 {
+		return ""
+	}
+	noun := "jobs"
+	if count == 1 {
+		noun = "job"
+	}
+
+	wall := m.run.WallDuration(time.Now())
+	var billable string
+	if m.runTiming != nil && m.runTimingID == m.run.ID {
+		if d := m.runTiming.RunDuration(); d > 0 {
+			wall = d
+		}
+		billable = m.runTiming.BillableSummary(formatDuration)
+	}
+
+	summary := fmt.Sprintf("CPU: %s across %d %s, wall: %s", formatDuration(total), count, noun, formatDuration(wall))
+	if billable != "" {
+		summary += " • billable: " + billable
+	}
+	return summary
+}
+
+// jobListTop returns the screen row of the first job in the single-repo
+// ready view, matching the layout of viewReady
+func (m Model) jobListTop() int {
+	top := strings.Count(m.viewHeader(), "\n")
+	if m.compact {
+		if m.run != nil {
+			top += strings.Count(m.viewCompactRunSummary(), "\n")
+		}
+	} else {
+		top++
+		if m.run != nil {
+			top += strings.Count(m.viewRunSummary(), "\n") + 1
+		}
+		top++ // viewJobs starts with a blank line
+	}
+	if m.jobsErr != nil && m.run != nil {
+		top += strings.Count(m.viewJobsWarning(), "\n")
+	}
+	return top
+}
+
+// viewJobsWarning says the jobs couldn't be loaded, above the last good ones
+func (m Model) viewJobsWarning() string {
+	return "\n  " + m.styles.LogWarning.Render("⚠ couldn't load jobs: "+m.jobsErr.Error()) + "\n" +
+		"  " + m.styles.Dim.Render(m.errorHint(m.jobsErr)) + "\n"
+}
+
+// viewCompactRunSummary is the run summary on a single line for the
+// compact layout: workflow, number, status and age
+func (m Model) viewCompactRunSummary() string {
+	run := m.run
+	return "  " + m.styles.Dim.Render(fmt.Sprintf("%s #%d", run.DisplayName(), run.RunNumber)) + " " +
+		m.styles.StatusBadge(run.Status, run.Conclusion) +
+		m.styles.Separator.Render(" • ") + m.styles.Dim.Render(TimeAgo(run.UpdatedAt)) + "\n"
+}
+
 func (m Model) viewJobs() string {
 	var b strings.Builder
 
-	b.WriteString("\n")
+	if !m.compact {
+		b.WriteString("\n")
+	}
 
-	for i, job := range m.jobs {
+	jobs := m.visibleJobs()
+	if len(jobs) == 0 {
+		b.WriteString(fmt.Sprintf("  No %s jobs (%d total) - press 't' to change filter\n",
+			jobStatusFilterLabel(m.jobStatusFilter), len(m.jobs)))
+		return b.String()
+	}
+
+	if m.compact {
+		for i, job := range jobs {
+			b.WriteString(m.viewCompactJob(job, i == m.cursor))
+		}
+		return b.String()
+	}
+
+	for i, job := range jobs {
 		// Icon
 		b.WriteString("  ")
 		b.WriteString(m.styles.StatusIconStyled(job.Status, job.Conclusion))
@@ -282,6 +608,12 @@ func (m Model) viewJobs() string {
 			b.WriteString(m.styles.JobName.Render(name))
 		}
 
+		// Skipped, neutral and unrun jobs look alike in dim, so say which
+		if note := StatusNote(job.Status, job.Conclusion); note != "" {
+			b.WriteString("  ")
+			b.WriteString(m.styles.Dim.Render(note))
+		}
+
 		// Duration (if completed)
 		if job.IsCompleted() && job.Duration() > 0 {
 			b.WriteString("  ")
@@ -294,18 +626,52 @@ func (m Model) viewJobs() string {
 	return b.String()
 }
 
+// viewCompactJob renders a job on one line for the compact layout: icon,
+// name cut to fit the window, and duration once it has one
+func (m Model) viewCompactJob(job gh.Job, selected bool) string {
+	icon := m.styles.StatusIconStyled(job.Status, job.Conclusion)
+	var duration string
+	if job.IsCompleted() && job.Duration() > 0 {
+		duration = " " + formatDuration(job.Duration())
+	}
+
+	name := job.Name
+	if maxName := m.width - 3 - lipgloss.Width(icon) - len(duration); m.width > 0 && len(name) > maxName {
+		name = name[:max(maxName-3, 0)] + "..."
+	}
+	if selected {
+		name = m.styles.Selected.Render(name)
+	} else {
+		name = m.styles.JobName.Render(name)
+	}
+
+	return "  " + icon + " " + name + m.styles.JobDuration.Render(duration) + "\n"
+}
+
 func (m Model) viewFooter() string {
 	var b strings.Builder
 
 	b.WriteString("  ")
 
 	var bindings []key.Binding
-	if m.state == StateStatusFilter {
+	if m.compact && (m.state == StateReady || m.state == StateWatching) && !m.multiRepoMode && !m.showingJobDetails {
+		// The compact layout keeps only the way back to everything else
+		bindings = []key.Binding{m.keys.Compact, m.keys.Help, m.keys.Quit}
+	} else if m.state == StateDashboard {
+		bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Dashboard, m.keys.Refresh, m.keys.Watch, m.keys.Quit}
+	} else if m.multiRepoMode && m.state == StateReady {
+		bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Dashboard, m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.Quit}
+	} else if m.state == StateStatusFilter {
 		// In status filter, show navigation and selection options
 		bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Filter, m.keys.Quit}
-	} else if m.state == StateBranchSelection {
-		// In branch selection, show navigation and selection options
-		bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.BranchSelect, m.keys.Quit}
+	} else if m.state == StateBranchSelection || m.state == StateQuickJump {
+		// In branch selection and quick jump letters go to the filter, so only the arrows and ctrl+c apply
+		up, down, back, quit := m.keys.Up, m.keys.Down, m.keys.Escape, m.keys.Quit
+		up.SetHelp("↑", "up")
+		down.SetHelp("↓", "down")
+		back.SetHelp("esc", "clear filter/back")
+		quit.SetHelp("ctrl+c", "quit")
+		bindings = []key.Binding{up, down, m.keys.Enter, back, quit}
 	} else if m.state == StateLogViewer {
 		// In log viewer, show navigation and exit options
 		if m.logSearchTerm != "" && len(m.logSearchMatches) > 0 {
@@ -314,21 +680,31 @@ func (m Model) viewFooter() string {
 			// Show view toggle in multi-job mode
 			bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Search, m.keys.LogViewToggle, m.keys.LogSave, m.keys.Logs, m.keys.Quit}
 		} else {
-			bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Search, m.keys.LogFilter, m.keys.LogSave, m.keys.LogHighlight, m.keys.Logs, m.keys.Quit}
+			bindings = []key.Binding{m.keys.Up, m.keys.Down, m.keys.Search, m.keys.LogFilter, m.keys.LogSave, m.keys.LogHighlight, m.keys.LineNumbers, m.keys.Timestamps, m.keys.Logs, m.keys.Quit}
+			if len(m.logStepLines) > 1 {
+				bindings = append([]key.Binding{m.keys.PrevStep, m.keys.NextStep}, bindings...)
+			}
 		}
 	} else if len(m.jobs) > 0 && !m.showingJobDetails && len(m.runs) > 1 {
 		// Show run navigation, Enter and Logs keys when multiple runs available
-		bindings = []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.PrevRun, m.keys.NextRun, m.keys.BranchSelect, m.keys.Filter, m.keys.LogMulti, m.keys.LogCompare, m.keys.Enter, m.keys.Logs, m.keys.Quit}
+		bindings = []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.PrevRun, m.keys.NextRun, m.keys.RunList, m.keys.BranchSelect, m.keys.Filter, m.keys.JobFilter, m.keys.JobSort, m.keys.LogMulti, m.keys.LogCompare, m.keys.Enter, m.keys.Logs, m.keys.Quit}
 	} else if len(m.jobs) > 0 && !m.showingJobDetails {
 		// Show Enter and Logs keys when jobs are available and not in details mode
-		bindings = []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.BranchSelect, m.keys.Filter, m.keys.LogMulti, m.keys.Enter, m.keys.Logs, m.keys.Quit}
+		bindings = []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.BranchSelect, m.keys.Filter, m.keys.JobFilter, m.keys.JobSort, m.keys.LogMulti, m.keys.Enter, m.keys.Logs, m.keys.Quit}
 	} else if m.showingJobDetails {
-		// Show Enter and Logs keys in job details mode
-		bindings = []key.Binding{m.keys.Refresh, m.keys.Open, m.keys.Logs, m.keys.Enter, m.keys.Quit}
+		// In job details, enter/l open the selected step's log
+		stepLogs, back := m.keys.Enter, m.keys.Escape
+		stepLogs.SetHelp("enter/l", "step logs")
+		back.SetHelp("esc", "back")
+		bindings = []key.Binding{m.keys.Up, m.keys.Down, stepLogs, m.keys.TailLogs, m.keys.Refresh, m.keys.Open, back, m.keys.Quit}
 	} else {
 		bindings = []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.BranchSelect, m.keys.Filter, m.keys.Quit}
 	}
 
+	if m.drilledIntoRepo && m.state != StateLogViewer {
+		bindings = append([]key.Binding{m.keys.Dashboard, m.keys.NextRepo}, bindings...)
+	}
+
 	for i, binding := range bindings {
 		if i > 0 {
 			b.WriteString("  ")
@@ -343,9 +719,160 @@ func (m Model) viewFooter() string {
 	return b.String()
 }
 
+// viewStatusMessage renders the transient status message, if still fresh
+func (m Model) viewStatusMessage() string {
+	if m.statusMessage == "" || time.Since(m.statusMessageTime) >= 5*time.Second {
+		return ""
+	}
+	style := m.styles.StatusSuccess
+	if m.statusMessageErr {
+		style = m.styles.Error
+	}
+	return "\n  " + style.Render(m.statusMessage) + "\n"
+}
+
+// viewConfirm renders the rerun/cancel/deployment review confirmation prompt
+func (m Model) viewConfirm() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewHeader())
+	b.WriteString("\n")
+
+	if m.run != nil {
+		b.WriteString(m.viewRunSummary())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("  ")
+	b.WriteString(m.styles.LogWarning.Render(m.confirmMessage))
+	b.WriteString("\n\n")
+
+	b.WriteString("  ")
+	b.WriteString(m.styles.HelpKey.Render("y"))
+	b.WriteString(" confirm  ")
+	if m.rejectAction != nil {
+		b.WriteString(m.styles.HelpKey.Render("x"))
+		b.WriteString(" reject  ")
+	}
+	b.WriteString(m.styles.HelpKey.Render("n/esc"))
+	b.WriteString(" cancel\n")
+
+	return b.String()
+}
+
+// viewDashboard renders one row per configured repo with its latest run status
+func (m Model) viewDashboard() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewHeader())
+	b.WriteString("\n")
+	b.WriteString(m.viewRepoFailures())
+
+	// Calculate max width for repo slug
+	maxRepoLen := 0
+	for _, spec := range m.config.Repositories {
+		if len(spec.Slug()) > maxRepoLen {
+			maxRepoLen = len(spec.Slug())
+		}
+	}
+
+	for i, spec := range m.config.Repositories {
+		// Selection indicator
+		if i == m.dashboardCursor {
+			b.WriteString(m.styles.Selected.Render("→ "))
+		} else {
+			b.WriteString("  ")
+		}
+
+		sr := m.latestRunForRepo(spec)
+		if sr == nil {
+			b.WriteString(m.styles.Dim.Render(IconSkipped))
+			b.WriteString(" ")
+			b.WriteString(m.styles.Branch.Render(fmt.Sprintf("%-*s", maxRepoLen, spec.Slug())))
+			b.WriteString(m.styles.Separator.Render(" • "))
+			if m.repoFailed(spec) {
+				b.WriteString(m.styles.Error.Render("failed to load"))
+			} else {
+				b.WriteString(m.styles.Dim.Render("no runs"))
+			}
+			b.WriteString("\n")
+			continue
+		}
+		run := sr.Run
+
+		b.WriteString(m.styles.StatusIconStyled(run.Status, run.Conclusion))
+		b.WriteString(" ")
+		b.WriteString(m.styles.Branch.Render(fmt.Sprintf("%-*s", maxRepoLen, spec.Slug())))
+		b.WriteString(m.styles.Separator.Render(" • "))
+
+		// Status badge
+		b.WriteString(m.styles.StatusBadge(run.Status, run.Conclusion))
+		b.WriteString("  ")
+
+		// Workflow name and run number
+		b.WriteString(m.styles.JobName.Render(run.DisplayName()))
+		b.WriteString(m.styles.Separator.Render(" #"))
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%d", run.RunNumber)))
+		b.WriteString("  ")
+
+		// Branch and last update
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("(%s)", run.HeadBranch)))
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render(TimeAgo(run.UpdatedAt)))
+
+		// The repo's own filter from cimon.yml, unless the f filter overrides it
+		if spec.Status != "" && m.currentStatusFilter == "" {
+			b.WriteString(m.styles.Separator.Render(fmt.Sprintf(" [%s]", spec.Status)))
+		}
+
+		if spark := m.runSparkline(m.repoRuns(spec)); spark != "" {
+			b.WriteString("  ")
+			b.WriteString(spark)
+		}
+
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.viewStatusMessage())
+
+	b.WriteString("\n")
+	b.WriteString(m.viewFooter())
+
+	return b.String()
+}
+
+// viewRepoFailures renders a banner naming the repos that failed to load on
+// the last multi-repo fetch, or nothing if they all loaded
+func (m Model) viewRepoFailures() string {
+	if len(m.repoFailures) == 0 {
+		return ""
+	}
+
+	slugs := make([]string, len(m.repoFailures))
+	for i := range m.repoFailures {
+		slugs[i] = m.repoFailures[i].RepoSlug()
+	}
+	noun := "repos"
+	if len(slugs) == 1 {
+		noun = "repo"
+	}
+	return m.styles.LogWarning.Render(fmt.Sprintf("⚠ %d %s failed to load: %s", len(slugs), noun, strings.Join(slugs, ", "))) + "\n"
+}
+
+// repoFailed reports whether spec failed to load on the last multi-repo fetch
+func (m Model) repoFailed(spec config.RepoSpec) bool {
+	for i := range m.repoFailures {
+		if m.repoFailures[i].Owner == spec.Owner && m.repoFailures[i].Repo == spec.Repo {
+			return true
+		}
+	}
+	return false
+}
+
 // viewMultiRepoRuns renders the aggregated run list from multiple repos (v0.8)
 func (m Model) viewMultiRepoRuns() string {
 	var b strings.Builder
+	b.WriteString(m.viewRepoFailures())
 
 	// Calculate max width for repo slug
 	maxRepoLen := 0
@@ -407,7 +934,7 @@ func (m Model) viewMultiRepoRuns() string {
 		b.WriteString(m.styles.Separator.Render(" • "))
 
 		// Workflow name and run number
-		b.WriteString(m.styles.JobName.Render(run.Name))
+		b.WriteString(m.styles.JobName.Render(run.DisplayName()))
 		b.WriteString(m.styles.Separator.Render(" #"))
 		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%d", run.RunNumber)))
 		b.WriteString("  ")
@@ -417,7 +944,7 @@ func (m Model) viewMultiRepoRuns() string {
 		b.WriteString("  ")
 
 		// Time ago
-		b.WriteString(m.styles.Dim.Render(timeAgo(run.UpdatedAt)))
+		b.WriteString(m.styles.Dim.Render(TimeAgo(run.UpdatedAt)))
 
 		b.WriteString("\n")
 	}
@@ -425,8 +952,35 @@ func (m Model) viewMultiRepoRuns() string {
 	return b.String()
 }
 
-// timeAgo returns a human-readable relative time string
-func timeAgo(t time.Time) string {
+// timeWindowLabel describes the --since/--until window, or "" if none is set
+func (m Model) timeWindowLabel() string {
+	const layout = "2006-01-02 15:04"
+	switch {
+	case !m.config.Since.IsZero() && !m.config.Until.IsZero():
+		return fmt.Sprintf("%s → %s", m.config.Since.Format(layout), m.config.Until.Format(layout))
+	case !m.config.Since.IsZero():
+		return "since " + m.config.Since.Format(layout)
+	case !m.config.Until.IsZero():
+		return "until " + m.config.Until.Format(layout)
+	default:
+		return ""
+	}
+}
+
+// jobStatusFilterLabel returns a short display label for a job filter
+func jobStatusFilterLabel(filter string) string {
+	switch filter {
+	case gh.ConclusionFailure:
+		return "failed"
+	case gh.StatusInProgress:
+		return "in progress"
+	default:
+		return "all"
+	}
+}
+
+// TimeAgo returns a human-readable relative time string
+func TimeAgo(t time.Time) string {
 	d := time.Since(t)
 
 	switch {
@@ -453,6 +1007,72 @@ func timeAgo(t time.Time) string {
 	}
 }
 
+// humanizeBytes formats a byte count using binary units (KB = 1024 bytes)
+func humanizeBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit && exp < 3; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// downloadMessage describes download progress, e.g. "Downloading build.zip 42%",
+// or the bytes received so far when the total size is unknown
+func downloadMessage(name string, done, total int64) string {
+	if total > 0 {
+		return fmt.Sprintf("Downloading %s %d%%", name, done*100/total)
+	}
+	return fmt.Sprintf("Downloading %s %s", name, humanizeBytes(done))
+}
+
+// formatExpiry describes when an artifact expires relative to now, e.g.
+// "expires in 3 days" or "expired 2 days ago". Returns "" if unknown.
+func formatExpiry(expiresAt time.Time, expired bool, now time.Time) string {
+	if expiresAt.IsZero() {
+		if expired {
+			return "expired"
+		}
+		return ""
+	}
+
+	d := expiresAt.Sub(now)
+	switch {
+	case d <= 0:
+		return "expired " + formatSpan(-d) + " ago"
+	case expired:
+		// GitHub already removed it; trust that over a skewed clock
+		return "expired"
+	default:
+		return "expires in " + formatSpan(d)
+	}
+}
+
+// formatSpan formats a non-negative duration in its largest whole unit
+func formatSpan(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case d < time.Minute:
+		return "less than a minute"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour")
+	default:
+		return plural(int(d.Hours()/24), "day")
+	}
+}
+
 // formatDuration formats a duration as a human-readable string
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -529,7 +1149,7 @@ func (m Model) viewJobsList(width int) string {
 
 	b.WriteString("Jobs:\n")
 
-	for i, job := range m.jobs {
+	for i, job := range m.visibleJobs() {
 		// Icon
 		b.WriteString("  ")
 		b.WriteString(m.styles.StatusIconStyled(job.Status, job.Conclusion))
@@ -568,6 +1188,10 @@ func (m Model) viewJobDetailsPanel(width int) string {
 	b.WriteString(m.styles.StatusIconStyled(job.Status, job.Conclusion))
 	b.WriteString(" ")
 	b.WriteString(m.styles.JobName.Render(job.Name))
+	if note := StatusNote(job.Status, job.Conclusion); note != "" {
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render(note))
+	}
 	b.WriteString("\n")
 
 	// Job metadata
@@ -579,13 +1203,13 @@ func (m Model) viewJobDetailsPanel(width int) string {
 
 	if job.StartedAt != nil {
 		b.WriteString("  Started: ")
-		b.WriteString(m.styles.Dim.Render(job.StartedAt.Format("15:04:05")))
+		b.WriteString(m.styles.Dim.Render(m.config.FormatTime(*job.StartedAt, "15:04:05 MST")))
 		b.WriteString("\n")
 	}
 
 	if job.CompletedAt != nil {
 		b.WriteString("  Completed: ")
-		b.WriteString(m.styles.Dim.Render(job.CompletedAt.Format("15:04:05")))
+		b.WriteString(m.styles.Dim.Render(m.config.FormatTime(*job.CompletedAt, "15:04:05 MST")))
 		b.WriteString("\n")
 	}
 
@@ -623,14 +1247,30 @@ func (m Model) viewBranchSelection() string {
 
 	b.WriteString("Select Branch\n\n")
 
+	branches := m.filteredBranches()
+	if len(m.branches) > 0 {
+		b.WriteString("  Filter: ")
+		if m.branchFilter == "" {
+			b.WriteString(m.styles.Dim.Render("type to filter"))
+		} else {
+			b.WriteString(m.branchFilter + "_")
+			b.WriteString(m.styles.Dim.Render(fmt.Sprintf("  (%d of %d)", len(branches), len(m.branches))))
+		}
+		b.WriteString("\n\n")
+	}
+
 	if len(m.branches) == 0 {
 		b.WriteString("  ")
 		b.WriteString(m.styles.Dim.Render("Loading branches"))
 		b.WriteString(" ")
 		b.WriteString(m.spinner.View())
 		b.WriteString("\n")
+	} else if len(branches) == 0 {
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render("No branches match"))
+		b.WriteString("\n")
 	} else {
-		for i, branch := range m.branches {
+		for i, branch := range branches {
 			if i == m.selectedBranchIndex {
 				b.WriteString(m.styles.Selected.Render("→ "))
 			} else {
@@ -654,7 +1294,64 @@ func (m Model) viewBranchSelection() string {
 		}
 	}
 
-	// Footer
+	// Footer
+	b.WriteString("\n")
+	b.WriteString(m.viewFooter())
+
+	return b.String()
+}
+
+// viewQuickJump shows the fuzzy finder: the query and the jobs or runs
+// matching it, best match first
+func (m Model) viewQuickJump() string {
+	var b strings.Builder
+
+	if m.quickJumpRuns {
+		b.WriteString("Jump to Run\n\n")
+	} else {
+		b.WriteString("Jump to Job\n\n")
+	}
+
+	items := m.quickJumpItems()
+	matches := m.quickJumpMatches()
+	b.WriteString("  > ")
+	if m.quickJumpQuery == "" {
+		b.WriteString(m.styles.Dim.Render("type to search"))
+	} else {
+		b.WriteString(m.quickJumpQuery + "_")
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("  (%d of %d)", len(matches), len(items))))
+	}
+	b.WriteString("\n\n")
+
+	if len(matches) == 0 {
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render("Nothing matches"))
+		b.WriteString("\n")
+	}
+
+	// Scroll so the cursor stays on screen
+	start, end := 0, len(matches)
+	if maxRows := m.height - 8; maxRows > 0 && len(matches) > maxRows {
+		start = max(0, m.quickJumpCursor-maxRows+1)
+		end = start + maxRows
+	}
+	jobs := m.visibleJobs()
+	for i := start; i < end; i++ {
+		if i == m.quickJumpCursor {
+			b.WriteString(m.styles.Selected.Render("→ "))
+		} else {
+			b.WriteString("  ")
+		}
+		if index := matches[i]; m.quickJumpRuns {
+			b.WriteString(m.styles.StatusIconStyled(m.runs[index].Status, m.runs[index].Conclusion))
+		} else {
+			b.WriteString(m.styles.StatusIconStyled(jobs[index].Status, jobs[index].Conclusion))
+		}
+		b.WriteString(" ")
+		b.WriteString(items[matches[i]])
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
 	b.WriteString(m.viewFooter())
 
@@ -678,11 +1375,16 @@ func (m Model) viewArtifactSelection() string {
 
 			b.WriteString(artifact.Name)
 			b.WriteString(" (")
-			b.WriteString(fmt.Sprintf("%d bytes", artifact.SizeInBytes))
+			b.WriteString(humanizeBytes(artifact.SizeInBytes))
 			b.WriteString(")")
 
-			if artifact.Expired {
-				b.WriteString(" EXPIRED")
+			if expiry := formatExpiry(artifact.ExpiresAt, artifact.Expired, time.Now()); expiry != "" {
+				b.WriteString(" ")
+				if strings.HasPrefix(expiry, "expired") {
+					b.WriteString(m.styles.StatusFailure.Render(expiry))
+				} else {
+					b.WriteString(m.styles.Dim.Render(expiry))
+				}
 			}
 
 			b.WriteString("\n")
@@ -764,13 +1466,13 @@ func (m Model) viewJobDetails() string {
 
 		if job.StartedAt != nil {
 			b.WriteString("Started: ")
-			b.WriteString(m.styles.Dim.Render(job.StartedAt.Format("2006-01-02 15:04:05")))
+			b.WriteString(m.styles.Dim.Render(m.config.FormatTime(*job.StartedAt, "2006-01-02 15:04:05 MST")))
 			b.WriteString("\n")
 		}
 
 		if job.CompletedAt != nil {
 			b.WriteString("Completed: ")
-			b.WriteString(m.styles.Dim.Render(job.CompletedAt.Format("2006-01-02 15:04:05")))
+			b.WriteString(m.styles.Dim.Render(m.config.FormatTime(*job.CompletedAt, "2006-01-02 15:04:05 MST")))
 			b.WriteString("\n")
 		}
 
@@ -822,6 +1524,9 @@ func (m Model) viewLogViewer() string {
 	if m.multiJobMode {
 		b.WriteString(m.styles.Branch.Render(fmt.Sprintf(" [MULTI: %d jobs]", len(m.multiJobIDs))))
 	}
+	if step := m.logRunningStep; m.logStreaming && step != nil {
+		b.WriteString(m.styles.StatusInProgress.Render(fmt.Sprintf("  ▶ Running step %d: %s", step.Number, step.Name)))
+	}
 	b.WriteString("\n\n")
 
 	if m.logContent == "" {
@@ -831,11 +1536,16 @@ func (m Model) viewLogViewer() string {
 		b.WriteString(m.spinner.View())
 		b.WriteString("\n")
 	} else {
-		// Split log content into lines
-		lines := strings.Split(strings.TrimSuffix(m.logContent, "\n"), "\n")
+		// Lines are split once when the log loads
+		lines := m.logLines
+
+		if m.logTruncated > 0 {
+			b.WriteString(m.styles.LogWarning.Render(fmt.Sprintf("Log truncated, showing last %d lines", len(lines))))
+			b.WriteString("\n")
+		}
 
 		// Calculate visible area (reserve space for header and footer)
-		maxLines := m.height - 10 // Reserve more space for streaming indicator
+		maxLines := m.logVisibleLines()
 
 		// Ensure scroll offset is valid
 		if m.logScrollOffset < 0 {
@@ -852,28 +1562,76 @@ func (m Model) viewLogViewer() string {
 			end = len(lines)
 		}
 
-		for i := start; i < end; i++ {
-			line := lines[i]
+		// Line number gutter, sized for the largest line number
+		gutterDigits := 0
+		if m.logLineNumbers {
+			gutterDigits = len(strconv.Itoa(len(lines) + m.logTruncated))
+		}
 
-			// Truncate long lines to fit width first
-			if len(line) > m.width-4 {
-				line = line[:m.width-7] + "..."
+		maxWidth := m.logTextWidth()
+
+		// Match density strip down the right edge when the log scrolls
+		var density []string
+		if m.showsSearchDensity() {
+			density = m.searchDensity(end - start)
+		}
+
+		// Horizontal pan, clamped so the longest visible line still shows
+		hOffset := min(m.logHScrollOffset, m.maxLogHScroll(maxWidth))
+
+		// Matches are sorted by line; start at the first visible one
+		matchIdx := sort.Search(len(m.logSearchMatches), func(j int) bool {
+			return m.logSearchMatches[j].line >= start
+		})
+
+		for i := start; i < end; i++ {
+			raw := lines[i]
+			line := raw
+			if m.logHideTimestamps {
+				line = stripLogTimestamp(line)
+			}
+			if hOffset > 0 {
+				line = panLogLine(line, hOffset)
 			}
+			shift := len(raw) - len(line) // Bytes stripped from the front
 
-			// Apply syntax highlighting (v0.6)
-			line = m.viewLogLine(line)
+			// Truncate long lines to fit width (minus gutter) first
+			visible := len(line)
+			if len(line) > maxWidth && maxWidth > 3 {
+				visible = maxWidth - 3
+			}
 
-			// Highlight search matches (overlay on top of syntax highlighting)
-			if m.logSearchTerm != "" {
-				lowerLine := strings.ToLower(line)
-				lowerTerm := strings.ToLower(m.logSearchTerm)
-				if strings.Contains(lowerLine, lowerTerm) {
-					// Simple highlighting - could be improved
-					line = strings.ReplaceAll(line, m.logSearchTerm, m.styles.StatusFailure.Render(m.logSearchTerm))
+			// Search match spans on this line, mapped onto the displayed text
+			var spans []logSpan
+			for ; matchIdx < len(m.logSearchMatches) && m.logSearchMatches[matchIdx].line == i; matchIdx++ {
+				match := m.logSearchMatches[matchIdx]
+				span := logSpan{
+					start:   max(match.start-shift, 0),
+					end:     min(match.end-shift, visible),
+					current: matchIdx == m.logSearchIndex,
+				}
+				if span.start < span.end {
+					spans = append(spans, span)
 				}
 			}
 
-			b.WriteString(line)
+			// Apply syntax highlighting (v0.6) with matches overlaid
+			rendered := m.renderLogLine(line[:visible], spans)
+			if visible < len(line) {
+				rendered += "..."
+			}
+
+			if gutterDigits > 0 {
+				b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%*d │ ", gutterDigits, i+1+m.logTruncated)))
+			}
+			b.WriteString(rendered)
+			if density != nil {
+				if pad := maxWidth - lipgloss.Width(rendered); pad > 0 {
+					b.WriteString(strings.Repeat(" ", pad))
+				}
+				b.WriteString(" ")
+				b.WriteString(density[i-start])
+			}
 			b.WriteString("\n")
 		}
 
@@ -890,6 +1648,17 @@ func (m Model) viewLogViewer() string {
 			statusParts = append(statusParts, fmt.Sprintf("Line %d/%d (%.0f%%)", m.logScrollOffset+1, len(lines), scrollPercent))
 		}
 
+		if hOffset > 0 {
+			statusParts = append(statusParts, fmt.Sprintf("Col %d", hOffset+1))
+		}
+
+		// The step whose header is at or above the top line
+		if steps := len(m.logStepLines); steps > 1 {
+			if current := sort.SearchInts(m.logStepLines, m.logScrollOffset+1); current > 0 {
+				statusParts = append(statusParts, fmt.Sprintf("Step %d/%d", current, steps))
+			}
+		}
+
 		if m.logStreaming {
 			statusParts = append(statusParts, "STREAMING")
 		}
@@ -920,27 +1689,112 @@ func (m Model) viewLogViewer() string {
 	return b.String()
 }
 
-func (m Model) viewHelp() string {
-	var b strings.Builder
+// helpSection is a titled group of shortcuts in the help view
+type helpSection struct {
+	title string
+	keys  []key.Binding
+}
+
+// helpScreens names the screens that have their own help, tailored to the
+// keys that work there
+var helpScreens = map[State]string{
+	StateLogViewer:         "Log Viewer",
+	StateLogFilter:         "Log Filter",
+	StateMultiJobSelect:    "Multi-Job Selection",
+	StateCompareSelect:     "Compare Runs",
+	StateCompareView:       "Run Comparison",
+	StateAnnotations:       "Annotations",
+	StateRunList:           "Run History",
+	StateFailureSummary:    "Failed Steps",
+	StateArtifactSelection: "Artifacts",
+	StateWorkflowViewer:    "Workflow",
+	StateStatusFilter:      "Status Filter",
+	StateDashboard:         "Dashboard",
+}
+
+// helpSections returns the shortcuts for the screen help was opened from.
+// Screens without their own help get every shortcut.
+func (m Model) helpSections() []helpSection {
+	back := m.keys.Escape
+	back.SetHelp("esc", "back")
+	general := helpSection{
+		title: "General",
+		keys:  []key.Binding{m.keys.TimeZone, m.keys.Quit, m.keys.Help},
+	}
 
-	b.WriteString("Keyboard Shortcuts\n\n")
+	switch m.helpReturnState {
+	case StateLogViewer:
+		closeLogs := m.keys.Logs
+		closeLogs.SetHelp("l", "close logs")
+		viewer := []key.Binding{m.keys.Up, m.keys.Down, m.keys.ScrollLeft, m.keys.ScrollRight, m.keys.PrevStep, m.keys.NextStep, m.keys.LogFilter, m.keys.LogHighlight, m.keys.LineNumbers, m.keys.Timestamps}
+		if m.multiJobMode {
+			viewer = append(viewer, m.keys.LogViewToggle)
+		}
+		return []helpSection{
+			{title: "Log Viewer", keys: viewer},
+			{title: "Search", keys: []key.Binding{m.keys.Search, m.keys.NextMatch, m.keys.PrevMatch}},
+			{title: "Actions", keys: []key.Binding{m.keys.LogSave, m.keys.LogGist, m.keys.LogMulti, closeLogs}},
+			general,
+		}
+	case StateLogFilter:
+		apply := m.keys.Enter
+		apply.SetHelp("enter/F", "apply filter")
+		toggle := m.keys.Space
+		toggle.SetHelp("space", "toggle step")
+		return []helpSection{{title: "Log Filter", keys: []key.Binding{m.keys.Up, m.keys.Down, toggle, apply, back}}, general}
+	case StateMultiJobSelect:
+		follow := m.keys.Enter
+		follow.SetHelp("enter/m", "follow selected jobs")
+		toggle := m.keys.Space
+		toggle.SetHelp("space", "toggle job")
+		return []helpSection{{title: "Multi-Job Selection", keys: []key.Binding{m.keys.Up, m.keys.Down, toggle, follow, back}}, general}
+	case StateCompareSelect:
+		pick := m.keys.Enter
+		pick.SetHelp("enter/c", "pick run")
+		return []helpSection{{title: "Compare Runs", keys: []key.Binding{m.keys.Up, m.keys.Down, pick, back}}, general}
+	case StateCompareView:
+		closeCompare := m.keys.LogCompare
+		closeCompare.SetHelp("c", "close comparison")
+		return []helpSection{{title: "Run Comparison", keys: []key.Binding{m.keys.Up, m.keys.Down, closeCompare, back}}, general}
+	case StateAnnotations:
+		return []helpSection{{title: "Annotations", keys: []key.Binding{m.keys.Up, m.keys.Down, m.keys.Annotations, back}}, general}
+	case StateRunList:
+		return []helpSection{{title: "Run History", keys: []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.QuickJump, m.keys.RunList, back}}, general}
+	case StateFailureSummary:
+		open := m.keys.Enter
+		open.SetHelp("enter", "step logs")
+		return []helpSection{{title: "Failed Steps", keys: []key.Binding{m.keys.Up, m.keys.Down, open, m.keys.Failures, back}}, general}
+	case StateArtifactSelection:
+		download := m.keys.Enter
+		download.SetHelp("enter", "download")
+		return []helpSection{{title: "Artifacts", keys: []key.Binding{m.keys.Up, m.keys.Down, download}}, general}
+	case StateWorkflowViewer:
+		return []helpSection{{title: "Workflow", keys: []key.Binding{m.keys.Up, m.keys.Down, m.keys.Open}}, general}
+	case StateStatusFilter:
+		return []helpSection{{title: "Status Filter", keys: []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Filter}}, general}
+	case StateDashboard:
+		list := m.keys.Dashboard
+		list.SetHelp("d", "list runs across repos")
+		return []helpSection{{title: "Dashboard", keys: []key.Binding{m.keys.Up, m.keys.Down, m.keys.Enter, list, m.keys.Refresh, m.keys.Watch}}, general}
+	}
 
 	// Group shortcuts by category
-	sections := []struct {
-		title string
-		keys  []key.Binding
-	}{
+	return []helpSection{
 		{
 			title: "Navigation",
-			keys:  []key.Binding{m.keys.Up, m.keys.Down, m.keys.NextRun, m.keys.PrevRun},
+			keys:  []key.Binding{m.keys.Up, m.keys.Down, m.keys.NextRun, m.keys.PrevRun, m.keys.RunList, m.keys.Attempt, m.keys.QuickJump, m.keys.NextRepo, m.keys.PrevRepo},
 		},
 		{
 			title: "Actions",
-			keys:  []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.Enter},
+			keys:  []key.Binding{m.keys.Refresh, m.keys.Watch, m.keys.Open, m.keys.OpenChange, m.keys.CopyCommand, m.keys.Enter, m.keys.Rerun, m.keys.RerunJob, m.keys.CancelRun, m.keys.Deployments},
 		},
 		{
 			title: "Filtering & Selection",
-			keys:  []key.Binding{m.keys.BranchSelect, m.keys.Filter, m.keys.Logs, m.keys.Search, m.keys.Workflow, m.keys.Artifacts},
+			keys:  []key.Binding{m.keys.BranchSelect, m.keys.Filter, m.keys.EventFilter, m.keys.JobFilter, m.keys.JobSort, m.keys.Logs, m.keys.TailLogs, m.keys.Search, m.keys.Workflow, m.keys.Artifacts, m.keys.Annotations, m.keys.Failures, m.keys.LogMulti, m.keys.LogCompare},
+		},
+		{
+			title: "Log Viewer",
+			keys:  []key.Binding{m.keys.LogFilter, m.keys.LogSave, m.keys.LogGist, m.keys.LogHighlight, m.keys.LineNumbers, m.keys.Timestamps, m.keys.ScrollLeft, m.keys.ScrollRight, m.keys.PrevStep, m.keys.NextStep},
 		},
 		{
 			title: "Search Navigation",
@@ -948,10 +1802,22 @@ func (m Model) viewHelp() string {
 		},
 		{
 			title: "General",
-			keys:  []key.Binding{m.keys.Quit, m.keys.Help},
+			keys:  []key.Binding{m.keys.Compact, m.keys.TimeZone, m.keys.Quit, m.keys.Help},
 		},
 	}
+}
+
+func (m Model) viewHelp() string {
+	var b strings.Builder
+
+	screen, tailored := helpScreens[m.helpReturnState]
+	if tailored {
+		b.WriteString("Keyboard Shortcuts: " + screen + "\n\n")
+	} else {
+		b.WriteString("Keyboard Shortcuts\n\n")
+	}
 
+	sections := m.helpSections()
 	for _, section := range sections {
 		b.WriteString(m.styles.Bold.Render(section.title))
 		b.WriteString("\n")
@@ -970,7 +1836,25 @@ func (m Model) viewHelp() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("Press any key to exit help\n")
+	// What the status icons mean, as they're drawn with the current settings
+	if !tailored {
+		b.WriteString(m.styles.Bold.Render("Status"))
+		b.WriteString("\n")
+		for _, entry := range statusLegend {
+			var conclusion *string
+			if entry.conclusion != "" {
+				conclusion = &entry.conclusion
+			}
+			b.WriteString("  ")
+			b.WriteString(m.styles.StatusIconStyled(entry.status, conclusion))
+			b.WriteString("  ")
+			b.WriteString(entry.meaning)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Press any key to go back\n")
 
 	return b.String()
 }
@@ -1043,21 +1927,171 @@ func (m Model) viewWorkflowViewer() string {
 
 // viewLogLine applies syntax highlighting to a log line (v0.6)
 func (m Model) viewLogLine(line string) string {
+	return m.renderLogLine(line, nil)
+}
+
+// logSpan is a search match within a displayed log line
+type logSpan struct {
+	start, end int  // byte offsets within the displayed line
+	current    bool // the match n/N is on
+}
+
+// renderLogLine applies syntax highlighting to a log line and overlays the
+// search match spans, which must be sorted and within the line
+func (m Model) renderLogLine(line string, spans []logSpan) string {
+	style, styled := m.logLineStyle(line)
+	if len(spans) == 0 {
+		if styled == 0 {
+			return line
+		}
+		return style.Render(line[:styled]) + line[styled:]
+	}
+
+	var b strings.Builder
+	// plain writes line[from:to] with the syntax style where it applies
+	plain := func(from, to int) {
+		if from < styled {
+			b.WriteString(style.Render(line[from:min(to, styled)]))
+			from = min(to, styled)
+		}
+		b.WriteString(line[from:to])
+	}
+
+	pos := 0
+	for _, span := range spans {
+		if span.start < pos {
+			continue
+		}
+		if span.start > pos {
+			plain(pos, span.start)
+		}
+		matchStyle := m.styles.SearchMatch
+		if span.current {
+			matchStyle = m.styles.SearchCurrent
+		}
+		b.WriteString(matchStyle.Render(line[span.start:span.end]))
+		pos = span.end
+	}
+	if pos < len(line) {
+		plain(pos, len(line))
+	}
+	return b.String()
+}
+
+// logVisibleLines returns how many log lines the log viewer shows at once
+func (m Model) logVisibleLines() int {
+	maxLines := m.height - 10 // Reserve more space for streaming indicator
+	if m.logTruncated > 0 {
+		maxLines-- // Truncation banner
+	}
+	return maxLines
+}
+
+// showsSearchDensity reports whether the log viewer draws the match density
+// strip, which it does when there are matches and the log scrolls
+func (m Model) showsSearchDensity() bool {
+	return len(m.logSearchMatches) > 0 && len(m.logLines) > m.logVisibleLines()
+}
+
+// logTextWidth returns the columns the log viewer has for log text, after
+// the line number gutter and the search density strip
+func (m Model) logTextWidth() int {
+	width := m.width - 4
+	if m.logLineNumbers {
+		width -= len(strconv.Itoa(len(m.logLines)+m.logTruncated)) + 3
+	}
+	if m.showsSearchDensity() {
+		width -= 2
+	}
+	return width
+}
+
+// maxLogHScroll returns how far the log viewer can pan right before the
+// longest visible line, at the given display width, runs out
+func (m Model) maxLogHScroll(width int) int {
+	start := max(m.logScrollOffset, 0)
+	end := min(start+max(m.height-10, 1), len(m.logLines))
+	longest := 0
+	for i := start; i < end; i++ {
+		line := m.logLines[i]
+		if m.logHideTimestamps {
+			line = stripLogTimestamp(line)
+		}
+		longest = max(longest, len(line))
+	}
+	return max(longest-width, 0)
+}
+
+// panLogLine drops the first cols bytes of a log line for horizontal
+// scrolling, moving forward to the next rune boundary if cols splits one
+func panLogLine(line string, cols int) string {
+	if cols >= len(line) {
+		return ""
+	}
+	for cols < len(line) && !utf8.RuneStart(line[cols]) {
+		cols++
+	}
+	return line[cols:]
+}
+
+// logLineStyle picks the syntax highlighting style for a log line and how
+// many leading bytes it applies to (0 = leave the line unstyled)
+func (m Model) logLineStyle(line string) (lipgloss.Style, int) {
 	if !m.logSyntaxEnabled {
-		return line
+		return lipgloss.Style{}, 0
+	}
+
+	kind, styled := classifyLogLine(line)
+	switch kind {
+	case logLineError:
+		return m.styles.LogError, styled
+	case logLineWarning:
+		return m.styles.LogWarning, styled
+	case logLineGroup:
+		return m.styles.LogGroup, styled
+	case logLineStep:
+		return m.styles.LogStep, styled
+	case logLineCommand:
+		return m.styles.LogCommand, styled
+	case logLineTimestamp:
+		return m.styles.LogTimestamp, styled
+	default:
+		return lipgloss.Style{}, 0
+	}
+}
+
+// logLineKind is what syntax highlighting treats a log line as
+type logLineKind int
+
+const (
+	logLinePlain logLineKind = iota
+	logLineError
+	logLineWarning
+	logLineGroup
+	logLineStep
+	logLineCommand
+	logLineTimestamp
+)
+
+// classifyLogLine returns the kind of a log line for highlighting and how
+// many leading bytes the highlight covers
+func classifyLogLine(line string) (logLineKind, int) {
+	// Step separators cimon adds when combining a job's step logs
+	if isStepHeader(line) {
+		return logLineStep, len(line)
 	}
 
 	// GitHub Actions error/warning markers
 	if strings.Contains(line, "##[error]") {
-		return m.styles.LogError.Render(line)
+		return logLineError, len(line)
 	}
 	if strings.Contains(line, "##[warning]") {
-		return m.styles.LogWarning.Render(line)
+		return logLineWarning, len(line)
 	}
 
 	// Group markers
 	if strings.HasPrefix(line, "##[group]") || strings.HasPrefix(line, "##[endgroup]") {
-		return m.styles.LogGroup.Render(line)
+		return logLineGroup, len(line)
 	}
 
 	// Common error patterns
@@ -1067,14 +2101,14 @@ func (m Model) viewLogLine(line string) string {
 		strings.Contains(lowerLine, "failed:") ||
 		strings.Contains(lowerLine, "exception:") ||
 		strings.Contains(lowerLine, "panic:") {
-		return m.styles.LogError.Render(line)
+		return logLineError, len(line)
 	}
 
 	// Common warning patterns
 	if strings.Contains(lowerLine, "warning:") ||
 		strings.Contains(lowerLine, "warn:") ||
 		strings.Contains(lowerLine, "deprecated:") {
-		return m.styles.LogWarning.Render(line)
+		return logLineWarning, len(line)
 	}
 
 	// Command execution patterns
@@ -1083,15 +2117,89 @@ func (m Model) viewLogLine(line string) string {
 		strings.HasPrefix(trimmed, "+ ") ||
 		strings.HasPrefix(trimmed, "$ ") ||
 		strings.HasPrefix(trimmed, "> ") {
-		return m.styles.LogCommand.Render(line)
+		return logLineCommand, len(line)
 	}
 
 	// Timestamp at start of line (e.g., "2024-01-15T12:34:56.789Z")
-	if len(line) >= 24 && line[4] == '-' && line[7] == '-' && line[10] == 'T' {
-		return m.styles.LogTimestamp.Render(line[:24]) + line[24:]
+	if hasLogTimestamp(line) {
+		return logLineTimestamp, 24
+	}
+
+	return logLinePlain, 0
+}
+
+// isStepHeader reports whether a log line is one of the "=== 3_Build ==="
+// separators between steps in a combined job log
+func isStepHeader(line string) bool {
+	return len(line) > len("===  ===") && strings.HasPrefix(line, "=== ") && strings.HasSuffix(line, " ===")
+}
+
+// densityGlyphs shade the match density strip from sparse to dense
+var densityGlyphs = []string{"░", "▒", "▓", "█"}
+
+// searchDensity buckets the whole log into rows and returns a glyph per row
+// showing how many search matches fall in it; the current match's row is
+// highlighted
+func (m Model) searchDensity(rows int) []string {
+	if rows <= 0 || len(m.logLines) == 0 {
+		return nil
+	}
+
+	// Matches past the end belong to lines that are gone
+	rowOf := func(line int) int {
+		return min(line*rows/len(m.logLines), rows-1)
+	}
+	counts := make([]int, rows)
+	peak := 0
+	for _, match := range m.logSearchMatches {
+		if match.line >= len(m.logLines) {
+			continue
+		}
+		row := rowOf(match.line)
+		counts[row]++
+		peak = max(peak, counts[row])
+	}
+
+	currentRow := -1
+	if m.logSearchIndex >= 0 && m.logSearchIndex < len(m.logSearchMatches) {
+		if line := m.logSearchMatches[m.logSearchIndex].line; line < len(m.logLines) {
+			currentRow = rowOf(line)
+		}
+	}
+
+	strip := make([]string, rows)
+	for row, count := range counts {
+		if count == 0 {
+			strip[row] = " "
+			continue
+		}
+		glyph := densityGlyphs[(count*len(densityGlyphs)-1)/peak]
+		if row == currentRow {
+			glyph = m.styles.SearchCurrent.Render(glyph)
+		} else {
+			glyph = m.styles.SearchMatch.Render(glyph)
+		}
+		strip[row] = glyph
 	}
+	return strip
+}
+
+// hasLogTimestamp reports whether a log line starts with a GitHub timestamp
+// (e.g., "2024-01-15T12:34:56.789Z")
+func hasLogTimestamp(line string) bool {
+	return len(line) >= 24 && line[4] == '-' && line[7] == '-' && line[10] == 'T'
+}
 
-	return line
+// stripLogTimestamp removes a leading GitHub timestamp and the space after it
+func stripLogTimestamp(line string) string {
+	if !hasLogTimestamp(line) {
+		return line
+	}
+	// Fractional seconds vary in length, so cut at the first space if close by
+	if idx := strings.IndexByte(line, ' '); idx >= 20 && idx <= 35 {
+		return line[idx+1:]
+	}
+	return line[24:]
 }
 
 // viewLogFilter displays the log filter step selection (v0.6)
@@ -1227,7 +2335,7 @@ func (m Model) viewCompareSelect() string {
 		// Show first selection
 		if m.compareRunIdx1 >= 0 && m.compareRunIdx1 < len(m.runs) {
 			run := m.runs[m.compareRunIdx1]
-			b.WriteString(fmt.Sprintf("  First: #%d %s\n\n", run.RunNumber, run.Name))
+			b.WriteString(fmt.Sprintf("  First: #%d %s\n\n", run.RunNumber, run.DisplayName()))
 		}
 	}
 
@@ -1254,13 +2362,13 @@ func (m Model) viewCompareSelect() string {
 			b.WriteString(" ")
 
 			// Run info
-			runLabel := fmt.Sprintf("#%d %s", run.RunNumber, run.Name)
+			runLabel := fmt.Sprintf("#%d %s", run.RunNumber, run.DisplayName())
 			if len(runLabel) > m.width-20 {
 				runLabel = runLabel[:m.width-23] + "..."
 			}
 			b.WriteString(runLabel)
 			b.WriteString(" ")
-			b.WriteString(m.styles.Dim.Render(timeAgo(run.UpdatedAt)))
+			b.WriteString(m.styles.Dim.Render(TimeAgo(run.UpdatedAt)))
 			b.WriteString("\n")
 		}
 	}
@@ -1351,3 +2459,233 @@ func (m Model) viewCompareView() string {
 
 	return b.String()
 }
+
+// annotationLine is one rendered row of the annotations panel
+type annotationLine struct {
+	text  string
+	level string // annotation level, or "" for job headings
+}
+
+// annotationLines lays out annotations grouped under their job names
+func annotationLines(annotations []gh.Annotation) []annotationLine {
+	var lines []annotationLine
+	lastJob := int64(-1)
+	for _, a := range annotations {
+		if a.JobID != lastJob {
+			lastJob = a.JobID
+			lines = append(lines, annotationLine{text: a.JobName})
+		}
+		text := "  " + a.Message
+		if loc := a.Location(); loc != "" {
+			text = "  " + loc + ": " + a.Message
+		}
+		if a.Title != "" {
+			text += " (" + a.Title + ")"
+		}
+		// Multi-line messages (stack traces, diffs) are cut to their first line
+		if i := strings.Index(text, "\n"); i >= 0 {
+			text = text[:i] + " ..."
+		}
+		lines = append(lines, annotationLine{text: text, level: a.AnnotationLevel})
+	}
+	return lines
+}
+
+// viewAnnotations lists the run's check-run annotations grouped by job
+func (m Model) viewAnnotations() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewHeader())
+	b.WriteString("\n")
+
+	b.WriteString("Annotations")
+	b.WriteString(m.styles.Dim.Render(fmt.Sprintf(" (%d)", len(m.annotations))))
+	b.WriteString("\n\n")
+
+	lines := annotationLines(m.annotations)
+	if len(lines) == 0 {
+		b.WriteString("  No annotations for this run\n")
+	} else {
+		maxLines := m.height - 10
+
+		start := m.annotationScrollOff
+		end := start + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for i := start; i < end; i++ {
+			line := lines[i]
+			text := line.text
+			if len(text) > m.width-4 && m.width > 7 {
+				text = text[:m.width-7] + "..."
+			}
+
+			switch line.level {
+			case "":
+				text = m.styles.Bold.Render(text)
+			case gh.AnnotationFailure:
+				text = m.styles.LogError.Render(text)
+			case gh.AnnotationWarning:
+				text = m.styles.LogWarning.Render(text)
+			default:
+				text = m.styles.Dim.Render(text)
+			}
+			b.WriteString(text)
+			b.WriteString("\n")
+		}
+
+		if len(lines) > maxLines {
+			scrollPercent := float64(m.annotationScrollOff) / float64(len(lines)-maxLines) * 100
+			b.WriteString(fmt.Sprintf("\n[Line %d/%d (%.0f%%)]", m.annotationScrollOff+1, len(lines), scrollPercent))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString("  ")
+	b.WriteString(m.styles.HelpKey.Render("↑/↓"))
+	b.WriteString(" scroll  ")
+	b.WriteString(m.styles.HelpKey.Render("e/esc"))
+	b.WriteString(" exit\n")
+
+	return b.String()
+}
+
+// runListNameWidth caps the workflow name column of the run history table
+const runListNameWidth = 24
+
+// viewRunList shows the loaded runs as a table, one row per run
+func (m Model) viewRunList() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewHeader())
+	b.WriteString("\n")
+
+	b.WriteString("Run History")
+	b.WriteString(m.styles.Dim.Render(fmt.Sprintf(" (%d)", len(m.runs))))
+	b.WriteString("\n\n")
+
+	// Size the columns to their widest values
+	now := time.Now()
+	var numberWidth, nameWidth, branchWidth, actorWidth int
+	for i := range m.runs {
+		run := &m.runs[i]
+		numberWidth = max(numberWidth, len(fmt.Sprintf("#%d", run.RunNumber)))
+		nameWidth = max(nameWidth, min(len(run.DisplayName()), runListNameWidth))
+		branchWidth = max(branchWidth, len(run.HeadBranch))
+		actorWidth = max(actorWidth, len(run.ActorLogin()))
+	}
+
+	// Scroll so the cursor stays on screen
+	cursor := min(m.runListCursor, len(m.runs)-1)
+	start, end := 0, len(m.runs)
+	if maxRows := m.height - 10; maxRows > 0 && len(m.runs) > maxRows {
+		start = max(0, cursor-maxRows+1)
+		end = start + maxRows
+	}
+
+	for i := start; i < end; i++ {
+		run := &m.runs[i]
+		if i == cursor {
+			b.WriteString(m.styles.Selected.Render("→ "))
+		} else {
+			b.WriteString("  ")
+		}
+
+		name := run.DisplayName()
+		if len(name) > runListNameWidth {
+			name = name[:runListNameWidth-3] + "..."
+		}
+
+		b.WriteString(m.styles.StatusIconStyled(run.Status, run.Conclusion))
+		b.WriteString(" ")
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%-*s", numberWidth, fmt.Sprintf("#%d", run.RunNumber))))
+		b.WriteString("  ")
+		b.WriteString(m.styles.JobName.Render(fmt.Sprintf("%-*s", nameWidth, name)))
+		b.WriteString("  ")
+		b.WriteString(m.styles.Branch.Render(fmt.Sprintf("%-*s", branchWidth, run.HeadBranch)))
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("%-*s", actorWidth, run.ActorLogin())))
+		b.WriteString("  ")
+		b.WriteString(m.styles.JobDuration.Render(fmt.Sprintf("%7s", formatDuration(run.WallDuration(now)))))
+		b.WriteString("  ")
+		b.WriteString(m.styles.Dim.Render(TimeAgo(run.UpdatedAt)))
+		if i == m.selectedRunIndex {
+			b.WriteString(m.styles.Dim.Render("  (current)"))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.viewStatusMessage())
+
+	b.WriteString("\n")
+	b.WriteString("  ")
+	b.WriteString(m.styles.HelpKey.Render("↑/↓"))
+	b.WriteString(" navigate  ")
+	b.WriteString(m.styles.HelpKey.Render("enter"))
+	b.WriteString(" open run  ")
+	b.WriteString(m.styles.HelpKey.Render(m.keys.QuickJump.Help().Key))
+	b.WriteString(" jump to  ")
+	b.WriteString(m.styles.HelpKey.Render("g/esc"))
+	b.WriteString(" back\n")
+
+	return b.String()
+}
+
+// viewFailureSummary lists the failed steps of every failed job in the run
+func (m Model) viewFailureSummary() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewHeader())
+	b.WriteString("\n")
+
+	rows := m.failedSteps()
+	b.WriteString("Failed Steps")
+	b.WriteString(m.styles.Dim.Render(fmt.Sprintf(" (%d)", len(rows))))
+	b.WriteString("\n\n")
+
+	if len(rows) == 0 {
+		b.WriteString("  No failed steps in this run\n")
+	}
+
+	// Scroll so the cursor stays on screen
+	cursor := min(m.failureCursor, len(rows)-1)
+	start, end := 0, len(rows)
+	if maxRows := m.height - 10; maxRows > 0 && len(rows) > maxRows {
+		start = max(0, cursor-maxRows+1)
+		end = start + maxRows
+	}
+
+	for i := start; i < end; i++ {
+		row := rows[i]
+		if i == cursor {
+			b.WriteString(m.styles.Selected.Render("→ "))
+		} else {
+			b.WriteString("  ")
+		}
+
+		b.WriteString(m.styles.StatusIconStyled(row.Job.Status, row.Job.Conclusion))
+		b.WriteString(" ")
+		b.WriteString(m.styles.JobName.Render(row.Job.Name))
+		b.WriteString("  ")
+		if row.Step.Number == 0 {
+			b.WriteString(m.styles.Dim.Render("no failed step, enter opens the job log"))
+		} else {
+			b.WriteString(m.styles.LogError.Render(fmt.Sprintf("step %d: %s", row.Step.Number, row.Step.Name)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.viewStatusMessage())
+
+	b.WriteString("\n")
+	b.WriteString("  ")
+	b.WriteString(m.styles.HelpKey.Render("↑/↓"))
+	b.WriteString(" navigate  ")
+	b.WriteString(m.styles.HelpKey.Render("enter"))
+	b.WriteString(" step log  ")
+	b.WriteString(m.styles.HelpKey.Render("!/esc"))
+	b.WriteString(" back\n")
+
+	return b.String()
+}
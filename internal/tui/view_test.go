@@ -2,8 +2,16 @@ package tui
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lance0/cimon/internal/config"
+	"github.com/lance0/cimon/internal/gh"
 )
 
 func TestTimeAgo(t *testing.T) {
@@ -25,9 +33,57 @@ func TestTimeAgo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create a time that is tt.duration ago
 			testTime := time.Now().Add(-tt.duration)
-			got := timeAgo(testTime)
+			got := TimeAgo(testTime)
 			if got != tt.want {
-				t.Errorf("timeAgo() = %q, want %q", got, tt.want)
+				t.Errorf("TimeAgo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024 / 2, "1.5 GB"},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeBytes(tt.bytes); got != tt.want {
+			t.Errorf("humanizeBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestFormatExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		expired   bool
+		want      string
+	}{
+		{"unknown", time.Time{}, false, ""},
+		{"unknown but expired", time.Time{}, true, "expired"},
+		{"days left", now.Add(3*24*time.Hour + time.Hour), false, "expires in 3 days"},
+		{"one hour left", now.Add(90 * time.Minute), false, "expires in 1 hour"},
+		{"minutes left", now.Add(30 * time.Second), false, "expires in less than a minute"},
+		{"expired days ago", now.Add(-2 * 24 * time.Hour), true, "expired 2 days ago"},
+		{"past expiry not yet flagged", now.Add(-5 * time.Minute), false, "expired 5 minutes ago"},
+		{"flagged before expiry time", now.Add(time.Hour), true, "expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExpiry(tt.expiresAt, tt.expired, now); got != tt.want {
+				t.Errorf("formatExpiry() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -62,6 +118,8 @@ func TestStatusIcon(t *testing.T) {
 	failure := "failure"
 	cancelled := "cancelled"
 	skipped := "skipped"
+	startupFailure := "startup_failure"
+	stale := "stale"
 
 	tests := []struct {
 		name       string
@@ -75,7 +133,9 @@ func TestStatusIcon(t *testing.T) {
 		{"failure", "completed", &failure, IconFailure},
 		{"cancelled", "completed", &cancelled, IconWarning},
 		{"skipped", "completed", &skipped, IconSkipped},
-		{"completed no conclusion", "completed", nil, IconSkipped},
+		{"completed no conclusion", "completed", nil, IconNotRun},
+		{"startup failure", "completed", &startupFailure, IconFailure},
+		{"stale", "completed", &stale, IconWarning},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +148,114 @@ func TestStatusIcon(t *testing.T) {
 	}
 }
 
+func TestStatusSymbols(t *testing.T) {
+	conclusion := func(c string) *string { return &c }
+	states := []struct {
+		name       string
+		status     string
+		conclusion *string
+		symbol     string
+	}{
+		{"queued", gh.StatusQueued, nil, SymbolQueued},
+		{"in progress", gh.StatusInProgress, nil, SymbolInProgress},
+		{"success", gh.StatusCompleted, conclusion(gh.ConclusionSuccess), SymbolSuccess},
+		{"failure", gh.StatusCompleted, conclusion(gh.ConclusionFailure), SymbolFailure},
+		{"cancelled", gh.StatusCompleted, conclusion(gh.ConclusionCancelled), SymbolWarning},
+		{"skipped", gh.StatusCompleted, conclusion(gh.ConclusionSkipped), SymbolSkipped},
+		{"neutral", gh.StatusCompleted, conclusion(gh.ConclusionNeutral), SymbolNeutral},
+		{"not run", gh.StatusCompleted, nil, SymbolNotRun},
+	}
+
+	for _, colorEnabled := range []bool{true, false} {
+		styles := DefaultStyles(colorEnabled)
+		styles.Symbols = true
+
+		icons := map[string]string{}
+		badges := map[string]string{}
+		for _, st := range states {
+			icon := styles.StatusIconStyled(st.status, st.conclusion)
+			if !strings.Contains(icon, st.symbol) || lipgloss.Width(icon) != symbolWidth {
+				t.Errorf("color=%v %s: icon = %q, want %q padded to %d", colorEnabled, st.name, icon, st.symbol, symbolWidth)
+			}
+			if prev, ok := icons[icon]; ok {
+				t.Errorf("color=%v: %s and %s share the icon %q", colorEnabled, prev, st.name, icon)
+			}
+			icons[icon] = st.name
+
+			badge := styles.StatusBadge(st.status, st.conclusion)
+			if !strings.Contains(badge, "[") || !strings.Contains(badge, "]") {
+				t.Errorf("color=%v %s: badge = %q, want it bracketed", colorEnabled, st.name, badge)
+			}
+			if prev, ok := badges[badge]; ok {
+				t.Errorf("color=%v: %s and %s share the badge %q", colorEnabled, prev, st.name, badge)
+			}
+			badges[badge] = st.name
+		}
+	}
+
+	// Without --symbols the icons are unchanged
+	styles := DefaultStyles(false)
+	if got := styles.StatusIconStyled(gh.StatusCompleted, conclusion(gh.ConclusionFailure)); got != IconFailure {
+		t.Errorf("StatusIconStyled() = %q, want %q", got, IconFailure)
+	}
+}
+
+func TestQuietConclusionsRenderDistinctly(t *testing.T) {
+	conclusion := func(c string) *string { return &c }
+	quiet := []struct {
+		name       string
+		conclusion *string
+		icon       string
+		note       string
+	}{
+		{"skipped", conclusion(gh.ConclusionSkipped), IconSkipped, "skipped"},
+		{"neutral", conclusion(gh.ConclusionNeutral), IconNeutral, "neutral"},
+		{"not run", nil, IconNotRun, "not run"},
+	}
+
+	styles := DefaultStyles(false)
+	seen := map[string]string{}
+	for _, q := range quiet {
+		icon := StatusIcon(gh.StatusCompleted, q.conclusion)
+		badge := styles.StatusBadge(gh.StatusCompleted, q.conclusion)
+		if icon != q.icon {
+			t.Errorf("%s: icon = %q, want %q", q.name, icon, q.icon)
+		}
+		if note := StatusNote(gh.StatusCompleted, q.conclusion); note != q.note {
+			t.Errorf("%s: note = %q, want %q", q.name, note, q.note)
+		}
+		for _, rendered := range []string{icon, badge} {
+			if prev, ok := seen[rendered]; ok {
+				t.Errorf("%s and %s both render as %q", prev, q.name, rendered)
+			}
+			seen[rendered] = q.name
+		}
+	}
+	if note := StatusNote(gh.StatusCompleted, conclusion(gh.ConclusionFailure)); note != "" {
+		t.Errorf("failed job note = %q, want none", note)
+	}
+
+	// The job list labels them, and help explains every icon
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.jobs = []gh.Job{
+		{Name: "deploy", Status: gh.StatusCompleted, Conclusion: conclusion(gh.ConclusionSkipped)},
+		{Name: "report", Status: gh.StatusCompleted, Conclusion: conclusion(gh.ConclusionNeutral)},
+		{Name: "cleanup", Status: gh.StatusCompleted},
+	}
+	jobs := m.viewJobs()
+	for _, want := range []string{"deploy  skipped", "report  neutral", "cleanup  not run"} {
+		if !strings.Contains(jobs, want) {
+			t.Errorf("job list missing %q:\n%s", want, jobs)
+		}
+	}
+	help := m.viewHelp()
+	for _, entry := range statusLegend {
+		if !strings.Contains(help, entry.meaning) {
+			t.Errorf("help legend missing %q", entry.meaning)
+		}
+	}
+}
+
 func TestGetErrorHint(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -106,11 +274,20 @@ func TestGetErrorHint(t *testing.T) {
 		{"502 error", errors.New("502 Bad Gateway"), "temporarily unavailable"},
 		{"503 error", errors.New("503 Service Unavailable"), "temporarily unavailable"},
 		{"unknown error", errors.New("something weird happened"), "retry"},
+		{"SAML error", &gh.SAMLError{Err: errors.New("HTTP 403")}, "gh auth refresh"},
+		{"secondary rate limit", &gh.SecondaryRateLimitError{Err: errors.New("HTTP 403"), RetryAfter: time.Minute}, "wait 1m0s"},
+		{"typed 403 rate limit", &gh.RateLimitError{Err: errors.New("HTTP 403")}, "rate limit"},
+		{"wrapped SAML error", fmt.Errorf("failed after 3 retries: %w", &gh.SAMLError{Err: errors.New("HTTP 403")}), "SSO"},
+		{"missing token permission", &gh.PermissionError{Err: errors.New("HTTP 403"), Required: "Actions: read"}, "grant Actions: read"},
+		{"wrapped token permission", fmt.Errorf("failed after 3 retries: %w", &gh.PermissionError{Err: errors.New("HTTP 403")}), "fine-grained token"},
+		{"no workflows", &gh.NoRunsError{Repo: "o/r", Branch: "main", NoWorkflows: true}, ".github/workflows"},
+		{"no runs on branch", &gh.NoRunsError{Repo: "o/r", Branch: "main"}, "none have run on this branch"},
+		{"no runs, cause unknown", fmt.Errorf("%w (couldn't list workflows to say why: HTTP 502)", gh.ErrNoRuns), "No runs found on this branch"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := Model{err: tt.err}
+			m := Model{err: tt.err, config: &config.Config{}}
 			got := m.getErrorHint()
 			if tt.wantIn == "" {
 				if got != "" {
@@ -125,6 +302,18 @@ func TestGetErrorHint(t *testing.T) {
 	}
 }
 
+func TestGetErrorHintGitLab(t *testing.T) {
+	m := Model{config: &config.Config{Provider: config.ProviderGitLab}}
+	m.err = &gh.NoRunsError{Repo: "acme/web", Branch: "main", NoWorkflows: true}
+	if hint := m.getErrorHint(); !strings.Contains(hint, ".gitlab-ci.yml") {
+		t.Errorf("no CI hint = %q, want .gitlab-ci.yml", hint)
+	}
+	m.err = &gh.NoRunsError{Repo: "acme/web", Branch: "main"}
+	if hint := m.getErrorHint(); !strings.Contains(hint, "no pipeline has run") {
+		t.Errorf("no runs hint = %q", hint)
+	}
+}
+
 func containsIgnoreCase(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && findIgnoreCase(s, substr)))
@@ -192,3 +381,395 @@ func TestDefaultStyles(t *testing.T) {
 		t.Fatal("DefaultStyles(false) returned nil")
 	}
 }
+
+func TestStripLogTimestamp(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"2024-01-15T12:34:56.1234567Z Run make test", "Run make test"},
+		{"2024-01-15T12:34:56.789Z ok", "ok"},
+		{"no timestamp here", "no timestamp here"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := stripLogTimestamp(tt.line); got != tt.want {
+			t.Errorf("stripLogTimestamp(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestLogViewerGutter(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateLogViewer
+	m.width = 40
+	m.height = 30
+	m.logSyntaxEnabled = false
+	m.setLogContent("2024-01-15T12:34:56.1234567Z first\n" + strings.Repeat("x", 100) + "\n")
+
+	m.logLineNumbers = true
+	m.logHideTimestamps = true
+	out := m.View()
+
+	if !strings.Contains(out, "1 │ first") {
+		t.Errorf("expected numbered line without timestamp, got:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "2 │ ") && len([]rune(line)) > m.width-4 {
+			t.Errorf("gutter line exceeds width %d: %q", m.width-4, line)
+		}
+	}
+
+	m.logLineNumbers = false
+	m.logHideTimestamps = false
+	out = m.View()
+	if strings.Contains(out, "1 │ ") || !strings.Contains(out, "2024-01-15T12:34:56.1234567Z first") {
+		t.Errorf("expected raw lines without gutter, got:\n%s", out)
+	}
+}
+
+func TestPanLogLine(t *testing.T) {
+	tests := []struct {
+		line string
+		cols int
+		want string
+	}{
+		{"abcdef", 2, "cdef"},
+		{"abc", 5, ""},
+		{"aé b", 2, " b"}, // lands inside é, skips to the next rune
+	}
+	for _, tt := range tests {
+		if got := panLogLine(tt.line, tt.cols); got != tt.want {
+			t.Errorf("panLogLine(%q, %d) = %q, want %q", tt.line, tt.cols, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkLogViewerLargeLog(b *testing.B) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateLogViewer
+	m.width = 120
+	m.height = 50
+	m.setLogContent(strings.Repeat("2024-01-15T12:34:56.1234567Z building target with a long log line\n", 200000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.View()
+	}
+}
+
+func TestViewAnnotations(t *testing.T) {
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.state = StateAnnotations
+	m.width = 80
+	m.height = 30
+	m.annotations = []gh.Annotation{
+		{JobID: 1, JobName: "test", Path: "foo_test.go", StartLine: 12, AnnotationLevel: gh.AnnotationFailure, Message: "expected 1, got 2\nfull diff"},
+		{JobID: 1, JobName: "test", AnnotationLevel: gh.AnnotationWarning, Message: "Node 16 is deprecated"},
+		{JobID: 2, JobName: "lint", Path: "main.go", StartLine: 3, EndLine: 5, AnnotationLevel: gh.AnnotationNotice, Message: "unused variable"},
+	}
+
+	out := m.View()
+	for _, want := range []string{"Annotations (3)", "test\n", "  foo_test.go:12: expected 1, got 2 ...", "  Node 16 is deprecated", "lint\n", "  main.go:3-5: unused variable"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("annotations view missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "full diff") {
+		t.Errorf("multi-line message not cut to its first line:\n%s", out)
+	}
+
+	m.annotations = nil
+	if out := m.View(); !strings.Contains(out, "No annotations for this run") {
+		t.Errorf("expected empty message, got:\n%s", out)
+	}
+}
+
+func TestDownloadMessage(t *testing.T) {
+	tests := []struct {
+		done, total int64
+		want        string
+	}{
+		{42, 100, "Downloading build.zip 42%"},
+		{100, 100, "Downloading build.zip 100%"},
+		{5 * 1024 * 1024, -1, "Downloading build.zip 5.0 MB"},
+	}
+	for _, tt := range tests {
+		if got := downloadMessage("build.zip", tt.done, tt.total); got != tt.want {
+			t.Errorf("downloadMessage(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestMatchSpans(t *testing.T) {
+	tests := []struct {
+		line, term string
+		want       [][2]int
+	}{
+		{"no match here", "error", nil},
+		{"Error: build failed, error code 2", "error", [][2]int{{0, 5}, {21, 26}}},
+		{"aaaa", "aa", [][2]int{{0, 2}, {2, 4}}},
+		// Lowercasing İ changes its byte length, so offsets come from the original line
+		{"İstanbul ERROR", "error", [][2]int{{10, 15}}},
+	}
+
+	for _, tt := range tests {
+		if got := matchSpans(tt.line, tt.term); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchSpans(%q, %q) = %v, want %v", tt.line, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestRenderLogLineHighlightsMatchedText(t *testing.T) {
+	m := NewModel(&config.Config{NoColor: true}, nil)
+	m.styles.SearchMatch = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	m.styles.SearchCurrent = lipgloss.NewStyle().Transform(func(s string) string { return "<" + s + ">" })
+	m.height = 20
+	m.width = 80
+	m.setLogContent("step one\nGot an Error here\nERROR again\nfine\n")
+	m.logSearchTerm = "error"
+	m.findSearchMatches()
+
+	if len(m.logSearchMatches) != 2 || m.logSearchMatches[1] != (logMatch{line: 2, start: 0, end: 5}) {
+		t.Fatalf("matches = %v", m.logSearchMatches)
+	}
+
+	// The matched text keeps its own case, and the current match stands out
+	got := m.renderLogLine(m.logLines[1], []logSpan{{start: 7, end: 12, current: true}})
+	if got != "Got an <Error> here" {
+		t.Errorf("current match rendered as %q", got)
+	}
+	got = m.renderLogLine(m.logLines[2], []logSpan{{start: 0, end: 5}})
+	if got != "[ERROR] again" {
+		t.Errorf("match rendered as %q", got)
+	}
+}
+
+func TestSearchDensity(t *testing.T) {
+	m := NewModel(&config.Config{NoColor: true}, nil)
+	var log strings.Builder
+	for i := 0; i < 100; i++ {
+		if i < 10 {
+			log.WriteString("error\n")
+		} else if i == 90 {
+			log.WriteString("one error\n")
+		} else {
+			log.WriteString("ok\n")
+		}
+	}
+	m.setLogContent(log.String())
+	m.logSearchTerm = "error"
+	m.findSearchMatches()
+
+	strip := m.searchDensity(10)
+	if len(strip) != 10 || strip[0] != "█" || strip[9] != "░" || strip[5] != " " {
+		t.Errorf("searchDensity(10) = %q", strip)
+	}
+
+	// The strip is drawn beside the visible lines when the log scrolls
+	m.state = StateLogViewer
+	m.width = 60
+	m.height = 20
+	if view := m.viewLogViewer(); !strings.Contains(view, "█") {
+		t.Errorf("log viewer missing density strip:\n%s", view)
+	}
+}
+
+func TestSearchDensityAfterShorterLog(t *testing.T) {
+	m := NewModel(&config.Config{NoColor: true}, nil)
+	m.width = 60
+	m.height = 20
+	m.state = StateLogViewer
+	m.setLogContent(strings.Repeat("ok\n", 999) + "error\n")
+	m.logSearchTerm = "error"
+	m.findSearchMatches()
+
+	// Another job's short log brings no matches over from the long one
+	m.openLogs(2, 0)
+	updated, _ := m.Update(LogLoadedMsg{Content: "error\nok\n"})
+	m = updated.(Model)
+	if m.logSearchTerm != "" || len(m.logSearchMatches) != 0 {
+		t.Fatalf("search kept across logs: term %q, %d matches", m.logSearchTerm, len(m.logSearchMatches))
+	}
+	_ = m.View()
+
+	// Matches that outlive their lines don't index past the strip
+	m.logSearchMatches = []logMatch{{line: 1000}}
+	if strip := m.searchDensity(20); len(strip) != 20 {
+		t.Errorf("searchDensity(20) = %q", strip)
+	}
+
+	// New content re-runs an active search
+	m.logSearchTerm = "error"
+	m.setLogContent("ok\nerror\n")
+	if len(m.logSearchMatches) != 1 || m.logSearchMatches[0].line != 1 {
+		t.Errorf("matches = %+v, want line 1", m.logSearchMatches)
+	}
+}
+
+func TestRunSummaryShowsBranchForAllBranches(t *testing.T) {
+	run := &gh.WorkflowRun{ID: 1, RunNumber: 4, Status: gh.StatusQueued, Event: "push", HeadBranch: "feature-x"}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", NoColor: true}, nil)
+	m.run = run
+	if summary := m.viewRunSummary(); !strings.Contains(summary, "on feature-x") {
+		t.Errorf("all-branches summary missing run branch:\n%s", summary)
+	}
+	if header := m.viewHeader(); !strings.Contains(header, "all branches") {
+		t.Errorf("header = %q, want all branches", header)
+	}
+
+	m = NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "feature-x", NoColor: true}, nil)
+	m.run = run
+	if summary := m.viewRunSummary(); strings.Contains(summary, "on feature-x") {
+		t.Errorf("single-branch summary repeats the branch:\n%s", summary)
+	}
+}
+
+func TestRunSummaryTypicalDuration(t *testing.T) {
+	now := time.Now()
+	success := gh.ConclusionSuccess
+	run := gh.WorkflowRun{ID: 9, Name: "CI", Status: gh.StatusInProgress, CreatedAt: now.Add(-3 * time.Minute)}
+	runs := []gh.WorkflowRun{run}
+	for i, minutes := range []int{6, 7, 8} {
+		start := now.Add(-time.Duration(i+1) * time.Hour)
+		runs = append(runs, gh.WorkflowRun{ID: int64(i), Name: "CI", Status: gh.StatusCompleted, Conclusion: &success,
+			CreatedAt: start, UpdatedAt: start.Add(time.Duration(minutes) * time.Minute)})
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.run = &run
+	m.runs = runs
+	if summary := m.viewRunSummary(); !strings.Contains(summary, "running 3m") || !strings.Contains(summary, "(typ. ~7m)") {
+		t.Errorf("summary missing the estimate:\n%s", summary)
+	}
+
+	// Too little history for an estimate
+	m.runs = runs[:3]
+	if summary := m.viewRunSummary(); strings.Contains(summary, "typ.") {
+		t.Errorf("summary estimates from two runs:\n%s", summary)
+	}
+}
+
+func TestRunSummaryBlockingRun(t *testing.T) {
+	now := time.Now()
+	queued := gh.WorkflowRun{ID: 2, RunNumber: 41, Name: "Deploy", Status: gh.StatusQueued, CreatedAt: now}
+	running := gh.WorkflowRun{ID: 1, RunNumber: 40, Name: "Deploy", Status: gh.StatusInProgress, CreatedAt: now.Add(-time.Minute)}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.run = &queued
+	m.runs = []gh.WorkflowRun{queued, running}
+	if summary := m.viewRunSummary(); !strings.Contains(summary, "may be queued behind run #40") {
+		t.Errorf("summary missing the concurrency hint:\n%s", summary)
+	}
+
+	m.runs = []gh.WorkflowRun{queued}
+	if summary := m.viewRunSummary(); strings.Contains(summary, "queued behind") {
+		t.Errorf("summary hints with nothing running:\n%s", summary)
+	}
+}
+
+func TestRunSparkline(t *testing.T) {
+	success, failure := gh.ConclusionSuccess, gh.ConclusionFailure
+	var runs []gh.WorkflowRun
+	for i := 0; i < 12; i++ {
+		conclusion := &success
+		if i == 1 {
+			conclusion = &failure
+		}
+		runs = append(runs, gh.WorkflowRun{ID: int64(i), Status: gh.StatusCompleted, Conclusion: conclusion})
+	}
+	runs[0] = gh.WorkflowRun{ID: 0, Status: gh.StatusInProgress}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.runs = runs
+	if spark := m.runSparkline(m.runs); spark != "" {
+		t.Errorf("sparkline without color = %q, want none", spark)
+	}
+
+	m.colorEnabled = true
+	want := IconInProgress + IconFailure + strings.Repeat(IconSuccess, sparklineRuns-2)
+	if spark := m.runSparkline(m.runs); spark != want {
+		t.Errorf("sparkline = %q, want %q", spark, want)
+	}
+	if header := m.viewHeader(); !strings.Contains(header, want) {
+		t.Errorf("header missing sparkline:\n%s", header)
+	}
+}
+
+func TestRunTimingSummary(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	at := func(minutes int) *time.Time {
+		t := start.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", NoColor: true}, nil)
+	m.run = &gh.WorkflowRun{ID: 5, Status: gh.StatusCompleted, CreatedAt: start, UpdatedAt: *at(6)}
+	if got := m.runTimingSummary(); got != "" {
+		t.Errorf("summary without jobs = %q, want none", got)
+	}
+
+	m.jobs = []gh.Job{
+		{Name: "test (linux)", StartedAt: at(0), CompletedAt: at(5)},
+		{Name: "test (macos)", StartedAt: at(0), CompletedAt: at(6)},
+	}
+	if got, want := m.runTimingSummary(), "CPU: 11m across 2 jobs, wall: 6m"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+
+	updated, _ := m.Update(RunTimingLoadedMsg{RunID: 5, Timing: &gh.RunTiming{
+		Billable:      map[string]gh.BillableTiming{"UBUNTU": {TotalMS: 300000, Jobs: 1}},
+		RunDurationMS: 420000,
+	}})
+	m = updated.(Model)
+	if m.runTiming != nil {
+		t.Fatal("timing for a run that wasn't requested was kept")
+	}
+	m.runTimingID = 5
+	updated, _ = m.Update(RunTimingLoadedMsg{RunID: 5, Timing: &gh.RunTiming{
+		Billable:      map[string]gh.BillableTiming{"UBUNTU": {TotalMS: 300000, Jobs: 1}},
+		RunDurationMS: 420000,
+	}})
+	m = updated.(Model)
+	if got, want := m.runTimingSummary(), "CPU: 11m across 2 jobs, wall: 7m • billable: UBUNTU 5m"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if summary := m.viewRunSummary(); !strings.Contains(summary, "billable: UBUNTU 5m") {
+		t.Errorf("run summary missing timing:\n%s", summary)
+	}
+}
+
+func TestCompactLayout(t *testing.T) {
+	success := gh.ConclusionSuccess
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+	m := NewModel(&config.Config{Owner: "o", Repo: "r", Branch: "main", Compact: true}, nil)
+	m.state = StateReady
+	m.width = 30
+	m.run = &gh.WorkflowRun{ID: 1, Name: "CI", RunNumber: 7, Status: gh.StatusCompleted, Conclusion: &success, UpdatedAt: end}
+	m.jobs = []gh.Job{
+		{ID: 1, Name: "lint", Status: gh.StatusCompleted, Conclusion: &success, StartedAt: &start, CompletedAt: &end},
+		{ID: 2, Name: "integration tests on every supported platform", Status: gh.StatusInProgress},
+	}
+
+	compact := m.View()
+	if !strings.Contains(compact, "lint 1m 30s") || !strings.Contains(compact, "integration tests on ev...\n") {
+		t.Errorf("compact jobs not one tight line each:\n%s", compact)
+	}
+	if strings.Contains(compact, "\n\n") {
+		t.Errorf("compact view has blank lines:\n%s", compact)
+	}
+	if got := m.jobListTop(); got != 2 {
+		t.Errorf("jobListTop() = %d, want 2 (header, summary)", got)
+	}
+
+	// z switches back to the spacious default
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = *updated.(*Model)
+	if spacious := m.View(); m.compact || strings.Count(spacious, "\n") <= strings.Count(compact, "\n") {
+		t.Errorf("z didn't restore the spacious layout:\n%s", spacious)
+	}
+}